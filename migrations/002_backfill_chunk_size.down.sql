@@ -0,0 +1,5 @@
+-- Rollback migration: Remove backfill worker columns added in 002_backfill_chunk_size.up.sql
+
+DROP INDEX IF EXISTS idx_backfill_jobs_pending;
+
+ALTER TABLE backfill_jobs DROP COLUMN IF EXISTS chunk_size;
@@ -0,0 +1,7 @@
+-- Backfill worker support: per-job chunk size and claim ordering
+
+ALTER TABLE backfill_jobs
+    ADD COLUMN chunk_size INTEGER NOT NULL DEFAULT 1000 CHECK (chunk_size > 0);
+
+-- Workers claim the oldest pending job first
+CREATE INDEX idx_backfill_jobs_pending ON backfill_jobs(created_at ASC) WHERE status = 'pending';
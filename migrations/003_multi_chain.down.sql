@@ -0,0 +1,44 @@
+-- Rollback migration: Remove chain scoping added in 003_multi_chain.up.sql
+-- Fails if the same address is registered on more than one chain.
+
+DROP VIEW IF EXISTS contract_stats;
+CREATE VIEW contract_stats AS
+SELECT 
+    c.address as contract_address,
+    c.name as contract_name,
+    COUNT(e.id) as total_events,
+    MAX(e.block_number) as latest_event_block,
+    c.current_block,
+    c.current_block - COALESCE(MAX(e.block_number), c.start_block) as indexer_delay,
+    MAX(e.timestamp) as last_event_time,
+    c.updated_at
+FROM contracts c
+LEFT JOIN events e ON c.address = e.contract_address
+GROUP BY c.address, c.name, c.current_block, c.start_block, c.updated_at;
+
+ALTER TABLE block_cache DROP CONSTRAINT IF EXISTS block_cache_pkey;
+ALTER TABLE block_cache DROP COLUMN IF EXISTS chain_id;
+ALTER TABLE block_cache ADD PRIMARY KEY (block_number);
+
+ALTER TABLE backfill_jobs DROP CONSTRAINT IF EXISTS backfill_jobs_contract_fkey;
+ALTER TABLE backfill_jobs DROP COLUMN IF EXISTS chain_id;
+
+ALTER TABLE indexer_state DROP CONSTRAINT IF EXISTS indexer_state_contract_fkey;
+ALTER TABLE indexer_state DROP CONSTRAINT IF EXISTS indexer_state_chain_contract_key;
+ALTER TABLE indexer_state DROP CONSTRAINT IF EXISTS indexer_state_pkey;
+ALTER TABLE indexer_state DROP COLUMN IF EXISTS chain_id;
+ALTER TABLE indexer_state ADD PRIMARY KEY (contract_address);
+
+DROP INDEX IF EXISTS idx_events_chain_contract_block;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_chain_tx_log_key;
+ALTER TABLE events DROP COLUMN IF EXISTS chain_id;
+ALTER TABLE events ADD CONSTRAINT events_transaction_hash_log_index_key UNIQUE (transaction_hash, log_index);
+
+ALTER TABLE contracts DROP CONSTRAINT IF EXISTS contracts_chain_address_key;
+ALTER TABLE contracts DROP COLUMN IF EXISTS chain_id;
+ALTER TABLE contracts ADD CONSTRAINT contracts_address_key UNIQUE (address);
+
+ALTER TABLE indexer_state ADD CONSTRAINT indexer_state_contract_address_fkey
+    FOREIGN KEY (contract_address) REFERENCES contracts(address) ON DELETE CASCADE;
+ALTER TABLE backfill_jobs ADD CONSTRAINT backfill_jobs_contract_address_fkey
+    FOREIGN KEY (contract_address) REFERENCES contracts(address) ON DELETE CASCADE;
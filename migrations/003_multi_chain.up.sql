@@ -0,0 +1,60 @@
+-- Multi-chain support: scope contracts, events, indexer state, backfill jobs
+-- and the block cache by chain_id. Existing rows are assigned to chain 1.
+
+-- Foreign keys on contracts(address) must go before the unique constraint changes
+ALTER TABLE indexer_state DROP CONSTRAINT IF EXISTS indexer_state_contract_address_fkey;
+ALTER TABLE backfill_jobs DROP CONSTRAINT IF EXISTS backfill_jobs_contract_address_fkey;
+
+-- contracts: the same address may be monitored on several chains
+ALTER TABLE contracts ADD COLUMN chain_id BIGINT NOT NULL DEFAULT 1 CHECK (chain_id > 0);
+ALTER TABLE contracts DROP CONSTRAINT IF EXISTS contracts_address_key;
+ALTER TABLE contracts ADD CONSTRAINT contracts_chain_address_key UNIQUE (chain_id, address);
+
+-- events: transaction hashes are only unique within a chain
+ALTER TABLE events ADD COLUMN chain_id BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_transaction_hash_log_index_key;
+ALTER TABLE events ADD CONSTRAINT events_chain_tx_log_key UNIQUE (chain_id, transaction_hash, log_index);
+CREATE INDEX idx_events_chain_contract_block ON events(chain_id, contract_address, block_number DESC);
+
+-- indexer_state: key by (chain_id, contract_address) and add the columns the
+-- indexer service tracks per contract
+ALTER TABLE indexer_state
+    ADD COLUMN chain_id BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS id BIGSERIAL,
+    ADD COLUMN IF NOT EXISTS last_block_hash VARCHAR(66) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_processed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS error_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_error TEXT,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+ALTER TABLE indexer_state DROP CONSTRAINT IF EXISTS indexer_state_pkey;
+ALTER TABLE indexer_state ADD PRIMARY KEY (id);
+ALTER TABLE indexer_state ADD CONSTRAINT indexer_state_chain_contract_key UNIQUE (chain_id, contract_address);
+ALTER TABLE indexer_state ADD CONSTRAINT indexer_state_contract_fkey
+    FOREIGN KEY (chain_id, contract_address) REFERENCES contracts(chain_id, address) ON DELETE CASCADE;
+
+-- backfill_jobs: a job targets a contract on a specific chain
+ALTER TABLE backfill_jobs ADD COLUMN chain_id BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE backfill_jobs ADD CONSTRAINT backfill_jobs_contract_fkey
+    FOREIGN KEY (chain_id, contract_address) REFERENCES contracts(chain_id, address) ON DELETE CASCADE;
+
+-- block_cache: block numbers overlap between chains
+ALTER TABLE block_cache ADD COLUMN chain_id BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE block_cache DROP CONSTRAINT IF EXISTS block_cache_pkey;
+ALTER TABLE block_cache ADD PRIMARY KEY (chain_id, block_number);
+
+-- contract_stats view joins on the chain as well as the address
+CREATE OR REPLACE VIEW contract_stats AS
+SELECT 
+    c.address as contract_address,
+    c.name as contract_name,
+    COUNT(e.id) as total_events,
+    MAX(e.block_number) as latest_event_block,
+    c.current_block,
+    c.current_block - COALESCE(MAX(e.block_number), c.start_block) as indexer_delay,
+    MAX(e.timestamp) as last_event_time,
+    c.updated_at,
+    c.chain_id
+FROM contracts c
+LEFT JOIN events e ON c.chain_id = e.chain_id AND c.address = e.contract_address
+GROUP BY c.chain_id, c.address, c.name, c.current_block, c.start_block, c.updated_at;
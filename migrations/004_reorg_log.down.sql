@@ -0,0 +1,3 @@
+-- Rollback migration: Remove reorg audit log added in 004_reorg_log.up.sql
+
+DROP TABLE IF EXISTS reorg_log;
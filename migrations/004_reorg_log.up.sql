@@ -0,0 +1,17 @@
+-- Audit log of chain reorganizations handled by the indexer
+
+CREATE TABLE reorg_log (
+    id BIGSERIAL PRIMARY KEY,
+    chain_id BIGINT NOT NULL,
+    fork_point BIGINT NOT NULL,
+    detected_at_block BIGINT NOT NULL,
+    depth BIGINT NOT NULL CHECK (depth > 0),
+    contracts_affected INTEGER NOT NULL DEFAULT 0,
+    events_removed BIGINT NOT NULL DEFAULT 0,
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reorg_log_chain_detected ON reorg_log(chain_id, detected_at DESC);
+
+COMMENT ON TABLE reorg_log IS 'Chain reorganizations detected and rolled back by the indexer';
+COMMENT ON COLUMN reorg_log.depth IS 'Number of blocks on the abandoned branch that were rolled back';
@@ -0,0 +1,5 @@
+-- Rollback migration: Remove event finality added in 005_event_finality.up.sql
+
+DROP INDEX IF EXISTS idx_events_pending;
+
+ALTER TABLE events DROP COLUMN IF EXISTS finality;
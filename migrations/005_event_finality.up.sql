@@ -0,0 +1,10 @@
+-- Pending/confirmed finality tier for events indexed at the chain head
+
+ALTER TABLE events
+    ADD COLUMN finality VARCHAR(10) NOT NULL DEFAULT 'confirmed'
+    CHECK (finality IN ('pending', 'confirmed'));
+
+-- Promotion scans only the (small) set of pending events
+CREATE INDEX idx_events_pending ON events(chain_id, contract_address, block_number) WHERE finality = 'pending';
+
+COMMENT ON COLUMN events.finality IS 'pending until the block has the contract''s required confirmations, then confirmed';
@@ -0,0 +1,4 @@
+-- Rollback migration: Remove webhook tables added in 006_webhooks.up.sql
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
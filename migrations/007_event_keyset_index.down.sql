@@ -0,0 +1,4 @@
+-- Rollback migration: Remove keyset indexes added in 007_event_keyset_index.up.sql
+
+DROP INDEX IF EXISTS idx_events_chain_contract_keyset;
+DROP INDEX IF EXISTS idx_events_keyset;
@@ -0,0 +1,5 @@
+-- Keyset pagination orders events by (block_number DESC, log_index, id) and
+-- seeks past the cursor on the same columns
+
+CREATE INDEX idx_events_keyset ON events(block_number DESC, log_index ASC, id ASC);
+CREATE INDEX idx_events_chain_contract_keyset ON events(chain_id, contract_address, block_number DESC, log_index ASC, id ASC);
@@ -0,0 +1,5 @@
+-- Rollback migration: Remove the contract pause flag added in 008_contract_is_active.up.sql
+
+DROP INDEX IF EXISTS idx_contracts_active;
+
+ALTER TABLE contracts DROP COLUMN IF EXISTS is_active;
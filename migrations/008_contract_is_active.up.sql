@@ -0,0 +1,9 @@
+-- Pause/resume flag for monitored contracts. Paused contracts keep their
+-- events, indexer state and backfill jobs; the indexer just skips them.
+
+ALTER TABLE contracts
+    ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX idx_contracts_active ON contracts(chain_id) WHERE is_active;
+
+COMMENT ON COLUMN contracts.is_active IS 'false while indexing is paused; the contract resumes from its current_block when reactivated';
@@ -0,0 +1,3 @@
+-- Rollback migration: Remove the contract event filter added in 009_contract_event_filter.up.sql
+
+ALTER TABLE contracts DROP COLUMN IF EXISTS event_filter;
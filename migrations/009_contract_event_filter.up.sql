@@ -0,0 +1,7 @@
+-- Per-contract event allowlist and indexed-topic filter. The indexer turns it
+-- into eth_getLogs topic filters, so filtered-out logs are never fetched.
+
+ALTER TABLE contracts
+    ADD COLUMN event_filter JSONB;
+
+COMMENT ON COLUMN contracts.event_filter IS 'NULL indexes every ABI event; otherwise {"events": [...], "topics": [{"topic1": [...], "topic2": [...], "topic3": [...]}]}. Changes apply to blocks indexed afterwards; stored events are not re-filtered';
@@ -0,0 +1,3 @@
+-- Rollback migration: Remove contract ABI versions added in 010_contract_abi_versions.up.sql
+
+DROP TABLE IF EXISTS contract_abi_versions;
@@ -0,0 +1,29 @@
+-- Later ABI versions of a contract, for upgradeable proxies whose event set
+-- changes with the implementation. contracts.abi stays the version in effect
+-- from the contract's first block; each row here takes over from from_block.
+-- A version registered with an implementation address and no from_block waits
+-- for the proxy's Upgraded(address) event to that implementation, and the
+-- indexer sets from_block to the block of that event.
+
+CREATE TABLE contract_abi_versions (
+    id BIGSERIAL PRIMARY KEY,
+    chain_id BIGINT NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    abi TEXT NOT NULL,
+    from_block BIGINT,
+    implementation VARCHAR(42),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (chain_id, contract_address) REFERENCES contracts(chain_id, address) ON DELETE CASCADE,
+    CHECK (from_block IS NOT NULL OR implementation IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX idx_contract_abi_versions_from_block ON contract_abi_versions(chain_id, contract_address, from_block) WHERE from_block IS NOT NULL;
+CREATE INDEX idx_contract_abi_versions_chain ON contract_abi_versions(chain_id);
+
+CREATE TRIGGER update_contract_abi_versions_updated_at BEFORE UPDATE ON contract_abi_versions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN contract_abi_versions.from_block IS 'First block decoded with this ABI; NULL while waiting for the Upgraded event to implementation';
+COMMENT ON COLUMN contract_abi_versions.implementation IS 'Implementation address whose Upgraded(address) event activates this version; NULL for versions attached at a fixed block';
@@ -0,0 +1,3 @@
+-- Rollback migration: Remove the unknown logs store added in 011_unknown_logs.up.sql
+
+DROP TABLE IF EXISTS unknown_logs;
@@ -0,0 +1,37 @@
+-- Logs of monitored contracts that could not be decoded into events, either
+-- because topic0 matches no event in the contract's ABIs or because the data
+-- does not unpack. The raw log is kept so it can be decoded again once the ABI
+-- is fixed; the indexer retries rows whose retry_requested_at is set.
+
+CREATE TABLE unknown_logs (
+    id BIGSERIAL PRIMARY KEY,
+    chain_id BIGINT NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    block_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+    transaction_hash VARCHAR(66) NOT NULL,
+    transaction_index INTEGER NOT NULL,
+    log_index INTEGER NOT NULL,
+    topics TEXT[] NOT NULL DEFAULT '{}',
+    data TEXT NOT NULL DEFAULT '0x',
+    reason TEXT NOT NULL,
+    retry_requested_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    UNIQUE (chain_id, transaction_hash, log_index),
+    FOREIGN KEY (chain_id, contract_address) REFERENCES contracts(chain_id, address) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_unknown_logs_contract_block ON unknown_logs(chain_id, contract_address, block_number);
+-- The indexer picks up rows waiting for a retry
+CREATE INDEX idx_unknown_logs_retry ON unknown_logs(chain_id, retry_requested_at) WHERE retry_requested_at IS NOT NULL;
+
+CREATE TRIGGER update_unknown_logs_updated_at BEFORE UPDATE ON unknown_logs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN unknown_logs.topics IS 'Raw log topics as 0x-prefixed hex, topic0 first';
+COMMENT ON COLUMN unknown_logs.data IS 'Raw log data as 0x-prefixed hex';
+COMMENT ON COLUMN unknown_logs.reason IS 'Why the last decoding attempt failed';
+COMMENT ON COLUMN unknown_logs.retry_requested_at IS 'Set to have the indexer decode the log again; cleared after the attempt';
@@ -0,0 +1,10 @@
+-- Rollback migration: Remove raw event logs and redecode jobs added in 012_event_raw_log.up.sql
+
+DELETE FROM backfill_jobs WHERE kind = 'redecode';
+
+ALTER TABLE backfill_jobs
+    DROP COLUMN IF EXISTS events_skipped,
+    DROP COLUMN IF EXISTS events_updated,
+    DROP COLUMN IF EXISTS kind;
+
+ALTER TABLE events DROP COLUMN IF EXISTS raw_log;
@@ -0,0 +1,17 @@
+-- Raw topics and data of every event, so events can be decoded again after an
+-- ABI change without fetching the logs from the chain. Events indexed before
+-- this migration have no raw log and are left untouched by redecode jobs.
+ALTER TABLE events ADD COLUMN raw_log JSONB;
+
+COMMENT ON COLUMN events.raw_log IS 'Raw log as {"topics": [...], "data": "0x..."}; NULL for events indexed before it was recorded';
+
+-- Redecode jobs share the backfill queue: they walk the block range like a
+-- backfill but re-decode stored raw logs instead of calling the RPC
+ALTER TABLE backfill_jobs
+    ADD COLUMN kind VARCHAR(20) NOT NULL DEFAULT 'fetch' CHECK (kind IN ('fetch', 'redecode')),
+    ADD COLUMN events_updated BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN events_skipped BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN backfill_jobs.kind IS 'fetch re-indexes logs from the chain; redecode rewrites stored events from their raw logs';
+COMMENT ON COLUMN backfill_jobs.events_updated IS 'Redecode jobs: events whose name or args changed';
+COMMENT ON COLUMN backfill_jobs.events_skipped IS 'Redecode jobs: events without a raw log or that no longer decode';
@@ -0,0 +1,6 @@
+-- Rollback migration: Remove contract factories added in 013_contract_factories.up.sql
+
+DROP INDEX IF EXISTS idx_contracts_factory_id;
+ALTER TABLE contracts DROP COLUMN IF EXISTS factory_id;
+
+DROP TABLE IF EXISTS contract_factories;
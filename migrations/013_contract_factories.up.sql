@@ -0,0 +1,35 @@
+-- Factory rules: when a factory contract emits event_name, the address in its
+-- address_arg argument is registered as a new contract with child_abi, starting
+-- at the block of that event. Children are added by the live indexer and by
+-- backfills of the factory, and record the rule that found them in
+-- contracts.factory_id.
+
+CREATE TABLE contract_factories (
+    id BIGSERIAL PRIMARY KEY,
+    chain_id BIGINT NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    address_arg VARCHAR(255) NOT NULL,
+    child_abi TEXT NOT NULL,
+    child_name VARCHAR(255) NOT NULL,
+    from_block BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (chain_id, contract_address) REFERENCES contracts(chain_id, address) ON DELETE CASCADE,
+    UNIQUE (chain_id, contract_address, event_name, address_arg),
+    CHECK (from_block >= 0)
+);
+
+CREATE INDEX idx_contract_factories_chain ON contract_factories(chain_id);
+
+CREATE TRIGGER update_contract_factories_updated_at BEFORE UPDATE ON contract_factories
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE contracts ADD COLUMN factory_id BIGINT REFERENCES contract_factories(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_contracts_factory_id ON contracts(factory_id) WHERE factory_id IS NOT NULL;
+
+COMMENT ON COLUMN contract_factories.address_arg IS 'Argument of event_name holding the address of the new child contract';
+COMMENT ON COLUMN contract_factories.from_block IS 'Creation events before this block are ignored';
+COMMENT ON COLUMN contracts.factory_id IS 'Factory rule that discovered this contract; NULL for contracts added through the admin API';
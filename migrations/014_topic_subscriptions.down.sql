@@ -0,0 +1,12 @@
+-- Rollback migration: Remove topic subscriptions added in 014_topic_subscriptions.up.sql
+
+DROP INDEX IF EXISTS idx_events_subscription_block;
+ALTER TABLE events DROP COLUMN IF EXISTS subscription_id;
+
+DELETE FROM indexer_state WHERE subscription_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_indexer_state_subscription;
+ALTER TABLE indexer_state DROP CONSTRAINT IF EXISTS indexer_state_target_check;
+ALTER TABLE indexer_state DROP COLUMN IF EXISTS subscription_id;
+ALTER TABLE indexer_state ALTER COLUMN contract_address SET NOT NULL;
+
+DROP TABLE IF EXISTS topic_subscriptions;
@@ -0,0 +1,44 @@
+-- Topic subscriptions index one event signature from any emitter, for example
+-- every ERC-20 Transfer on a chain. They are decoded with their own ABI
+-- fragment and keep their cursor in indexer_state, which now tracks either a
+-- contract or a subscription. Their events record the emitting address in
+-- events.contract_address and the subscription in events.subscription_id.
+
+CREATE TABLE topic_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    chain_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    abi TEXT NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    event_signature TEXT NOT NULL,
+    start_block BIGINT NOT NULL DEFAULT 0,
+    confirm_blocks INTEGER NOT NULL DEFAULT 6,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    UNIQUE (chain_id, name),
+    CHECK (start_block >= 0),
+    CHECK (confirm_blocks BETWEEN 1 AND 100)
+);
+
+CREATE INDEX idx_topic_subscriptions_chain ON topic_subscriptions(chain_id) WHERE is_active = TRUE;
+
+CREATE TRIGGER update_topic_subscriptions_updated_at BEFORE UPDATE ON topic_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- indexer_state: a row tracks exactly one contract or one subscription
+ALTER TABLE indexer_state ALTER COLUMN contract_address DROP NOT NULL;
+ALTER TABLE indexer_state ADD COLUMN subscription_id BIGINT REFERENCES topic_subscriptions(id) ON DELETE CASCADE;
+ALTER TABLE indexer_state ADD CONSTRAINT indexer_state_target_check
+    CHECK ((contract_address IS NULL) <> (subscription_id IS NULL));
+CREATE UNIQUE INDEX idx_indexer_state_subscription ON indexer_state(subscription_id) WHERE subscription_id IS NOT NULL;
+
+-- events: stored events are kept when their subscription is deleted
+ALTER TABLE events ADD COLUMN subscription_id BIGINT REFERENCES topic_subscriptions(id) ON DELETE SET NULL;
+CREATE INDEX idx_events_subscription_block ON events(subscription_id, block_number) WHERE subscription_id IS NOT NULL;
+
+COMMENT ON COLUMN topic_subscriptions.abi IS 'ABI fragment declaring the subscribed event';
+COMMENT ON COLUMN topic_subscriptions.event_signature IS 'Canonical signature of the event, e.g. Transfer(address,address,uint256)';
+COMMENT ON COLUMN indexer_state.subscription_id IS 'Topic subscription tracked by this row; NULL for contract rows';
+COMMENT ON COLUMN events.subscription_id IS 'Topic subscription that indexed the event; NULL for events of monitored contracts';
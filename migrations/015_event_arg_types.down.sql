@@ -0,0 +1,3 @@
+-- Rollback migration: Remove event argument types added in 015_event_arg_types.up.sql
+
+ALTER TABLE events DROP COLUMN IF EXISTS arg_types;
@@ -0,0 +1,7 @@
+-- Solidity type, indexed flag and ABI order of every decoded argument, kept
+-- next to the args so clients see uint256/address/bytes32 rather than the
+-- JSON type of the stored value. Events indexed before this migration have an
+-- empty list until a redecode job rewrites them from their raw logs.
+ALTER TABLE events ADD COLUMN arg_types JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+COMMENT ON COLUMN events.arg_types IS 'Arguments in ABI order as [{"name", "type", "indexed"}]; integer args are stored as decimal strings';
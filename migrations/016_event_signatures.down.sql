@@ -0,0 +1,7 @@
+-- Rollback migration: Remove event signatures and anonymous decoding added in 016_event_signatures.up.sql
+
+ALTER TABLE contracts DROP COLUMN IF EXISTS decode_anonymous_events;
+
+DROP INDEX IF EXISTS idx_events_signature;
+
+ALTER TABLE events DROP COLUMN IF EXISTS event_signature;
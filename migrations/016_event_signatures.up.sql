@@ -0,0 +1,15 @@
+-- Full event signature of every decoded event, e.g.
+-- Transfer(address,address,uint256), so overloads of one event name can be
+-- told apart and filtered. Events indexed before this migration have an empty
+-- signature until a redecode job rewrites them from their raw logs.
+ALTER TABLE events ADD COLUMN event_signature TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_events_signature ON events(event_signature, block_number DESC) WHERE event_signature <> '';
+
+-- Anonymous events have no signature topic, so their logs can only be decoded
+-- by matching the ABI's anonymous events against the log's topic count and
+-- data layout. Contracts opt in, since a match is a guess.
+ALTER TABLE contracts ADD COLUMN decode_anonymous_events BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN events.event_signature IS 'Canonical signature of the decoded event, e.g. Transfer(address,address,uint256)';
+COMMENT ON COLUMN contracts.decode_anonymous_events IS 'Decode logs without a known topic0 by matching the ABI''s anonymous events';
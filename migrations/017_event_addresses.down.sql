@@ -0,0 +1,3 @@
+-- Rollback migration: Remove the event address table added in 017_event_addresses.up.sql
+
+DROP TABLE IF EXISTS event_addresses;
@@ -0,0 +1,42 @@
+-- Every address held by an address-typed event argument, indexed or not and
+-- whatever the argument is called, with the argument's name as its role.
+-- Address lookups join this table instead of scanning the JSONB args.
+-- Addresses are stored lower-cased; rows go away with their event.
+
+CREATE TABLE event_addresses (
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    address VARCHAR(42) NOT NULL,
+    role VARCHAR(255) NOT NULL,
+
+    PRIMARY KEY (event_id, address, role)
+);
+
+CREATE INDEX idx_event_addresses_address ON event_addresses(address, role, event_id);
+
+-- Backfill events with argument types from their address and address[] args
+INSERT INTO event_addresses (event_id, address, role)
+SELECT e.id, LOWER(a.value), t.arg->>'name'
+FROM events e
+CROSS JOIN LATERAL jsonb_array_elements(e.arg_types) t(arg)
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE jsonb_typeof(e.args -> (t.arg->>'name'))
+        WHEN 'array' THEN e.args -> (t.arg->>'name')
+        ELSE jsonb_build_array(e.args -> (t.arg->>'name'))
+    END
+) a(value)
+WHERE t.arg->>'type' ~ '^address(\[[0-9]*\])*$'
+  AND a.value ~ '^0x[0-9a-fA-F]{40}$'
+ON CONFLICT DO NOTHING;
+
+-- Events indexed before argument types were recorded only have their values,
+-- so every top-level argument holding an address is taken
+INSERT INTO event_addresses (event_id, address, role)
+SELECT e.id, LOWER(kv.value), kv.key
+FROM events e
+CROSS JOIN LATERAL jsonb_each_text(e.args) kv(key, value)
+WHERE e.arg_types = '[]'::jsonb
+  AND kv.value ~ '^0x[0-9a-fA-F]{40}$'
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE event_addresses IS 'Addresses held by address-typed event arguments, for address lookups';
+COMMENT ON COLUMN event_addresses.role IS 'Name of the event argument holding the address, e.g. from or to';
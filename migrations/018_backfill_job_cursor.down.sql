@@ -0,0 +1,5 @@
+-- Rollback migration: Restore the cursor default replaced in 018_backfill_job_cursor.up.sql
+
+UPDATE backfill_jobs
+SET current_block = 0
+WHERE current_block < 0;
@@ -0,0 +1,11 @@
+-- Backfill job cursor: current_block starts one below from_block
+--
+-- current_block used to default to 0, which a job starting at block 0 could
+-- not tell apart from having processed block 0. Jobs are now queued with
+-- current_block = from_block - 1; unfinished jobs that made no progress are
+-- moved to the same sentinel.
+
+UPDATE backfill_jobs
+SET current_block = from_block - 1
+WHERE status IN ('pending', 'running')
+  AND (current_block < from_block OR (from_block = 0 AND current_block = 0));
@@ -0,0 +1,4 @@
+-- Rollback migration: Drop the stream position added in 019_event_stream_position.up.sql
+
+DROP INDEX IF EXISTS idx_events_stream;
+ALTER TABLE events DROP COLUMN IF EXISTS stream_xid;
@@ -0,0 +1,13 @@
+-- Event stream position: the transaction that last made an event visible
+--
+-- Event IDs are taken from the sequence when a row is inserted, not when its
+-- transaction commits, so a stream reading in ID order can pass an ID whose
+-- row only becomes visible later. stream_xid records the inserting (or
+-- promoting) transaction; streams read in (stream_xid, id) order and only
+-- below the oldest running transaction, where no new rows can appear.
+-- Existing events get the lowest position and keep their ID order.
+
+ALTER TABLE events ADD COLUMN stream_xid xid8 NOT NULL DEFAULT '1'::xid8;
+ALTER TABLE events ALTER COLUMN stream_xid SET DEFAULT pg_current_xact_id();
+
+CREATE INDEX idx_events_stream ON events(stream_xid, id);
@@ -0,0 +1,16 @@
+-- Rollback migration: Restore events.subscription_id replaced in 020_event_subscriptions.up.sql
+
+ALTER TABLE events ADD COLUMN subscription_id BIGINT REFERENCES topic_subscriptions(id) ON DELETE SET NULL;
+CREATE INDEX idx_events_subscription_block ON events(subscription_id, block_number) WHERE subscription_id IS NOT NULL;
+
+-- An event keeps one of its subscriptions
+UPDATE events e
+SET subscription_id = es.subscription_id
+FROM (
+    SELECT event_id, MIN(subscription_id) AS subscription_id
+    FROM event_subscriptions
+    GROUP BY event_id
+) es
+WHERE es.event_id = e.id;
+
+DROP TABLE IF EXISTS event_subscriptions;
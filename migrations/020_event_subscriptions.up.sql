@@ -0,0 +1,24 @@
+-- Event subscriptions: every topic subscription that indexed an event
+--
+-- A log is stored once, keyed by (chain_id, transaction_hash, log_index). A
+-- subscription whose event was already stored by a monitored contract or by
+-- another subscription lost it to the conflict clause, as events could only
+-- record one subscription_id. Subscriptions are now linked to their events
+-- through this table, which replaces events.subscription_id.
+
+CREATE TABLE event_subscriptions (
+    event_id BIGINT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    subscription_id BIGINT NOT NULL REFERENCES topic_subscriptions(id) ON DELETE CASCADE,
+
+    PRIMARY KEY (subscription_id, event_id)
+);
+
+CREATE INDEX idx_event_subscriptions_event ON event_subscriptions(event_id);
+
+INSERT INTO event_subscriptions (event_id, subscription_id)
+SELECT id, subscription_id
+FROM events
+WHERE subscription_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_events_subscription_block;
+ALTER TABLE events DROP COLUMN subscription_id;
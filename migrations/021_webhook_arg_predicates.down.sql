@@ -0,0 +1,14 @@
+-- Rollback migration: Restore the argument filter objects replaced in 021_webhook_arg_predicates.up.sql
+
+-- Only equality predicates can be expressed as name -> value
+UPDATE webhooks
+SET arg_filters = COALESCE((
+    SELECT jsonb_object_agg(p->>'name', p->>'value')
+    FROM jsonb_array_elements(arg_filters) p
+    WHERE p->>'op' = 'eq'
+), '{}'::jsonb)
+WHERE jsonb_typeof(arg_filters) = 'array';
+
+ALTER TABLE webhooks ALTER COLUMN arg_filters SET DEFAULT '{}';
+
+COMMENT ON COLUMN webhooks.arg_filters IS 'Event argument name to required value; all entries must match';
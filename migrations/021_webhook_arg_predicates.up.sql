@@ -0,0 +1,17 @@
+-- Webhook argument filters become argument predicates
+--
+-- arg_filters held an object of argument name to required value, compared as
+-- strings. It now holds an array of predicates ({"name", "op", "value",
+-- "values"}) evaluated like the argument predicates of event queries; each
+-- existing entry becomes an equality predicate.
+
+UPDATE webhooks
+SET arg_filters = COALESCE((
+    SELECT jsonb_agg(jsonb_build_object('name', f.key, 'op', 'eq', 'value', f.value) ORDER BY f.key)
+    FROM jsonb_each_text(arg_filters) f
+), '[]'::jsonb)
+WHERE jsonb_typeof(arg_filters) = 'object';
+
+ALTER TABLE webhooks ALTER COLUMN arg_filters SET DEFAULT '[]';
+
+COMMENT ON COLUMN webhooks.arg_filters IS 'Argument predicates ({name, op, value, values}); all must hold';
@@ -0,0 +1,10 @@
+-- Rollback migration: Remove the cancelled delivery status added in 022_webhook_delivery_cancelled.up.sql
+
+-- Cancelled deliveries are for events that no longer exist
+DELETE FROM webhook_deliveries WHERE status = 'cancelled';
+
+ALTER TABLE webhook_deliveries DROP CONSTRAINT webhook_deliveries_status_check;
+ALTER TABLE webhook_deliveries ADD CONSTRAINT webhook_deliveries_status_check
+    CHECK (status IN ('pending', 'delivered', 'dead_letter'));
+
+COMMENT ON COLUMN webhook_deliveries.status IS 'pending until delivered; dead_letter once retries are exhausted or the receiver rejects the payload';
@@ -0,0 +1,11 @@
+-- Webhook deliveries of reorged events are cancelled
+--
+-- webhook_deliveries.event_id has no foreign key, so deliveries outlived the
+-- events a reorg rollback deletes and were still sent. The rollback now marks
+-- the pending deliveries of the events it removes as cancelled.
+
+ALTER TABLE webhook_deliveries DROP CONSTRAINT webhook_deliveries_status_check;
+ALTER TABLE webhook_deliveries ADD CONSTRAINT webhook_deliveries_status_check
+    CHECK (status IN ('pending', 'delivered', 'dead_letter', 'cancelled'));
+
+COMMENT ON COLUMN webhook_deliveries.status IS 'pending until delivered; dead_letter once retries are exhausted or the receiver rejects the payload; cancelled when a reorg removed the event before it was delivered';
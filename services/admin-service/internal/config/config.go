@@ -4,6 +4,8 @@ import (
 	"os"
 	"strconv"
 	"time"
+
+	sharedconfig "github.com/smart-contract-event-indexer/shared/config"
 )
 
 // Config holds the configuration for the Admin Service
@@ -21,6 +23,16 @@ type Config struct {
 	LogLevel  string `json:"log_level"`
 	LogFormat string `json:"log_format"`
 
+	// Chain configuration. DefaultChainID is used when a request omits the
+	// chain; when Chains is loaded, requests for unconfigured chains are rejected.
+	DefaultChainID   int64                       `json:"default_chain_id"`
+	ChainsConfigPath string                      `json:"chains_config"`
+	Chains           *sharedconfig.ChainRegistry `json:"-"`
+
+	// RPCEndpoint serves the default chain when no chain registry is loaded.
+	// Backfill requests are checked against the chain head it reports.
+	RPCEndpoint string `json:"rpc_endpoint"`
+
 	// Backfill configuration
 	ChunkSize           int           `json:"chunk_size"`
 	MaxConcurrentChunks int           `json:"max_concurrent_chunks"`
@@ -36,15 +48,49 @@ func Load(configPath string) (*Config, error) {
 		RedisURL:             getEnvString("REDIS_URL", "redis://localhost:6379"),
 		LogLevel:             getEnvString("LOG_LEVEL", "info"),
 		LogFormat:            getEnvString("LOG_FORMAT", "json"),
+		DefaultChainID:       getEnvInt64("CHAIN_ID", sharedconfig.DefaultChainID),
+		ChainsConfigPath:     getEnvString("CHAINS_CONFIG", ""),
+		RPCEndpoint:          getEnvString("RPC_ENDPOINT", ""),
 		ChunkSize:            getEnvInt("CHUNK_SIZE", 1000),
 		MaxConcurrentChunks:  getEnvInt("MAX_CONCURRENT_CHUNKS", 3),
 		RateLimit:            getEnvInt("RATE_LIMIT", 100),
 		BackfillTimeout:      getEnvDuration("BACKFILL_TIMEOUT", 30*time.Minute),
 	}
 
+	if cfg.ChainsConfigPath != "" {
+		chains, err := sharedconfig.LoadChainRegistry(cfg.ChainsConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Chains = chains
+	}
+
 	return cfg, nil
 }
 
+// IsChainAllowed reports whether requests may target the given chain
+func (c *Config) IsChainAllowed(chainID int64) bool {
+	if chainID <= 0 {
+		return false
+	}
+	return c.Chains == nil || c.Chains.Has(chainID)
+}
+
+// RPCEndpointFor returns the RPC endpoint serving the chain, or "" when none
+// is configured
+func (c *Config) RPCEndpointFor(chainID int64) string {
+	if c.Chains != nil {
+		if chain, ok := c.Chains.Get(chainID); ok {
+			return chain.PrimaryEndpoint()
+		}
+		return ""
+	}
+	if chainID == c.DefaultChainID {
+		return c.RPCEndpoint
+	}
+	return ""
+}
+
 // getEnvString gets an environment variable as a string with a default value
 func getEnvString(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -63,6 +109,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvInt64 gets an environment variable as an int64 with a default value
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
 // getEnvDuration gets an environment variable as a duration with a default value
 func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
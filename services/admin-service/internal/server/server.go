@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -22,6 +23,8 @@ import (
 
 // AdminServiceServer implements the gRPC AdminService
 type AdminServiceServer struct {
+	protoapi.UnimplementedAdminServiceServer
+
 	db           *sql.DB
 	redisClient  *redis.Client
 	adminService *service.AdminService
@@ -162,18 +165,21 @@ func (s *AdminServiceServer) checkDependencies(ctx context.Context) error {
 
 func (s *AdminServiceServer) AddContract(ctx context.Context, req *protoapi.AddContractRequest) (*protoapi.AddContractResponse, error) {
 	resp, err := s.adminService.AddContract(ctx, &service.AddContractRequest{
-		Address:       req.Address,
-		Name:          req.Name,
-		ABI:           req.Abi,
-		StartBlock:    req.StartBlock,
-		ConfirmBlocks: req.ConfirmBlocks,
+		ChainID:               req.ChainId,
+		Address:               req.Address,
+		Name:                  req.Name,
+		ABI:                   req.Abi,
+		StartBlock:            req.StartBlock,
+		ConfirmBlocks:         req.GetConfirmBlocks(),
+		Filter:                filterFromProto(req.Filter),
+		DecodeAnonymousEvents: req.DecodeAnonymousEvents,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	var contractProto *protoapi.Contract
-	if contract, err := s.adminService.GetContract(ctx, req.Address); err == nil && contract != nil {
+	if contract, err := s.adminService.GetContract(ctx, req.ChainId, req.Address); err == nil && contract != nil {
 		contractProto = convertContract(contract)
 	}
 
@@ -187,6 +193,7 @@ func (s *AdminServiceServer) AddContract(ctx context.Context, req *protoapi.AddC
 
 func (s *AdminServiceServer) RemoveContract(ctx context.Context, req *protoapi.RemoveContractRequest) (*protoapi.RemoveContractResponse, error) {
 	resp, err := s.adminService.RemoveContract(ctx, &service.RemoveContractRequest{
+		ChainID: req.ChainId,
 		Address: req.Address,
 	})
 	if err != nil {
@@ -198,8 +205,124 @@ func (s *AdminServiceServer) RemoveContract(ctx context.Context, req *protoapi.R
 	}, nil
 }
 
+func (s *AdminServiceServer) SetContractActive(ctx context.Context, req *protoapi.SetContractActiveRequest) (*protoapi.SetContractActiveResponse, error) {
+	resp, err := s.adminService.SetContractActive(ctx, &service.SetContractActiveRequest{
+		ChainID:  req.ChainId,
+		Address:  req.Address,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var contractProto *protoapi.Contract
+	if resp.Success {
+		if contract, err := s.adminService.GetContract(ctx, req.ChainId, req.Address); err == nil && contract != nil {
+			contractProto = convertContract(contract)
+		}
+	}
+
+	return &protoapi.SetContractActiveResponse{
+		Success:  resp.Success,
+		Contract: contractProto,
+		Message:  resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) UpdateContractFilter(ctx context.Context, req *protoapi.UpdateContractFilterRequest) (*protoapi.UpdateContractFilterResponse, error) {
+	resp, err := s.adminService.UpdateContractFilter(ctx, &service.UpdateContractFilterRequest{
+		ChainID: req.ChainId,
+		Address: req.Address,
+		Filter:  filterFromProto(req.Filter),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var contractProto *protoapi.Contract
+	if resp.Success {
+		if contract, err := s.adminService.GetContract(ctx, req.ChainId, req.Address); err == nil && contract != nil {
+			contractProto = convertContract(contract)
+		}
+	}
+
+	return &protoapi.UpdateContractFilterResponse{
+		Success:  resp.Success,
+		Contract: contractProto,
+		Message:  resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) AddContractABIVersion(ctx context.Context, req *protoapi.AddContractABIVersionRequest) (*protoapi.AddContractABIVersionResponse, error) {
+	resp, err := s.adminService.AddContractABIVersion(ctx, &service.AddContractABIVersionRequest{
+		ChainID:        req.ChainId,
+		Address:        req.Address,
+		ABI:            req.Abi,
+		FromBlock:      req.FromBlock,
+		Implementation: req.Implementation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &protoapi.AddContractABIVersionResponse{
+		Success: resp.Success,
+		Version: convertABIVersion(resp.Version),
+		Message: resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) ListContractABIVersions(ctx context.Context, req *protoapi.ListContractABIVersionsRequest) (*protoapi.ListContractABIVersionsResponse, error) {
+	versions, err := s.adminService.ListContractABIVersions(ctx, req.ChainId, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*protoapi.ABIVersion, 0, len(versions))
+	for _, v := range versions {
+		result = append(result, convertABIVersion(v))
+	}
+
+	return &protoapi.ListContractABIVersionsResponse{Versions: result}, nil
+}
+
+func (s *AdminServiceServer) AddContractFactory(ctx context.Context, req *protoapi.AddContractFactoryRequest) (*protoapi.AddContractFactoryResponse, error) {
+	resp, err := s.adminService.AddContractFactory(ctx, &service.AddContractFactoryRequest{
+		ChainID:    req.ChainId,
+		Address:    req.Address,
+		EventName:  req.EventName,
+		AddressArg: req.AddressArg,
+		ChildABI:   req.ChildAbi,
+		ChildName:  req.ChildName,
+		FromBlock:  req.FromBlock,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &protoapi.AddContractFactoryResponse{
+		Success: resp.Success,
+		Factory: convertContractFactory(resp.Factory),
+		Message: resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) ListContractFactories(ctx context.Context, req *protoapi.ListContractFactoriesRequest) (*protoapi.ListContractFactoriesResponse, error) {
+	factories, err := s.adminService.ListContractFactories(ctx, req.ChainId, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*protoapi.ContractFactory, 0, len(factories))
+	for _, f := range factories {
+		result = append(result, convertContractFactory(f))
+	}
+
+	return &protoapi.ListContractFactoriesResponse{Factories: result}, nil
+}
+
 func (s *AdminServiceServer) GetContract(ctx context.Context, req *protoapi.GetContractRequest) (*protoapi.Contract, error) {
-	contract, err := s.adminService.GetContract(ctx, req.Address)
+	contract, err := s.adminService.GetContract(ctx, req.ChainId, req.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -210,7 +333,7 @@ func (s *AdminServiceServer) GetContract(ctx context.Context, req *protoapi.GetC
 }
 
 func (s *AdminServiceServer) ListContracts(ctx context.Context, req *protoapi.ListContractsRequest) (*protoapi.ListContractsResponse, error) {
-	contracts, total, err := s.adminService.ListContracts(ctx, req.Limit, req.Offset)
+	contracts, total, err := s.adminService.ListContracts(ctx, req.ChainId, req.IsActive, req.Limit, req.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -228,9 +351,30 @@ func (s *AdminServiceServer) ListContracts(ctx context.Context, req *protoapi.Li
 
 func (s *AdminServiceServer) TriggerBackfill(ctx context.Context, req *protoapi.BackfillRequest) (*protoapi.BackfillResponse, error) {
 	resp, err := s.adminService.TriggerBackfill(ctx, &service.BackfillRequest{
+		ChainID:   req.ChainId,
 		Address:   req.ContractAddress,
 		FromBlock: req.FromBlock,
 		ToBlock:   req.ToBlock,
+		ChunkSize: req.ChunkSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &protoapi.BackfillResponse{
+		Success:       resp.Success,
+		JobId:         resp.JobID,
+		EstimatedTime: resp.EstimatedMinutes,
+		Message:       resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) TriggerRedecode(ctx context.Context, req *protoapi.RedecodeRequest) (*protoapi.BackfillResponse, error) {
+	resp, err := s.adminService.TriggerRedecode(ctx, &service.BackfillRequest{
+		ChainID:   req.ChainId,
+		Address:   req.ContractAddress,
+		FromBlock: req.FromBlock,
+		ToBlock:   req.ToBlock,
+		ChunkSize: req.ChunkSize,
 	})
 	if err != nil {
 		return nil, err
@@ -293,22 +437,293 @@ func (s *AdminServiceServer) HealthCheck(ctx context.Context, _ *protoapi.Empty)
 	}, nil
 }
 
+func (s *AdminServiceServer) CreateWebhook(ctx context.Context, req *protoapi.CreateWebhookRequest) (*protoapi.CreateWebhookResponse, error) {
+	resp, err := s.adminService.CreateWebhook(ctx, &service.CreateWebhookRequest{
+		ChainID:         req.ChainId,
+		URL:             req.Url,
+		ContractAddress: req.ContractAddress,
+		EventName:       req.EventName,
+		ArgFilters:      argPredicatesFromProto(req.ArgFilters),
+		Secret:          req.Secret,
+		Description:     req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &protoapi.CreateWebhookResponse{
+		Success: resp.Success,
+		Webhook: convertWebhook(resp.Webhook),
+		Secret:  resp.Secret,
+		Message: resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) ListWebhooks(ctx context.Context, req *protoapi.ListWebhooksRequest) (*protoapi.ListWebhooksResponse, error) {
+	webhooks, err := s.adminService.ListWebhooks(ctx, req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*protoapi.Webhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		result = append(result, convertWebhook(w))
+	}
+
+	return &protoapi.ListWebhooksResponse{Webhooks: result}, nil
+}
+
+func (s *AdminServiceServer) DeleteWebhook(ctx context.Context, req *protoapi.DeleteWebhookRequest) (*protoapi.DeleteWebhookResponse, error) {
+	resp, err := s.adminService.DeleteWebhook(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &protoapi.DeleteWebhookResponse{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) ListWebhookDeliveries(ctx context.Context, req *protoapi.ListWebhookDeliveriesRequest) (*protoapi.ListWebhookDeliveriesResponse, error) {
+	switch req.Status {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliveryDelivered, models.WebhookDeliveryDeadLetter:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown delivery status %q", req.Status)
+	}
+
+	deliveries, total, err := s.adminService.ListWebhookDeliveries(ctx, req.WebhookId, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*protoapi.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, convertWebhookDelivery(d))
+	}
+
+	return &protoapi.ListWebhookDeliveriesResponse{
+		Deliveries: result,
+		TotalCount: total,
+	}, nil
+}
+
+func (s *AdminServiceServer) RetryWebhookDelivery(ctx context.Context, req *protoapi.RetryWebhookDeliveryRequest) (*protoapi.RetryWebhookDeliveryResponse, error) {
+	resp, err := s.adminService.RetryWebhookDelivery(ctx, req.DeliveryId)
+	if err != nil {
+		return nil, err
+	}
+	return &protoapi.RetryWebhookDeliveryResponse{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) ListUnknownLogs(ctx context.Context, req *protoapi.ListUnknownLogsRequest) (*protoapi.ListUnknownLogsResponse, error) {
+	logs, total, err := s.adminService.ListUnknownLogs(ctx, req.ChainId, req.ContractAddress, req.FromBlock, req.ToBlock, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*protoapi.UnknownLog, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, convertUnknownLog(l))
+	}
+
+	return &protoapi.ListUnknownLogsResponse{
+		Logs:       result,
+		TotalCount: total,
+	}, nil
+}
+
+func (s *AdminServiceServer) RetryUnknownLogs(ctx context.Context, req *protoapi.RetryUnknownLogsRequest) (*protoapi.RetryUnknownLogsResponse, error) {
+	resp, err := s.adminService.RetryUnknownLogs(ctx, req.ChainId, req.ContractAddress, req.FromBlock, req.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	return &protoapi.RetryUnknownLogsResponse{
+		Success: resp.Success,
+		Queued:  resp.Queued,
+		Message: resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) CreateTopicSubscription(ctx context.Context, req *protoapi.CreateTopicSubscriptionRequest) (*protoapi.CreateTopicSubscriptionResponse, error) {
+	resp, err := s.adminService.CreateTopicSubscription(ctx, &service.CreateTopicSubscriptionRequest{
+		ChainID:       req.ChainId,
+		Name:          req.Name,
+		ABI:           req.Abi,
+		EventName:     req.EventName,
+		StartBlock:    req.StartBlock,
+		ConfirmBlocks: req.GetConfirmBlocks(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &protoapi.CreateTopicSubscriptionResponse{
+		Success:      resp.Success,
+		Subscription: convertTopicSubscription(resp.Subscription),
+		Message:      resp.Message,
+	}, nil
+}
+
+func (s *AdminServiceServer) ListTopicSubscriptions(ctx context.Context, req *protoapi.ListTopicSubscriptionsRequest) (*protoapi.ListTopicSubscriptionsResponse, error) {
+	subscriptions, err := s.adminService.ListTopicSubscriptions(ctx, req.ChainId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*protoapi.TopicSubscription, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		result = append(result, convertTopicSubscription(sub))
+	}
+
+	return &protoapi.ListTopicSubscriptionsResponse{Subscriptions: result}, nil
+}
+
+func (s *AdminServiceServer) DeleteTopicSubscription(ctx context.Context, req *protoapi.DeleteTopicSubscriptionRequest) (*protoapi.DeleteTopicSubscriptionResponse, error) {
+	resp, err := s.adminService.DeleteTopicSubscription(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &protoapi.DeleteTopicSubscriptionResponse{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
 // Helper conversions
 func convertContract(contract *models.Contract) *protoapi.Contract {
 	if contract == nil {
 		return nil
 	}
 	return &protoapi.Contract{
-		Id:            contract.ID,
-		Address:       string(contract.Address),
-		Abi:           contract.ABI,
-		Name:          contract.Name,
-		StartBlock:    contract.StartBlock,
-		CurrentBlock:  contract.CurrentBlock,
-		ConfirmBlocks: int32(contract.ConfirmBlocks),
-		CreatedAt:     timestampOrNil(contract.CreatedAt),
-		UpdatedAt:     timestampOrNil(contract.UpdatedAt),
+		Id:                    contract.ID,
+		ChainId:               contract.ChainID,
+		Address:               string(contract.Address),
+		Abi:                   contract.ABI,
+		Name:                  contract.Name,
+		StartBlock:            contract.StartBlock,
+		CurrentBlock:          contract.CurrentBlock,
+		ConfirmBlocks:         int32(contract.ConfirmBlocks),
+		IsActive:              contract.IsActive,
+		CreatedAt:             timestampOrNil(contract.CreatedAt),
+		UpdatedAt:             timestampOrNil(contract.UpdatedAt),
+		Filter:                convertContractFilter(contract.Filter),
+		DecodeAnonymousEvents: contract.DecodeAnonymousEvents,
+	}
+}
+
+// convertContractFilter returns nil for a filter that indexes every event
+func convertContractFilter(filter models.ContractFilter) *protoapi.ContractFilter {
+	if filter.IsEmpty() {
+		return nil
+	}
+	topics := make([]*protoapi.TopicFilter, 0, len(filter.Topics))
+	for _, t := range filter.Topics {
+		topics = append(topics, &protoapi.TopicFilter{
+			Topic1: t.Topic1,
+			Topic2: t.Topic2,
+			Topic3: t.Topic3,
+		})
+	}
+	return &protoapi.ContractFilter{
+		Events: filter.Events,
+		Topics: topics,
+	}
+}
+
+func filterFromProto(filter *protoapi.ContractFilter) models.ContractFilter {
+	if filter == nil {
+		return models.ContractFilter{}
+	}
+	result := models.ContractFilter{Events: filter.Events}
+	for _, t := range filter.Topics {
+		result.Topics = append(result.Topics, models.TopicFilter{
+			Topic1: t.Topic1,
+			Topic2: t.Topic2,
+			Topic3: t.Topic3,
+		})
+	}
+	return result
+}
+
+func convertABIVersion(version *models.ABIVersion) *protoapi.ABIVersion {
+	if version == nil {
+		return nil
+	}
+	result := &protoapi.ABIVersion{
+		Id:              version.ID,
+		ChainId:         version.ChainID,
+		ContractAddress: string(version.ContractAddress),
+		Abi:             version.ABI,
+		FromBlock:       version.FromBlock,
+		CreatedAt:       timestampOrNil(version.CreatedAt),
+	}
+	if version.Implementation != nil {
+		result.Implementation = string(*version.Implementation)
+	}
+	return result
+}
+
+func convertContractFactory(factory *models.ContractFactory) *protoapi.ContractFactory {
+	if factory == nil {
+		return nil
+	}
+	return &protoapi.ContractFactory{
+		Id:              factory.ID,
+		ChainId:         factory.ChainID,
+		ContractAddress: string(factory.ContractAddress),
+		EventName:       factory.EventName,
+		AddressArg:      factory.AddressArg,
+		ChildAbi:        factory.ChildABI,
+		ChildName:       factory.ChildName,
+		FromBlock:       factory.FromBlock,
+		Children:        factory.Children,
+		CreatedAt:       timestampOrNil(factory.CreatedAt),
+	}
+}
+
+func convertTopicSubscription(subscription *models.TopicSubscription) *protoapi.TopicSubscription {
+	if subscription == nil {
+		return nil
+	}
+	return &protoapi.TopicSubscription{
+		Id:               subscription.ID,
+		ChainId:          subscription.ChainID,
+		Name:             subscription.Name,
+		Abi:              subscription.ABI,
+		EventName:        subscription.EventName,
+		EventSignature:   subscription.EventSignature,
+		StartBlock:       subscription.StartBlock,
+		ConfirmBlocks:    int32(subscription.ConfirmBlocks),
+		IsActive:         subscription.IsActive,
+		LastIndexedBlock: subscription.CurrentBlock,
+		CreatedAt:        timestampOrNil(subscription.CreatedAt),
+	}
+}
+
+func convertUnknownLog(log *models.UnknownLog) *protoapi.UnknownLog {
+	if log == nil {
+		return nil
+	}
+	result := &protoapi.UnknownLog{
+		Id:               log.ID,
+		ChainId:          log.ChainID,
+		ContractAddress:  string(log.ContractAddress),
+		BlockNumber:      log.BlockNumber,
+		BlockHash:        string(log.BlockHash),
+		TransactionHash:  string(log.TransactionHash),
+		TransactionIndex: int32(log.TransactionIndex),
+		LogIndex:         int32(log.LogIndex),
+		Topics:           log.Topics,
+		Data:             log.Data,
+		Reason:           log.Reason,
+		BlockTimestamp:   timestampOrNil(log.BlockTimestamp),
+		CreatedAt:        timestampOrNil(log.CreatedAt),
 	}
+	if log.RetryRequestedAt != nil {
+		result.RetryRequestedAt = timestamppb.New(*log.RetryRequestedAt)
+	}
+	return result
 }
 
 func convertBackfillJob(job *service.BackfillJob) *protoapi.BackfillJob {
@@ -318,12 +733,16 @@ func convertBackfillJob(job *service.BackfillJob) *protoapi.BackfillJob {
 
 	return &protoapi.BackfillJob{
 		Id:              job.ID,
+		ChainId:         job.ChainID,
 		ContractAddress: job.ContractAddress,
 		FromBlock:       job.FromBlock,
 		ToBlock:         job.ToBlock,
 		CurrentBlock:    job.CurrentBlock,
+		Kind:            job.Kind,
+		EventsUpdated:   job.EventsUpdated,
+		EventsSkipped:   job.EventsSkipped,
 		Status:          job.Status,
-		ErrorMessage:    job.ErrorMessage,
+		ErrorMessage:    stringOrNil(job.ErrorMessage),
 		Progress:        job.Progress,
 		CreatedAt:       timestampOrNil(job.CreatedAt),
 		UpdatedAt:       timestampOrNil(job.UpdatedAt),
@@ -342,3 +761,85 @@ func timestampOrNil(t time.Time) *timestamppb.Timestamp {
 	}
 	return timestamppb.New(t)
 }
+
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func argPredicatesFromProto(predicates []*protoapi.ArgPredicate) []models.ArgPredicate {
+	result := make([]models.ArgPredicate, 0, len(predicates))
+	for _, predicate := range predicates {
+		result = append(result, models.ArgPredicate{
+			Name:   predicate.GetName(),
+			Op:     models.ArgOp(predicate.GetOp()),
+			Value:  predicate.GetValue(),
+			Values: predicate.GetValues(),
+		})
+	}
+	return result
+}
+
+func convertWebhook(webhook *models.Webhook) *protoapi.Webhook {
+	if webhook == nil {
+		return nil
+	}
+
+	argFilters := make([]*protoapi.ArgPredicate, 0, len(webhook.ArgFilters))
+	for _, predicate := range webhook.ArgFilters {
+		argFilters = append(argFilters, &protoapi.ArgPredicate{
+			Name:   predicate.Name,
+			Op:     string(predicate.Op),
+			Value:  predicate.Value,
+			Values: predicate.Values,
+		})
+	}
+
+	result := &protoapi.Webhook{
+		Id:          webhook.ID,
+		ChainId:     webhook.ChainID,
+		Url:         webhook.URL,
+		Description: webhook.Description,
+		EventName:   webhook.EventName,
+		ArgFilters:  argFilters,
+		IsActive:    webhook.IsActive,
+		CreatedAt:   timestampOrNil(webhook.CreatedAt),
+		UpdatedAt:   timestampOrNil(webhook.UpdatedAt),
+	}
+	if webhook.ContractAddress != nil {
+		address := string(*webhook.ContractAddress)
+		result.ContractAddress = &address
+	}
+	return result
+}
+
+func convertWebhookDelivery(delivery *models.WebhookDelivery) *protoapi.WebhookDelivery {
+	if delivery == nil {
+		return nil
+	}
+
+	payload, _ := json.Marshal(delivery.Payload)
+
+	result := &protoapi.WebhookDelivery{
+		Id:            delivery.ID,
+		WebhookId:     delivery.WebhookID,
+		EventId:       delivery.EventID,
+		Status:        delivery.Status,
+		Attempts:      int32(delivery.Attempts),
+		NextAttemptAt: timestampOrNil(delivery.NextAttemptAt),
+		LastError:     delivery.LastError,
+		Payload:       string(payload),
+		CreatedAt:     timestampOrNil(delivery.CreatedAt),
+		UpdatedAt:     timestampOrNil(delivery.UpdatedAt),
+	}
+	if delivery.LastStatusCode != nil {
+		code := int32(*delivery.LastStatusCode)
+		result.LastStatusCode = &code
+	}
+	if delivery.DeliveredAt != nil {
+		result.DeliveredAt = timestamppb.New(*delivery.DeliveredAt)
+	}
+	return result
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// AddContractABIVersionRequest represents a request to attach an ABI version
+// to a contract. Exactly one of FromBlock and Implementation must be set.
+type AddContractABIVersionRequest struct {
+	ChainID        int64  `json:"chain_id"`
+	Address        string `json:"address"`
+	ABI            string `json:"abi"`
+	FromBlock      *int64 `json:"from_block,omitempty"`
+	Implementation string `json:"implementation,omitempty"`
+}
+
+// AddContractABIVersionResponse represents the response for attaching an ABI version
+type AddContractABIVersionResponse struct {
+	Success bool               `json:"success"`
+	Version *models.ABIVersion `json:"version,omitempty"`
+	Message string             `json:"message"`
+}
+
+// AddContractABIVersion attaches a new ABI to an upgradeable proxy. With
+// FromBlock the version decodes the logs from that block on. With an
+// Implementation it stays pending until the indexer sees the proxy's
+// Upgraded(address) event to that implementation, and takes effect from the
+// event's block. Events already stored are not decoded again; a redecode job
+// over the affected blocks does that.
+func (s *AdminService) AddContractABIVersion(ctx context.Context, req *AddContractABIVersionRequest) (*AddContractABIVersionResponse, error) {
+	chainID, ok := s.resolveChainID(req.ChainID)
+	if !ok {
+		return &AddContractABIVersionResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+
+	if (req.FromBlock == nil) == (req.Implementation == "") {
+		return &AddContractABIVersionResponse{
+			Success: false,
+			Message: "Either from_block or implementation must be set",
+		}, nil
+	}
+	if req.FromBlock != nil && *req.FromBlock < 0 {
+		return &AddContractABIVersionResponse{
+			Success: false,
+			Message: "Invalid from_block",
+		}, nil
+	}
+	var implementation *models.Address
+	if req.Implementation != "" {
+		address := models.Address(req.Implementation)
+		if err := address.Validate(); err != nil {
+			return &AddContractABIVersionResponse{
+				Success: false,
+				Message: "Invalid implementation address",
+			}, nil
+		}
+		implementation = &address
+	}
+
+	if _, err := abiEventNames(req.ABI); err != nil {
+		return &AddContractABIVersionResponse{
+			Success: false,
+			Message: "Invalid ABI JSON",
+		}, nil
+	}
+
+	var (
+		address      models.Address
+		currentBlock int64
+	)
+	err := s.db.QueryRowContext(ctx,
+		"SELECT address, current_block FROM contracts WHERE chain_id = $1 AND address = $2",
+		chainID, req.Address,
+	).Scan(&address, &currentBlock)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &AddContractABIVersionResponse{
+			Success: false,
+			Message: "Contract not found",
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to load contract", "error", err)
+		return &AddContractABIVersionResponse{
+			Success: false,
+			Message: "Failed to add ABI version",
+		}, nil
+	}
+
+	if req.FromBlock != nil {
+		var exists bool
+		if err := s.db.QueryRowContext(ctx,
+			"SELECT EXISTS(SELECT 1 FROM contract_abi_versions WHERE chain_id = $1 AND contract_address = $2 AND from_block = $3)",
+			chainID, address, *req.FromBlock,
+		).Scan(&exists); err != nil {
+			s.logger.Error("Failed to check ABI versions", "error", err)
+			return &AddContractABIVersionResponse{
+				Success: false,
+				Message: "Failed to add ABI version",
+			}, nil
+		}
+		if exists {
+			return &AddContractABIVersionResponse{
+				Success: false,
+				Message: fmt.Sprintf("An ABI version already starts at block %d", *req.FromBlock),
+			}, nil
+		}
+	}
+
+	version := &models.ABIVersion{
+		ChainID:         chainID,
+		ContractAddress: address,
+		ABI:             req.ABI,
+		FromBlock:       req.FromBlock,
+		Implementation:  implementation,
+	}
+	insertQuery := `
+		INSERT INTO contract_abi_versions (chain_id, contract_address, abi, from_block, implementation)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	if err := s.db.QueryRowContext(ctx,
+		insertQuery,
+		version.ChainID,
+		version.ContractAddress,
+		version.ABI,
+		version.FromBlock,
+		version.Implementation,
+	).Scan(&version.ID, &version.CreatedAt, &version.UpdatedAt); err != nil {
+		s.logger.Error("Failed to store ABI version", "error", err)
+		return &AddContractABIVersionResponse{
+			Success: false,
+			Message: "Failed to add ABI version",
+		}, nil
+	}
+
+	s.logger.Info("ABI version added", "chain_id", chainID, "address", address, "version_id", version.ID, "from_block", req.FromBlock, "implementation", req.Implementation)
+
+	message := "ABI version added; it applies once the proxy upgrades to the implementation"
+	if req.FromBlock != nil {
+		message = fmt.Sprintf("ABI version added from block %d", *req.FromBlock)
+		if *req.FromBlock <= currentBlock {
+			message += fmt.Sprintf("; events already indexed from that block are not decoded again until blocks %d-%d are redecoded", *req.FromBlock, currentBlock)
+		}
+	}
+	return &AddContractABIVersionResponse{
+		Success: true,
+		Version: version,
+		Message: message,
+	}, nil
+}
+
+// ListContractABIVersions lists the ABI versions of a contract, active ones in
+// block order followed by pending ones. A zero chainID uses the default chain.
+func (s *AdminService) ListContractABIVersions(ctx context.Context, chainID int64, address string) ([]*models.ABIVersion, error) {
+	if chainID == 0 {
+		chainID = s.config.DefaultChainID
+	}
+
+	query := `
+		SELECT id, chain_id, contract_address, abi, from_block, implementation, created_at, updated_at
+		FROM contract_abi_versions
+		WHERE chain_id = $1 AND contract_address = $2
+		ORDER BY from_block ASC NULLS LAST, id ASC
+	`
+	rows, err := s.db.QueryContext(ctx, query, chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make([]*models.ABIVersion, 0)
+	for rows.Next() {
+		var version models.ABIVersion
+		if err := rows.Scan(
+			&version.ID,
+			&version.ChainID,
+			&version.ContractAddress,
+			&version.ABI,
+			&version.FromBlock,
+			&version.Implementation,
+			&version.CreatedAt,
+			&version.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		versions = append(versions, &version)
+	}
+	return versions, rows.Err()
+}
+
+// contractEventNames returns the names of the events declared in any ABI of a
+// contract: its own and every attached version, pending ones included
+func (s *AdminService) contractEventNames(ctx context.Context, chainID int64, address, abiJSON string) (map[string]bool, error) {
+	events, err := abiEventNames(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT abi FROM contract_abi_versions WHERE chain_id = $1 AND contract_address = $2",
+		chainID, address,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var versionABI string
+		if err := rows.Scan(&versionABI); err != nil {
+			return nil, err
+		}
+		versionEvents, err := abiEventNames(versionABI)
+		if err != nil {
+			return nil, err
+		}
+		for name := range versionEvents {
+			events[name] = true
+		}
+	}
+	return events, rows.Err()
+}
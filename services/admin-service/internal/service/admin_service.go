@@ -3,9 +3,8 @@ package service
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"fmt"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -39,11 +38,14 @@ func NewAdminService(
 
 // AddContractRequest represents a request to add a contract
 type AddContractRequest struct {
-	Address       string `json:"address"`
-	Name          string `json:"name"`
-	ABI           string `json:"abi"`
-	StartBlock    int64  `json:"start_block"`
-	ConfirmBlocks int32  `json:"confirm_blocks"`
+	ChainID               int64                 `json:"chain_id"`
+	Address               string                `json:"address"`
+	Name                  string                `json:"name"`
+	ABI                   string                `json:"abi"`
+	StartBlock            int64                 `json:"start_block"`
+	ConfirmBlocks         int32                 `json:"confirm_blocks"`
+	Filter                models.ContractFilter `json:"filter"`
+	DecodeAnonymousEvents bool                  `json:"decode_anonymous_events"` // match logs without a known topic0 against the ABI's anonymous events
 }
 
 // AddContractResponse represents the response for adding a contract
@@ -56,6 +58,7 @@ type AddContractResponse struct {
 
 // RemoveContractRequest represents a request to remove a contract
 type RemoveContractRequest struct {
+	ChainID int64  `json:"chain_id"`
 	Address string `json:"address"`
 }
 
@@ -65,11 +68,26 @@ type RemoveContractResponse struct {
 	Message string `json:"message"`
 }
 
+// SetContractActiveRequest represents a request to pause or resume a contract
+type SetContractActiveRequest struct {
+	ChainID  int64  `json:"chain_id"`
+	Address  string `json:"address"`
+	IsActive bool   `json:"is_active"`
+}
+
+// SetContractActiveResponse represents the response for pausing or resuming a contract
+type SetContractActiveResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
 // BackfillRequest represents a request to trigger backfill
 type BackfillRequest struct {
+	ChainID   int64  `json:"chain_id"`
 	Address   string `json:"address"`
 	FromBlock int64  `json:"from_block"`
 	ToBlock   int64  `json:"to_block"`
+	ChunkSize int32  `json:"chunk_size"`
 }
 
 // BackfillResponse represents the response for backfill
@@ -99,13 +117,17 @@ type ServiceStatus struct {
 	Error   string `json:"error,omitempty"`
 }
 
-// BackfillJob represents job metadata stored in Redis.
+// BackfillJob represents job metadata stored in the backfill_jobs table.
 type BackfillJob struct {
 	ID              string
+	ChainID         int64
 	ContractAddress string
 	FromBlock       int64
 	ToBlock         int64
 	CurrentBlock    int64
+	Kind            string
+	EventsUpdated   int64
+	EventsSkipped   int64
 	Status          string
 	ErrorMessage    string
 	Progress        float64
@@ -114,8 +136,25 @@ type BackfillJob struct {
 	CompletedAt     *time.Time
 }
 
+// resolveChainID applies the default chain and checks the chain is configured
+func (s *AdminService) resolveChainID(chainID int64) (int64, bool) {
+	if chainID == 0 {
+		chainID = s.config.DefaultChainID
+	}
+	return chainID, s.config.IsChainAllowed(chainID)
+}
+
 // AddContract adds a new contract for monitoring
 func (s *AdminService) AddContract(ctx context.Context, req *AddContractRequest) (*AddContractResponse, error) {
+	chainID, ok := s.resolveChainID(req.ChainID)
+	if !ok {
+		return &AddContractResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+	req.ChainID = chainID
+
 	// Validate address
 	addr := models.Address(req.Address)
 	if err := addr.Validate(); err != nil {
@@ -132,8 +171,8 @@ func (s *AdminService) AddContract(ctx context.Context, req *AddContractRequest)
 
 	// Check if contract already exists
 	var existingID int32
-	checkQuery := "SELECT id FROM contracts WHERE address = $1"
-	err := s.db.QueryRowContext(ctx, checkQuery, req.Address).Scan(&existingID)
+	checkQuery := "SELECT id FROM contracts WHERE chain_id = $1 AND address = $2"
+	err := s.db.QueryRowContext(ctx, checkQuery, req.ChainID, req.Address).Scan(&existingID)
 
 	if err == nil {
 		// Contract exists, return existing contract info
@@ -152,30 +191,43 @@ func (s *AdminService) AddContract(ctx context.Context, req *AddContractRequest)
 	}
 
 	// Validate ABI JSON
-	var abiJSON models.JSONB
-	if err := json.Unmarshal([]byte(req.ABI), &abiJSON); err != nil {
+	events, err := abiEventNames(req.ABI)
+	if err != nil {
 		return &AddContractResponse{
 			Success: false,
 			Message: "Invalid ABI JSON",
 		}, nil
 	}
 
+	// Validate the event filter against the ABI
+	filter, message := validateContractFilter(events, req.Filter)
+	if message != "" {
+		return &AddContractResponse{
+			Success: false,
+			Message: message,
+		}, nil
+	}
+
 	// Insert new contract
 	insertQuery := `
-		INSERT INTO contracts (address, name, abi, start_block, current_block, confirm_blocks, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO contracts (chain_id, address, name, abi, start_block, current_block, confirm_blocks, event_filter,
+		                       decode_anonymous_events, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id
 	`
 
 	var contractID int32
 	err = s.db.QueryRowContext(ctx,
 		insertQuery,
+		req.ChainID,
 		req.Address,
 		req.Name,
 		req.ABI,
 		req.StartBlock,
 		req.StartBlock, // current_block starts at start_block
 		req.ConfirmBlocks,
+		filter,
+		req.DecodeAnonymousEvents,
 		models.Now(),
 		models.Now(),
 	).Scan(&contractID)
@@ -188,7 +240,7 @@ func (s *AdminService) AddContract(ctx context.Context, req *AddContractRequest)
 		}, nil
 	}
 
-	s.logger.Info("Contract added", "address", req.Address, "id", contractID)
+	s.logger.Info("Contract added", "chain_id", req.ChainID, "address", req.Address, "id", contractID)
 
 	return &AddContractResponse{
 		Success:    true,
@@ -200,9 +252,18 @@ func (s *AdminService) AddContract(ctx context.Context, req *AddContractRequest)
 
 // RemoveContract removes a contract from monitoring
 func (s *AdminService) RemoveContract(ctx context.Context, req *RemoveContractRequest) (*RemoveContractResponse, error) {
-	// Delete contract (no is_active column in current schema)
-	query := "DELETE FROM contracts WHERE address = $1"
-	result, err := s.db.ExecContext(ctx, query, req.Address)
+	chainID, ok := s.resolveChainID(req.ChainID)
+	if !ok {
+		return &RemoveContractResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+
+	// Delete contract; its indexer state and backfill jobs cascade. Use
+	// SetContractActive to stop indexing without losing data.
+	query := "DELETE FROM contracts WHERE chain_id = $1 AND address = $2"
+	result, err := s.db.ExecContext(ctx, query, chainID, req.Address)
 	if err != nil {
 		s.logger.Error("Failed to remove contract", "error", err)
 		return &RemoveContractResponse{
@@ -227,7 +288,7 @@ func (s *AdminService) RemoveContract(ctx context.Context, req *RemoveContractRe
 		}, nil
 	}
 
-	s.logger.Info("Contract removed", "address", req.Address)
+	s.logger.Info("Contract removed", "chain_id", chainID, "address", req.Address)
 
 	return &RemoveContractResponse{
 		Success: true,
@@ -235,8 +296,122 @@ func (s *AdminService) RemoveContract(ctx context.Context, req *RemoveContractRe
 	}, nil
 }
 
+// SetContractActive pauses or resumes indexing for a contract. Events, the
+// indexing position and backfill jobs are kept, so a resumed contract
+// continues from where it stopped.
+func (s *AdminService) SetContractActive(ctx context.Context, req *SetContractActiveRequest) (*SetContractActiveResponse, error) {
+	chainID, ok := s.resolveChainID(req.ChainID)
+	if !ok {
+		return &SetContractActiveResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+	req.ChainID = chainID
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("Failed to begin transaction", "error", err)
+		return &SetContractActiveResponse{
+			Success: false,
+			Message: "Failed to update contract",
+		}, nil
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE contracts SET is_active = $1, updated_at = NOW() WHERE chain_id = $2 AND address = $3",
+		req.IsActive, chainID, req.Address,
+	)
+	if err != nil {
+		s.logger.Error("Failed to update contract active flag", "error", err)
+		return &SetContractActiveResponse{
+			Success: false,
+			Message: "Failed to update contract",
+		}, nil
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("Failed to get rows affected", "error", err)
+		return &SetContractActiveResponse{
+			Success: false,
+			Message: "Failed to update contract",
+		}, nil
+	}
+
+	if rowsAffected == 0 {
+		return &SetContractActiveResponse{
+			Success: false,
+			Message: "Contract not found",
+		}, nil
+	}
+
+	// Keep the indexer's reported status in line with the flag
+	status := "active"
+	if !req.IsActive {
+		status = "paused"
+	}
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE indexer_state SET status = $1, updated_at = NOW() WHERE chain_id = $2 AND contract_address = $3",
+		status, chainID, req.Address,
+	); err != nil {
+		s.logger.Error("Failed to update indexer status", "error", err)
+		return &SetContractActiveResponse{
+			Success: false,
+			Message: "Failed to update contract",
+		}, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("Failed to commit contract update", "error", err)
+		return &SetContractActiveResponse{
+			Success: false,
+			Message: "Failed to update contract",
+		}, nil
+	}
+
+	s.logger.Info("Contract active flag updated", "chain_id", chainID, "address", req.Address, "is_active", req.IsActive)
+
+	message := "Contract indexing resumed"
+	if !req.IsActive {
+		message = "Contract indexing paused"
+	}
+	return &SetContractActiveResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
 // TriggerBackfill triggers a historical backfill for a contract
 func (s *AdminService) TriggerBackfill(ctx context.Context, req *BackfillRequest) (*BackfillResponse, error) {
+	return s.queueJob(ctx, req, models.BackfillKindFetch)
+}
+
+// TriggerRedecode queues a job that decodes the contract's stored events in
+// the block range again from their raw logs with the contract's current ABIs,
+// rewriting their names and args without calling the RPC. It shares the
+// backfill queue and reports progress through GetBackfillJob.
+func (s *AdminService) TriggerRedecode(ctx context.Context, req *BackfillRequest) (*BackfillResponse, error) {
+	return s.queueJob(ctx, req, models.BackfillKindRedecode)
+}
+
+// queueJob validates a backfill or redecode request and queues the job for
+// the indexer-service backfill worker
+func (s *AdminService) queueJob(ctx context.Context, req *BackfillRequest, kind string) (*BackfillResponse, error) {
+	label := "Backfill"
+	if kind == models.BackfillKindRedecode {
+		label = "Redecode"
+	}
+
+	chainID, ok := s.resolveChainID(req.ChainID)
+	if !ok {
+		return &BackfillResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+
 	// Validate address
 	addr := models.Address(req.Address)
 	if err := addr.Validate(); err != nil {
@@ -254,68 +429,119 @@ func (s *AdminService) TriggerBackfill(ctx context.Context, req *BackfillRequest
 		}, nil
 	}
 
-	// Generate job ID
-	jobID := fmt.Sprintf("backfill_%s_%d_%d_%d", req.Address, req.FromBlock, req.ToBlock, time.Now().Unix())
+	// Blocks past the chain head do not exist yet; the live indexer covers
+	// them once they are mined
+	latest, ok, err := s.latestBlock(ctx, chainID)
+	if err != nil {
+		s.logger.Error("Failed to get chain head", "chain_id", chainID, "error", err)
+		return &BackfillResponse{
+			Success: false,
+			Message: "Failed to create " + strings.ToLower(label) + " job",
+		}, nil
+	}
+	if ok && req.ToBlock > latest {
+		return &BackfillResponse{
+			Success: false,
+			Message: fmt.Sprintf("to_block %d is beyond the chain head %d", req.ToBlock, latest),
+		}, nil
+	}
 
-	// Store backfill job in Redis
-	jobData := map[string]interface{}{
-		"address":    req.Address,
-		"from_block": req.FromBlock,
-		"to_block":   req.ToBlock,
-		"status":     "pending",
-		"created_at": time.Now().Unix(),
+	chunkSize := req.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = int32(s.config.ChunkSize)
 	}
 
-	// Store job metadata
-	jobKey := fmt.Sprintf("backfill_job:%s", jobID)
-	if err := s.redisClient.HSet(ctx, jobKey, jobData).Err(); err != nil {
-		s.logger.Error("Failed to store backfill job", "error", err)
+	// The job must reference a monitored contract (backfill_jobs has a foreign key)
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM contracts WHERE chain_id = $1 AND address = $2)", chainID, req.Address).Scan(&exists); err != nil {
+		s.logger.Error("Failed to check contract", "error", err)
+		return &BackfillResponse{
+			Success: false,
+			Message: "Failed to create " + strings.ToLower(label) + " job",
+		}, nil
+	}
+	if !exists {
 		return &BackfillResponse{
 			Success: false,
-			Message: "Failed to create backfill job",
+			Message: "Contract not found",
 		}, nil
 	}
 
-	// Set job expiration (24 hours)
-	if err := s.redisClient.Expire(ctx, jobKey, 24*time.Hour).Err(); err != nil {
-		s.logger.Warn("Failed to set job expiration", "error", err)
+	// Queue the job; the indexer-service backfill worker claims pending jobs.
+	// current_block starts one below from_block so that no block of the range
+	// counts as processed, including block 0
+	insertQuery := `
+		INSERT INTO backfill_jobs (chain_id, contract_address, from_block, to_block, current_block, chunk_size, kind, status)
+		VALUES ($1, $2, $3, $4, $3 - 1, $5, $6, $7)
+		RETURNING id
+	`
+
+	var jobID string
+	if err := s.db.QueryRowContext(ctx,
+		insertQuery,
+		chainID,
+		req.Address,
+		req.FromBlock,
+		req.ToBlock,
+		chunkSize,
+		kind,
+		models.BackfillStatusPending,
+	).Scan(&jobID); err != nil {
+		s.logger.Error("Failed to store backfill job", "kind", kind, "error", err)
+		return &BackfillResponse{
+			Success: false,
+			Message: "Failed to create " + strings.ToLower(label) + " job",
+		}, nil
 	}
 
 	// Calculate estimated time (simplified)
 	blockRange := req.ToBlock - req.FromBlock
 	estimatedBlocksPerMinute := 100 // Simplified estimate
+	if kind == models.BackfillKindRedecode {
+		// Redecoding reads stored events instead of calling the RPC
+		estimatedBlocksPerMinute = 1000
+	}
 	estimatedMinutes := int64(blockRange) / int64(estimatedBlocksPerMinute)
 	if estimatedMinutes < 1 {
 		estimatedMinutes = 1
 	}
 
-	s.logger.Info("Backfill job created", "job_id", jobID, "address", req.Address, "from_block", req.FromBlock, "to_block", req.ToBlock)
+	s.logger.Info(label+" job created", "job_id", jobID, "chain_id", chainID, "address", req.Address, "from_block", req.FromBlock, "to_block", req.ToBlock, "chunk_size", chunkSize)
 
 	return &BackfillResponse{
 		Success:          true,
 		JobID:            jobID,
-		Message:          "Backfill job created successfully",
+		Message:          label + " job created successfully",
 		EstimatedMinutes: estimatedMinutes,
 	}, nil
 }
 
-// GetContract fetches a contract by address.
-func (s *AdminService) GetContract(ctx context.Context, address string) (*models.Contract, error) {
+// GetContract fetches a contract by chain and address. A zero chainID uses the default chain.
+func (s *AdminService) GetContract(ctx context.Context, chainID int64, address string) (*models.Contract, error) {
+	if chainID == 0 {
+		chainID = s.config.DefaultChainID
+	}
+
 	query := `
-		SELECT id, address, abi, name, start_block, current_block, confirm_blocks, created_at, updated_at
+		SELECT id, chain_id, address, abi, name, start_block, current_block, confirm_blocks, is_active, event_filter,
+		       decode_anonymous_events, created_at, updated_at
 		FROM contracts
-		WHERE address = $1
+		WHERE chain_id = $1 AND address = $2
 	`
-	row := s.db.QueryRowContext(ctx, query, address)
+	row := s.db.QueryRowContext(ctx, query, chainID, address)
 	var contract models.Contract
 	if err := row.Scan(
 		&contract.ID,
+		&contract.ChainID,
 		&contract.Address,
 		&contract.ABI,
 		&contract.Name,
 		&contract.StartBlock,
 		&contract.CurrentBlock,
 		&contract.ConfirmBlocks,
+		&contract.IsActive,
+		&contract.Filter,
+		&contract.DecodeAnonymousEvents,
 		&contract.CreatedAt,
 		&contract.UpdatedAt,
 	); err != nil {
@@ -327,8 +553,9 @@ func (s *AdminService) GetContract(ctx context.Context, address string) (*models
 	return &contract, nil
 }
 
-// ListContracts returns paginated contracts.
-func (s *AdminService) ListContracts(ctx context.Context, limit, offset int32) ([]*models.Contract, int32, error) {
+// ListContracts returns paginated contracts. A zero chainID lists every chain
+// and a nil isActive lists both active and paused contracts.
+func (s *AdminService) ListContracts(ctx context.Context, chainID int64, isActive *bool, limit, offset int32) ([]*models.Contract, int32, error) {
 	if limit <= 0 {
 		limit = 20
 	}
@@ -336,13 +563,20 @@ func (s *AdminService) ListContracts(ctx context.Context, limit, offset int32) (
 		offset = 0
 	}
 
+	where := `
+		WHERE ($1 = 0 OR chain_id = $1)
+		  AND ($2::boolean IS NULL OR is_active = $2)
+	`
+
 	query := `
-		SELECT id, address, abi, name, start_block, current_block, confirm_blocks, created_at, updated_at
+		SELECT id, chain_id, address, abi, name, start_block, current_block, confirm_blocks, is_active, event_filter,
+		       decode_anonymous_events, created_at, updated_at
 		FROM contracts
+	` + where + `
 		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
+		LIMIT $3 OFFSET $4
 	`
-	rows, err := s.db.QueryContext(ctx, query, limit, offset)
+	rows, err := s.db.QueryContext(ctx, query, chainID, isActive, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -353,12 +587,16 @@ func (s *AdminService) ListContracts(ctx context.Context, limit, offset int32) (
 		var contract models.Contract
 		if err := rows.Scan(
 			&contract.ID,
+			&contract.ChainID,
 			&contract.Address,
 			&contract.ABI,
 			&contract.Name,
 			&contract.StartBlock,
 			&contract.CurrentBlock,
 			&contract.ConfirmBlocks,
+			&contract.IsActive,
+			&contract.Filter,
+			&contract.DecodeAnonymousEvents,
 			&contract.CreatedAt,
 			&contract.UpdatedAt,
 		); err != nil {
@@ -368,65 +606,73 @@ func (s *AdminService) ListContracts(ctx context.Context, limit, offset int32) (
 	}
 
 	var total int32
-	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM contracts").Scan(&total); err != nil {
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM contracts"+where, chainID, isActive).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
 	return contracts, total, nil
 }
 
-// GetBackfillJob retrieves job info stored in Redis.
+// GetBackfillJob retrieves job progress from the backfill_jobs table.
 func (s *AdminService) GetBackfillJob(ctx context.Context, jobID string) (*BackfillJob, error) {
-	jobKey := fmt.Sprintf("backfill_job:%s", jobID)
-	data, err := s.redisClient.HGetAll(ctx, jobKey).Result()
-	if err != nil {
-		return nil, err
-	}
-	if len(data) == 0 {
-		return nil, nil
-	}
-
-	parseInt := func(key string) int64 {
-		str := data[key]
-		if str == "" {
-			return 0
-		}
-		val, _ := strconv.ParseInt(str, 10, 64)
-		return val
-	}
+	query := `
+		SELECT id, chain_id, contract_address, from_block, to_block, current_block, kind,
+		       events_updated, events_skipped, status, error_message, created_at, updated_at,
+		       completed_at
+		FROM backfill_jobs
+		WHERE id::text = $1
+	`
 
-	parseFloat := func(key string) float64 {
-		str := data[key]
-		if str == "" {
-			return 0
+	var (
+		job          models.BackfillJob
+		errorMessage sql.NullString
+		completedAt  sql.NullTime
+	)
+	err := s.db.QueryRowContext(ctx, query, jobID).Scan(
+		&job.ID,
+		&job.ChainID,
+		&job.ContractAddress,
+		&job.FromBlock,
+		&job.ToBlock,
+		&job.CurrentBlock,
+		&job.Kind,
+		&job.EventsUpdated,
+		&job.EventsSkipped,
+		&job.Status,
+		&errorMessage,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
 		}
-		val, _ := strconv.ParseFloat(str, 64)
-		return val
-	}
-
-	job := &BackfillJob{
-		ID:              jobID,
-		ContractAddress: data["address"],
-		FromBlock:       parseInt("from_block"),
-		ToBlock:         parseInt("to_block"),
-		CurrentBlock:    parseInt("current_block"),
-		Status:          data["status"],
-		ErrorMessage:    data["error_message"],
-		Progress:        parseFloat("progress"),
-	}
-
-	if created := parseInt("created_at"); created > 0 {
-		job.CreatedAt = time.Unix(created, 0)
-	}
-	if updated := parseInt("updated_at"); updated > 0 {
-		job.UpdatedAt = time.Unix(updated, 0)
-	}
-	if completed := parseInt("completed_at"); completed > 0 {
-		t := time.Unix(completed, 0)
-		job.CompletedAt = &t
+		return nil, err
 	}
 
-	return job, nil
+	result := &BackfillJob{
+		ID:              job.ID,
+		ChainID:         job.ChainID,
+		ContractAddress: string(job.ContractAddress),
+		FromBlock:       job.FromBlock,
+		ToBlock:         job.ToBlock,
+		CurrentBlock:    job.CurrentBlock,
+		Kind:            job.Kind,
+		EventsUpdated:   job.EventsUpdated,
+		EventsSkipped:   job.EventsSkipped,
+		Status:          job.Status,
+		ErrorMessage:    errorMessage.String,
+		Progress:        job.Progress(),
+		CreatedAt:       job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
+	}
+	if completedAt.Valid {
+		t := completedAt.Time
+		result.CompletedAt = &t
+	}
+
+	return result, nil
 }
 
 // GetSystemStatus returns the current system status
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// chainHeadTimeout bounds the eth_blockNumber call made while validating a
+// request
+const chainHeadTimeout = 10 * time.Second
+
+// latestBlock returns the chain's head block number from its RPC endpoint.
+// ok is false when no endpoint is configured for the chain.
+func (s *AdminService) latestBlock(ctx context.Context, chainID int64) (block int64, ok bool, err error) {
+	endpoint := s.config.RPCEndpointFor(chainID)
+	if endpoint == "" {
+		return 0, false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, chainHeadTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "eth_blockNumber",
+		"params":  []interface{}{},
+	})
+	if err != nil {
+		return 0, true, fmt.Errorf("failed to encode eth_blockNumber request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, true, fmt.Errorf("failed to create eth_blockNumber request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return 0, true, fmt.Errorf("failed to call eth_blockNumber: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, true, fmt.Errorf("eth_blockNumber returned status %d", resp.StatusCode)
+	}
+
+	var rpcResp struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return 0, true, fmt.Errorf("failed to decode eth_blockNumber response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return 0, true, fmt.Errorf("eth_blockNumber failed: %s", rpcResp.Error.Message)
+	}
+
+	block, err = strconv.ParseInt(strings.TrimPrefix(rpcResp.Result, "0x"), 16, 64)
+	if err != nil {
+		return 0, true, fmt.Errorf("failed to parse block number %q: %w", rpcResp.Result, err)
+	}
+	return block, true, nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// UpdateContractFilterRequest represents a request to replace a contract's event filter
+type UpdateContractFilterRequest struct {
+	ChainID int64                 `json:"chain_id"`
+	Address string                `json:"address"`
+	Filter  models.ContractFilter `json:"filter"`
+}
+
+// UpdateContractFilterResponse represents the response for updating a contract's event filter
+type UpdateContractFilterResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// UpdateContractFilter replaces the event filter of a contract. The indexer
+// applies it to the blocks it indexes from its next poll on. Stored events are
+// never re-filtered: events a narrower filter excludes are kept, and events a
+// wider filter allows are only indexed for older blocks by a backfill.
+func (s *AdminService) UpdateContractFilter(ctx context.Context, req *UpdateContractFilterRequest) (*UpdateContractFilterResponse, error) {
+	chainID, ok := s.resolveChainID(req.ChainID)
+	if !ok {
+		return &UpdateContractFilterResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+
+	var abiJSON string
+	err := s.db.QueryRowContext(ctx,
+		"SELECT abi FROM contracts WHERE chain_id = $1 AND address = $2",
+		chainID, req.Address,
+	).Scan(&abiJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &UpdateContractFilterResponse{
+			Success: false,
+			Message: "Contract not found",
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to load contract", "error", err)
+		return &UpdateContractFilterResponse{
+			Success: false,
+			Message: "Failed to update contract filter",
+		}, nil
+	}
+
+	events, err := s.contractEventNames(ctx, chainID, req.Address, abiJSON)
+	if err != nil {
+		s.logger.Error("Failed to read contract ABIs", "error", err, "chain_id", chainID, "address", req.Address)
+		return &UpdateContractFilterResponse{
+			Success: false,
+			Message: "Failed to update contract filter",
+		}, nil
+	}
+
+	filter, message := validateContractFilter(events, req.Filter)
+	if message != "" {
+		return &UpdateContractFilterResponse{
+			Success: false,
+			Message: message,
+		}, nil
+	}
+
+	// Factory rules need their creation events to be indexed
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT DISTINCT event_name FROM contract_factories WHERE chain_id = $1 AND contract_address = $2",
+		chainID, req.Address,
+	)
+	if err != nil {
+		s.logger.Error("Failed to load contract factories", "error", err)
+		return &UpdateContractFilterResponse{
+			Success: false,
+			Message: "Failed to update contract filter",
+		}, nil
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var eventName string
+		if err := rows.Scan(&eventName); err != nil {
+			s.logger.Error("Failed to load contract factories", "error", err)
+			return &UpdateContractFilterResponse{
+				Success: false,
+				Message: "Failed to update contract filter",
+			}, nil
+		}
+		if !filterAllowsEvent(filter, eventName) {
+			return &UpdateContractFilterResponse{
+				Success: false,
+				Message: fmt.Sprintf("Event %s registers child contracts and cannot be filtered out", eventName),
+			}, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error("Failed to load contract factories", "error", err)
+		return &UpdateContractFilterResponse{
+			Success: false,
+			Message: "Failed to update contract filter",
+		}, nil
+	}
+
+	if _, err := s.db.ExecContext(ctx,
+		"UPDATE contracts SET event_filter = $1, updated_at = NOW() WHERE chain_id = $2 AND address = $3",
+		filter, chainID, req.Address,
+	); err != nil {
+		s.logger.Error("Failed to update contract filter", "error", err)
+		return &UpdateContractFilterResponse{
+			Success: false,
+			Message: "Failed to update contract filter",
+		}, nil
+	}
+
+	s.logger.Info("Contract filter updated", "chain_id", chainID, "address", req.Address, "events", filter.Events, "topic_filters", len(filter.Topics))
+
+	message = "Contract filter updated; it applies to blocks indexed from now on"
+	if filter.IsEmpty() {
+		message = "Contract filter removed; every event is indexed from now on"
+	}
+	return &UpdateContractFilterResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+// abiEventNames returns the events of an ABI a filter can select: the name
+// and full signature of every event with a signature topic. Anonymous events
+// have none and are left out.
+func abiEventNames(abiJSON string) (map[string]bool, error) {
+	var entries []abiEventEntry
+	if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+		return nil, err
+	}
+
+	events := make(map[string]bool)
+	for k := range entries {
+		entry := &entries[k]
+		if entry.Type == "event" && !entry.Anonymous {
+			events[entry.Name] = true
+			events[eventSignature(entry)] = true
+		}
+	}
+	return events, nil
+}
+
+// validateContractFilter normalizes a filter and checks that every event it
+// names, by name or full signature, is declared in the ABI and not anonymous. It returns a message describing the problem
+// when the filter is invalid.
+func validateContractFilter(events map[string]bool, filter models.ContractFilter) (models.ContractFilter, string) {
+	normalized, err := filter.Normalize()
+	if err != nil {
+		return models.ContractFilter{}, err.Error()
+	}
+	for _, name := range normalized.Events {
+		if !events[name] {
+			return models.ContractFilter{}, fmt.Sprintf("Event %s is not in the contract ABI or is anonymous", name)
+		}
+	}
+	return normalized, ""
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// AddContractFactoryRequest represents a request to register a factory rule on
+// a contract
+type AddContractFactoryRequest struct {
+	ChainID    int64  `json:"chain_id"`
+	Address    string `json:"address"`
+	EventName  string `json:"event_name"`
+	AddressArg string `json:"address_arg"`
+	ChildABI   string `json:"child_abi"`
+	ChildName  string `json:"child_name,omitempty"`
+	FromBlock  int64  `json:"from_block"`
+}
+
+// AddContractFactoryResponse represents the response for registering a factory rule
+type AddContractFactoryResponse struct {
+	Success bool                    `json:"success"`
+	Factory *models.ContractFactory `json:"factory,omitempty"`
+	Message string                  `json:"message"`
+}
+
+// AddContractFactory registers a factory rule: whenever the contract emits
+// EventName from FromBlock on, the indexer adds the address in its AddressArg
+// argument as a contract with ChildABI, indexed from the block of the event
+// and with the factory's confirmations. Children are found by the live
+// indexer and by backfills of the factory, and addresses already monitored
+// are left alone.
+func (s *AdminService) AddContractFactory(ctx context.Context, req *AddContractFactoryRequest) (*AddContractFactoryResponse, error) {
+	chainID, ok := s.resolveChainID(req.ChainID)
+	if !ok {
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+
+	if req.EventName == "" || req.AddressArg == "" {
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: "event_name and address_arg are required",
+		}, nil
+	}
+	if req.FromBlock < 0 {
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: "Invalid from_block",
+		}, nil
+	}
+	if _, err := abiEventNames(req.ChildABI); err != nil {
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: "Invalid child ABI JSON",
+		}, nil
+	}
+
+	var (
+		address      models.Address
+		name         string
+		abiJSON      string
+		currentBlock int64
+		filter       models.ContractFilter
+	)
+	err := s.db.QueryRowContext(ctx,
+		"SELECT address, name, abi, current_block, event_filter FROM contracts WHERE chain_id = $1 AND address = $2",
+		chainID, req.Address,
+	).Scan(&address, &name, &abiJSON, &currentBlock, &filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: "Contract not found",
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to load contract", "error", err)
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: "Failed to add contract factory",
+		}, nil
+	}
+
+	found, err := s.contractHasAddressArg(ctx, chainID, address, abiJSON, req.EventName, req.AddressArg)
+	if err != nil {
+		s.logger.Error("Failed to read contract ABIs", "error", err, "chain_id", chainID, "address", address)
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: "Failed to add contract factory",
+		}, nil
+	}
+	if !found {
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: fmt.Sprintf("Event %s has no address argument %s in the contract ABI", req.EventName, req.AddressArg),
+		}, nil
+	}
+	if !filterAllowsEvent(filter, req.EventName) {
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: fmt.Sprintf("Event %s is excluded by the contract's event filter", req.EventName),
+		}, nil
+	}
+
+	childName := req.ChildName
+	if childName == "" {
+		childName = name + " child"
+	}
+
+	factory := &models.ContractFactory{
+		ChainID:         chainID,
+		ContractAddress: address,
+		EventName:       req.EventName,
+		AddressArg:      req.AddressArg,
+		ChildABI:        req.ChildABI,
+		ChildName:       childName,
+		FromBlock:       req.FromBlock,
+	}
+	insertQuery := `
+		INSERT INTO contract_factories (chain_id, contract_address, event_name, address_arg, child_abi, child_name, from_block)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chain_id, contract_address, event_name, address_arg) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+	err = s.db.QueryRowContext(ctx,
+		insertQuery,
+		factory.ChainID,
+		factory.ContractAddress,
+		factory.EventName,
+		factory.AddressArg,
+		factory.ChildABI,
+		factory.ChildName,
+		factory.FromBlock,
+	).Scan(&factory.ID, &factory.CreatedAt, &factory.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: fmt.Sprintf("A factory rule for %s(%s) already exists", req.EventName, req.AddressArg),
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to store contract factory", "error", err)
+		return &AddContractFactoryResponse{
+			Success: false,
+			Message: "Failed to add contract factory",
+		}, nil
+	}
+
+	s.logger.Info("Contract factory added", "chain_id", chainID, "address", address, "factory_id", factory.ID, "event", req.EventName, "address_arg", req.AddressArg, "from_block", req.FromBlock)
+
+	message := "Contract factory added; children are registered as its events are indexed"
+	if req.FromBlock <= currentBlock {
+		message += fmt.Sprintf("; backfill blocks %d-%d of the factory to register children created there", req.FromBlock, currentBlock)
+	}
+	return &AddContractFactoryResponse{
+		Success: true,
+		Factory: factory,
+		Message: message,
+	}, nil
+}
+
+// ListContractFactories lists the factory rules of a contract with the number
+// of children each has registered. A zero chainID uses the default chain.
+func (s *AdminService) ListContractFactories(ctx context.Context, chainID int64, address string) ([]*models.ContractFactory, error) {
+	if chainID == 0 {
+		chainID = s.config.DefaultChainID
+	}
+
+	query := `
+		SELECT f.id, f.chain_id, f.contract_address, f.event_name, f.address_arg, f.child_abi,
+		       f.child_name, f.from_block,
+		       (SELECT COUNT(*) FROM contracts c WHERE c.factory_id = f.id),
+		       f.created_at, f.updated_at
+		FROM contract_factories f
+		WHERE f.chain_id = $1 AND f.contract_address = $2
+		ORDER BY f.id ASC
+	`
+	rows, err := s.db.QueryContext(ctx, query, chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	factories := make([]*models.ContractFactory, 0)
+	for rows.Next() {
+		var factory models.ContractFactory
+		if err := rows.Scan(
+			&factory.ID,
+			&factory.ChainID,
+			&factory.ContractAddress,
+			&factory.EventName,
+			&factory.AddressArg,
+			&factory.ChildABI,
+			&factory.ChildName,
+			&factory.FromBlock,
+			&factory.Children,
+			&factory.CreatedAt,
+			&factory.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		factories = append(factories, &factory)
+	}
+	return factories, rows.Err()
+}
+
+// contractHasAddressArg reports whether eventName is declared with an address
+// argument named arg in any ABI of a contract: its own or an attached version
+func (s *AdminService) contractHasAddressArg(ctx context.Context, chainID int64, address models.Address, abiJSON, eventName, arg string) (bool, error) {
+	abis := []string{abiJSON}
+
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT abi FROM contract_abi_versions WHERE chain_id = $1 AND contract_address = $2",
+		chainID, address,
+	)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var versionABI string
+		if err := rows.Scan(&versionABI); err != nil {
+			return false, err
+		}
+		abis = append(abis, versionABI)
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	for _, abiJSON := range abis {
+		var entries []struct {
+			Type   string `json:"type"`
+			Name   string `json:"name"`
+			Inputs []struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"inputs"`
+		}
+		if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+			return false, err
+		}
+		for _, entry := range entries {
+			if entry.Type != "event" || entry.Name != eventName {
+				continue
+			}
+			for _, input := range entry.Inputs {
+				if input.Name == arg && input.Type == "address" {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// filterAllowsEvent reports whether a contract's event filter lets eventName
+// through, by name or by the signature of one of its overloads
+func filterAllowsEvent(filter models.ContractFilter, eventName string) bool {
+	if len(filter.Events) == 0 {
+		return true
+	}
+	for _, name := range filter.Events {
+		if name == eventName || strings.HasPrefix(name, eventName+"(") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// CreateTopicSubscriptionRequest represents a request to index an event
+// signature from any emitter
+type CreateTopicSubscriptionRequest struct {
+	ChainID       int64  `json:"chain_id"`
+	Name          string `json:"name"`
+	ABI           string `json:"abi"`
+	EventName     string `json:"event_name,omitempty"`
+	StartBlock    int64  `json:"start_block"`
+	ConfirmBlocks int32  `json:"confirm_blocks"`
+}
+
+// CreateTopicSubscriptionResponse represents the response for creating a topic subscription
+type CreateTopicSubscriptionResponse struct {
+	Success      bool                      `json:"success"`
+	Subscription *models.TopicSubscription `json:"subscription,omitempty"`
+	Message      string                    `json:"message"`
+}
+
+// DeleteTopicSubscriptionResponse represents the response for deleting a topic subscription
+type DeleteTopicSubscriptionResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// abiEventEntry is an event declaration in an ABI, with the components of
+// tuple arguments
+type abiEventEntry struct {
+	Type      string        `json:"type"`
+	Name      string        `json:"name"`
+	Anonymous bool          `json:"anonymous"`
+	Inputs    []abiArgument `json:"inputs"`
+}
+
+// abiArgument is an event argument or tuple component in an ABI
+type abiArgument struct {
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Components []abiArgument `json:"components"`
+}
+
+// CreateTopicSubscription registers a topic subscription: the indexer fetches
+// the event from every contract on the chain from StartBlock on, decodes it
+// with the ABI fragment and stores it as an event of the emitting address.
+// The fragment may declare a single event, in which case EventName is
+// optional.
+func (s *AdminService) CreateTopicSubscription(ctx context.Context, req *CreateTopicSubscriptionRequest) (*CreateTopicSubscriptionResponse, error) {
+	chainID, ok := s.resolveChainID(req.ChainID)
+	if !ok {
+		return &CreateTopicSubscriptionResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+
+	if req.Name == "" {
+		return &CreateTopicSubscriptionResponse{
+			Success: false,
+			Message: "name is required",
+		}, nil
+	}
+	if req.StartBlock < 0 {
+		return &CreateTopicSubscriptionResponse{
+			Success: false,
+			Message: "Invalid start_block",
+		}, nil
+	}
+	if req.ConfirmBlocks == 0 {
+		req.ConfirmBlocks = 6
+	}
+	if req.ConfirmBlocks < 1 || req.ConfirmBlocks > 100 {
+		return &CreateTopicSubscriptionResponse{
+			Success: false,
+			Message: "confirm_blocks must be between 1 and 100",
+		}, nil
+	}
+
+	event, message := subscribedEvent(req.ABI, req.EventName)
+	if message != "" {
+		return &CreateTopicSubscriptionResponse{
+			Success: false,
+			Message: message,
+		}, nil
+	}
+
+	subscription := &models.TopicSubscription{
+		ChainID:        chainID,
+		Name:           req.Name,
+		ABI:            req.ABI,
+		EventName:      event.Name,
+		EventSignature: eventSignature(event),
+		StartBlock:     req.StartBlock,
+		ConfirmBlocks:  int(req.ConfirmBlocks),
+		IsActive:       true,
+		CurrentBlock:   req.StartBlock - 1,
+	}
+	insertQuery := `
+		INSERT INTO topic_subscriptions (chain_id, name, abi, event_name, event_signature, start_block, confirm_blocks)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chain_id, name) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+	err := s.db.QueryRowContext(ctx,
+		insertQuery,
+		subscription.ChainID,
+		subscription.Name,
+		subscription.ABI,
+		subscription.EventName,
+		subscription.EventSignature,
+		subscription.StartBlock,
+		subscription.ConfirmBlocks,
+	).Scan(&subscription.ID, &subscription.CreatedAt, &subscription.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &CreateTopicSubscriptionResponse{
+			Success: false,
+			Message: fmt.Sprintf("A topic subscription named %s already exists", req.Name),
+		}, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to store topic subscription", "error", err)
+		return &CreateTopicSubscriptionResponse{
+			Success: false,
+			Message: "Failed to create topic subscription",
+		}, nil
+	}
+
+	s.logger.Info("Topic subscription created", "id", subscription.ID, "chain_id", chainID, "signature", subscription.EventSignature, "start_block", req.StartBlock)
+
+	return &CreateTopicSubscriptionResponse{
+		Success:      true,
+		Subscription: subscription,
+		Message:      "Topic subscription created; its events are indexed from every emitter on the chain",
+	}, nil
+}
+
+// ListTopicSubscriptions returns topic subscriptions with their indexing
+// position. A zero chainID lists every chain.
+func (s *AdminService) ListTopicSubscriptions(ctx context.Context, chainID int64) ([]*models.TopicSubscription, error) {
+	query := `
+		SELECT t.id, t.chain_id, t.name, t.abi, t.event_name, t.event_signature, t.start_block,
+		       t.confirm_blocks, t.is_active, COALESCE(st.last_indexed_block, t.start_block - 1),
+		       t.created_at, t.updated_at
+		FROM topic_subscriptions t
+		LEFT JOIN indexer_state st ON st.subscription_id = t.id
+		WHERE $1 = 0 OR t.chain_id = $1
+		ORDER BY t.id ASC
+	`
+	rows, err := s.db.QueryContext(ctx, query, chainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*models.TopicSubscription, 0)
+	for rows.Next() {
+		var subscription models.TopicSubscription
+		if err := rows.Scan(
+			&subscription.ID,
+			&subscription.ChainID,
+			&subscription.Name,
+			&subscription.ABI,
+			&subscription.EventName,
+			&subscription.EventSignature,
+			&subscription.StartBlock,
+			&subscription.ConfirmBlocks,
+			&subscription.IsActive,
+			&subscription.CurrentBlock,
+			&subscription.CreatedAt,
+			&subscription.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, &subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+// DeleteTopicSubscription stops a topic subscription and drops its indexing
+// position. The events it indexed are kept.
+func (s *AdminService) DeleteTopicSubscription(ctx context.Context, id int64) (*DeleteTopicSubscriptionResponse, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM topic_subscriptions WHERE id = $1", id)
+	if err != nil {
+		s.logger.Error("Failed to delete topic subscription", "error", err)
+		return &DeleteTopicSubscriptionResponse{
+			Success: false,
+			Message: "Failed to delete topic subscription",
+		}, nil
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("Failed to get rows affected", "error", err)
+		return &DeleteTopicSubscriptionResponse{
+			Success: false,
+			Message: "Failed to delete topic subscription",
+		}, nil
+	}
+
+	if rowsAffected == 0 {
+		return &DeleteTopicSubscriptionResponse{
+			Success: false,
+			Message: "Subscription not found",
+		}, nil
+	}
+
+	s.logger.Info("Topic subscription deleted", "id", id)
+
+	return &DeleteTopicSubscriptionResponse{
+		Success: true,
+		Message: "Topic subscription deleted successfully",
+	}, nil
+}
+
+// subscribedEvent finds the event a subscription follows in its ABI
+// fragment: the one named eventName, which may be a full signature to pick an
+// overload, or the only event when eventName is empty. It returns a message
+// describing the problem when there is none.
+func subscribedEvent(abiJSON, eventName string) (*abiEventEntry, string) {
+	var entries []abiEventEntry
+	if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+		return nil, "Invalid ABI JSON"
+	}
+
+	var events []*abiEventEntry
+	for k := range entries {
+		entry := &entries[k]
+		if entry.Type != "event" {
+			continue
+		}
+		if eventName == "" || entry.Name == eventName || eventSignature(entry) == eventName {
+			events = append(events, entry)
+		}
+	}
+
+	switch {
+	case len(events) == 0 && eventName != "":
+		return nil, fmt.Sprintf("Event %s is not in the ABI", eventName)
+	case len(events) == 0:
+		return nil, "The ABI declares no events"
+	case len(events) > 1 && eventName == "":
+		return nil, "The ABI declares several events; set event_name"
+	case len(events) > 1:
+		return nil, fmt.Sprintf("Event %s is overloaded in the ABI; set event_name to the full signature of one overload", eventName)
+	case events[0].Anonymous:
+		return nil, fmt.Sprintf("Event %s is anonymous and has no signature topic to subscribe to", events[0].Name)
+	}
+	return events[0], ""
+}
+
+// eventSignature returns the canonical signature of an event, e.g.
+// Transfer(address,address,uint256)
+func eventSignature(event *abiEventEntry) string {
+	return event.Name + "(" + argumentTypes(event.Inputs) + ")"
+}
+
+// argumentTypes joins the canonical types of arguments, spelling tuples out
+// as their component types
+func argumentTypes(args []abiArgument) string {
+	types := make([]string, len(args))
+	for k, arg := range args {
+		types[k] = arg.Type
+		if strings.HasPrefix(arg.Type, "tuple") {
+			types[k] = "(" + argumentTypes(arg.Components) + ")" + strings.TrimPrefix(arg.Type, "tuple")
+		}
+	}
+	return strings.Join(types, ",")
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// RetryUnknownLogsResponse represents the response for a retry request
+type RetryUnknownLogsResponse struct {
+	Success bool   `json:"success"`
+	Queued  int64  `json:"queued"`
+	Message string `json:"message"`
+}
+
+// unknownLogsWhere restricts unknown logs to a contract and block range; a
+// zero bound leaves that side of the range open
+const unknownLogsWhere = `
+		WHERE chain_id = $1 AND contract_address = $2
+		  AND ($3 = 0 OR block_number >= $3)
+		  AND ($4 = 0 OR block_number <= $4)
+	`
+
+// ListUnknownLogs lists the logs of a contract that could not be decoded, in
+// block order. A zero chainID uses the default chain.
+func (s *AdminService) ListUnknownLogs(ctx context.Context, chainID int64, address string, fromBlock, toBlock int64, limit, offset int32) ([]*models.UnknownLog, int32, error) {
+	if chainID == 0 {
+		chainID = s.config.DefaultChainID
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT id, chain_id, contract_address, block_number, block_hash, block_timestamp,
+		       transaction_hash, transaction_index, log_index, topics, data, reason,
+		       retry_requested_at, created_at, updated_at
+		FROM unknown_logs
+	` + unknownLogsWhere + `
+		ORDER BY block_number ASC, log_index ASC
+		LIMIT $5 OFFSET $6
+	`
+	rows, err := s.db.QueryContext(ctx, query, chainID, address, fromBlock, toBlock, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	logs := make([]*models.UnknownLog, 0)
+	for rows.Next() {
+		var log models.UnknownLog
+		if err := rows.Scan(
+			&log.ID,
+			&log.ChainID,
+			&log.ContractAddress,
+			&log.BlockNumber,
+			&log.BlockHash,
+			&log.BlockTimestamp,
+			&log.TransactionHash,
+			&log.TransactionIndex,
+			&log.LogIndex,
+			&log.Topics,
+			&log.Data,
+			&log.Reason,
+			&log.RetryRequestedAt,
+			&log.CreatedAt,
+			&log.UpdatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		logs = append(logs, &log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int32
+	countQuery := "SELECT COUNT(*) FROM unknown_logs" + unknownLogsWhere
+	if err := s.db.QueryRowContext(ctx, countQuery, chainID, address, fromBlock, toBlock).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+// RetryUnknownLogs asks the indexer to decode a contract's unknown logs again
+// with its current ABIs, typically after the ABI was fixed or a version was
+// attached. The indexer picks them up on its next poll; logs that decode
+// become events and the others keep waiting with the new failure reason.
+func (s *AdminService) RetryUnknownLogs(ctx context.Context, chainID int64, address string, fromBlock, toBlock int64) (*RetryUnknownLogsResponse, error) {
+	chainID, ok := s.resolveChainID(chainID)
+	if !ok {
+		return &RetryUnknownLogsResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+	if fromBlock < 0 || toBlock < 0 || (toBlock > 0 && fromBlock > toBlock) {
+		return &RetryUnknownLogsResponse{
+			Success: false,
+			Message: "Invalid block range",
+		}, nil
+	}
+
+	query := "UPDATE unknown_logs SET retry_requested_at = NOW()" + unknownLogsWhere
+	result, err := s.db.ExecContext(ctx, query, chainID, address, fromBlock, toBlock)
+	if err != nil {
+		s.logger.Error("Failed to request unknown log retry", "error", err)
+		return &RetryUnknownLogsResponse{
+			Success: false,
+			Message: "Failed to retry unknown logs",
+		}, nil
+	}
+
+	queued, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("Failed to get rows affected", "error", err)
+		return &RetryUnknownLogsResponse{
+			Success: false,
+			Message: "Failed to retry unknown logs",
+		}, nil
+	}
+
+	if queued == 0 {
+		return &RetryUnknownLogsResponse{
+			Success: true,
+			Message: "No unknown logs in range",
+		}, nil
+	}
+
+	s.logger.Info("Unknown log retry requested", "chain_id", chainID, "address", address, "queued", queued)
+
+	return &RetryUnknownLogsResponse{
+		Success: true,
+		Queued:  queued,
+		Message: fmt.Sprintf("%d unknown logs queued; the indexer decodes them on its next poll", queued),
+	}, nil
+}
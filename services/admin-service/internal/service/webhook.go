@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// webhookSecretBytes is the size of generated webhook signing secrets
+const webhookSecretBytes = 32
+
+// CreateWebhookRequest represents a request to register a webhook
+type CreateWebhookRequest struct {
+	ChainID         int64                 `json:"chain_id"`
+	URL             string                `json:"url"`
+	ContractAddress string                `json:"contract_address"`
+	EventName       string                `json:"event_name"`
+	ArgFilters      []models.ArgPredicate `json:"arg_filters"`
+	Secret          string                `json:"secret"`
+	Description     string                `json:"description"`
+}
+
+// CreateWebhookResponse represents the response for registering a webhook.
+// Secret is only ever returned here.
+type CreateWebhookResponse struct {
+	Success bool            `json:"success"`
+	Webhook *models.Webhook `json:"webhook,omitempty"`
+	Secret  string          `json:"secret,omitempty"`
+	Message string          `json:"message"`
+}
+
+// DeleteWebhookResponse represents the response for deleting a webhook
+type DeleteWebhookResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// RetryWebhookDeliveryResponse represents the response for requeueing a delivery
+type RetryWebhookDeliveryResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// CreateWebhook registers a webhook. The indexer starts delivering matching
+// events once it refreshes its webhook list.
+func (s *AdminService) CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	chainID, ok := s.resolveChainID(req.ChainID)
+	if !ok {
+		return &CreateWebhookResponse{
+			Success: false,
+			Message: "Unsupported chain ID",
+		}, nil
+	}
+
+	// Validate URL
+	endpoint, err := url.Parse(req.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return &CreateWebhookResponse{
+			Success: false,
+			Message: "Invalid webhook URL",
+		}, nil
+	}
+
+	webhook := &models.Webhook{
+		ChainID:     chainID,
+		URL:         req.URL,
+		Description: req.Description,
+		ArgFilters:  models.ArgPredicates{},
+		IsActive:    true,
+	}
+
+	// Validate filters
+	if req.ContractAddress != "" {
+		addr := models.Address(req.ContractAddress)
+		if err := addr.Validate(); err != nil {
+			return &CreateWebhookResponse{
+				Success: false,
+				Message: "Invalid contract address",
+			}, nil
+		}
+		webhook.ContractAddress = &addr
+	}
+	if req.EventName != "" {
+		webhook.EventName = &req.EventName
+	}
+	for _, predicate := range req.ArgFilters {
+		if predicate.Op == "" {
+			predicate.Op = models.ArgOpEq
+		}
+		if err := predicate.Validate(); err != nil {
+			return &CreateWebhookResponse{
+				Success: false,
+				Message: fmt.Sprintf("Invalid argument filter: %v", err),
+			}, nil
+		}
+		webhook.ArgFilters = append(webhook.ArgFilters, predicate)
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		buf := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(buf); err != nil {
+			s.logger.Error("Failed to generate webhook secret", "error", err)
+			return &CreateWebhookResponse{
+				Success: false,
+				Message: "Failed to create webhook",
+			}, nil
+		}
+		secret = hex.EncodeToString(buf)
+	}
+	webhook.Secret = secret
+
+	insertQuery := `
+		INSERT INTO webhooks (chain_id, url, secret, description, contract_address, event_name, arg_filters)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+
+	if err := s.db.QueryRowContext(ctx,
+		insertQuery,
+		webhook.ChainID,
+		webhook.URL,
+		webhook.Secret,
+		webhook.Description,
+		webhook.ContractAddress,
+		webhook.EventName,
+		webhook.ArgFilters,
+	).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt); err != nil {
+		s.logger.Error("Failed to insert webhook", "error", err)
+		return &CreateWebhookResponse{
+			Success: false,
+			Message: "Failed to create webhook",
+		}, nil
+	}
+
+	s.logger.Info("Webhook created", "id", webhook.ID, "chain_id", chainID, "url", webhook.URL)
+
+	return &CreateWebhookResponse{
+		Success: true,
+		Webhook: webhook,
+		Secret:  secret,
+		Message: "Webhook created successfully",
+	}, nil
+}
+
+// ListWebhooks returns registered webhooks. A zero chainID lists every chain.
+func (s *AdminService) ListWebhooks(ctx context.Context, chainID int64) ([]*models.Webhook, error) {
+	query := `
+		SELECT id, chain_id, url, description, contract_address, event_name, arg_filters, is_active, created_at, updated_at
+		FROM webhooks
+		WHERE $1 = 0 OR chain_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := s.db.QueryContext(ctx, query, chainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*models.Webhook
+	for rows.Next() {
+		var (
+			webhook         models.Webhook
+			contractAddress sql.NullString
+			eventName       sql.NullString
+		)
+		if err := rows.Scan(
+			&webhook.ID,
+			&webhook.ChainID,
+			&webhook.URL,
+			&webhook.Description,
+			&contractAddress,
+			&eventName,
+			&webhook.ArgFilters,
+			&webhook.IsActive,
+			&webhook.CreatedAt,
+			&webhook.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if contractAddress.Valid {
+			addr := models.Address(contractAddress.String)
+			webhook.ContractAddress = &addr
+		}
+		if eventName.Valid {
+			webhook.EventName = &eventName.String
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// DeleteWebhook removes a webhook together with its delivery log
+func (s *AdminService) DeleteWebhook(ctx context.Context, id string) (*DeleteWebhookResponse, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM webhooks WHERE id::text = $1", id)
+	if err != nil {
+		s.logger.Error("Failed to delete webhook", "error", err)
+		return &DeleteWebhookResponse{
+			Success: false,
+			Message: "Failed to delete webhook",
+		}, nil
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("Failed to get rows affected", "error", err)
+		return &DeleteWebhookResponse{
+			Success: false,
+			Message: "Failed to delete webhook",
+		}, nil
+	}
+
+	if rowsAffected == 0 {
+		return &DeleteWebhookResponse{
+			Success: false,
+			Message: "Webhook not found",
+		}, nil
+	}
+
+	s.logger.Info("Webhook deleted", "id", id)
+
+	return &DeleteWebhookResponse{
+		Success: true,
+		Message: "Webhook deleted successfully",
+	}, nil
+}
+
+// ListWebhookDeliveries returns a page of the delivery log, newest first. An
+// empty webhookID or status does not filter on that column.
+func (s *AdminService) ListWebhookDeliveries(ctx context.Context, webhookID, status string, limit, offset int32) ([]*models.WebhookDelivery, int32, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	where := `
+		WHERE ($1 = '' OR webhook_id::text = $1)
+		  AND ($2 = '' OR status = $2)
+	`
+
+	query := `
+		SELECT id, webhook_id, event_id, payload, status, attempts, next_attempt_at,
+		       last_status_code, last_error, created_at, updated_at, delivered_at
+		FROM webhook_deliveries
+	` + where + `
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := s.db.QueryContext(ctx, query, webhookID, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		var (
+			delivery       models.WebhookDelivery
+			lastStatusCode sql.NullInt32
+			lastError      sql.NullString
+			deliveredAt    sql.NullTime
+		)
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.EventID,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&lastStatusCode,
+			&lastError,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+			&deliveredAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		if lastStatusCode.Valid {
+			code := int(lastStatusCode.Int32)
+			delivery.LastStatusCode = &code
+		}
+		if lastError.Valid {
+			delivery.LastError = &lastError.String
+		}
+		if deliveredAt.Valid {
+			t := deliveredAt.Time
+			delivery.DeliveredAt = &t
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var total int32
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM webhook_deliveries"+where, webhookID, status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+// RetryWebhookDelivery moves a dead-lettered delivery back onto the queue with
+// a fresh set of attempts
+func (s *AdminService) RetryWebhookDelivery(ctx context.Context, deliveryID int64) (*RetryWebhookDeliveryResponse, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = 0, next_attempt_at = NOW()
+		WHERE id = $2 AND status = $3
+	`
+	result, err := s.db.ExecContext(ctx, query, models.WebhookDeliveryPending, deliveryID, models.WebhookDeliveryDeadLetter)
+	if err != nil {
+		s.logger.Error("Failed to requeue webhook delivery", "error", err)
+		return &RetryWebhookDeliveryResponse{
+			Success: false,
+			Message: "Failed to requeue delivery",
+		}, nil
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.logger.Error("Failed to get rows affected", "error", err)
+		return &RetryWebhookDeliveryResponse{
+			Success: false,
+			Message: "Failed to requeue delivery",
+		}, nil
+	}
+
+	if rowsAffected == 0 {
+		return &RetryWebhookDeliveryResponse{
+			Success: false,
+			Message: "Dead-lettered delivery not found",
+		}, nil
+	}
+
+	s.logger.Info("Webhook delivery requeued", "delivery_id", deliveryID)
+
+	return &RetryWebhookDeliveryResponse{
+		Success: true,
+		Message: "Delivery requeued",
+	}, nil
+}
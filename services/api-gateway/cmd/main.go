@@ -14,6 +14,7 @@ import (
 	"github.com/smart-contract-event-indexer/api-gateway/internal/config"
 	"github.com/smart-contract-event-indexer/api-gateway/internal/grpcclient"
 	"github.com/smart-contract-event-indexer/api-gateway/internal/server"
+	"github.com/smart-contract-event-indexer/api-gateway/internal/subscription"
 	sharedconfig "github.com/smart-contract-event-indexer/shared/config"
 	"github.com/smart-contract-event-indexer/shared/database"
 	"github.com/smart-contract-event-indexer/shared/utils"
@@ -65,12 +66,19 @@ func main() {
 	}
 	defer clients.Close()
 
+	// Fan out events published by the indexer to GraphQL subscriptions
+	brokerCtx, stopBroker := context.WithCancel(context.Background())
+	defer stopBroker()
+	broker := subscription.NewBroker(redisClient.Client, logger)
+	go broker.Run(brokerCtx)
+
 	// Create and start HTTP server
 	httpServer := server.NewHTTPServer(
 		db.DB,
 		redisClient.Client,
 		clients.Query,
 		clients.Admin,
+		broker,
 		logger,
 		cfg,
 	)
@@ -3,6 +3,7 @@ package graph
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/smart-contract-event-indexer/api-gateway/graph/model"
@@ -20,10 +21,9 @@ func eventConnectionFromProto(resp *protoapi.EventResponse) *models.EventConnect
 	events := eventsFromProto(resp.Events)
 	edges := make([]*models.EventEdge, 0, len(events))
 	for _, evt := range events {
-		cursor := fmt.Sprintf("%d", evt.ID)
 		edges = append(edges, &models.EventEdge{
 			Node:   evt,
-			Cursor: cursor,
+			Cursor: models.CursorForEvent(evt).Encode(),
 		})
 	}
 
@@ -48,11 +48,13 @@ func eventFromProto(evt *protoapi.Event) *models.Event {
 	}
 
 	args := make(models.JSONB)
+	var argTypes models.ArgTypes
 	for _, arg := range evt.Args {
 		if arg == nil {
 			continue
 		}
 		args[arg.Key] = arg.Value
+		argTypes = append(argTypes, models.ArgType{Name: arg.Key, Type: arg.Type, Indexed: arg.Indexed})
 	}
 
 	var timestamp time.Time
@@ -66,15 +68,19 @@ func eventFromProto(evt *protoapi.Event) *models.Event {
 
 	event := &models.Event{
 		ID:               evt.Id,
+		ChainID:          evt.ChainId,
 		ContractAddress:  models.Address(evt.ContractAddress),
 		EventName:        evt.EventName,
+		EventSignature:   evt.EventSignature,
 		BlockNumber:      evt.BlockNumber,
 		BlockHash:        models.Hash(evt.BlockHash),
 		TransactionHash:  models.Hash(evt.TransactionHash),
 		TransactionIndex: int(evt.TransactionIndex),
 		LogIndex:         int(evt.LogIndex),
 		Args:             args,
+		ArgTypes:         argTypes,
 		Timestamp:        timestamp,
+		Finality:         models.Finality(evt.Finality),
 		CreatedAt:        createdAt,
 	}
 	event.RawLog = encodeRawLog(args)
@@ -89,12 +95,8 @@ func pageInfoFromProto(pi *protoapi.PageInfo) models.PageInfo {
 		HasNextPage:     pi.HasNextPage,
 		HasPreviousPage: pi.HasPreviousPage,
 	}
-	if pi.StartCursor != "" {
-		page.StartCursor = stringPtr(pi.StartCursor)
-	}
-	if pi.EndCursor != "" {
-		page.EndCursor = stringPtr(pi.EndCursor)
-	}
+	page.StartCursor = stringPtr(pi.GetStartCursor())
+	page.EndCursor = stringPtr(pi.GetEndCursor())
 	return page
 }
 
@@ -103,13 +105,17 @@ func contractFromProto(p *protoapi.Contract) *models.Contract {
 		return nil
 	}
 	contract := &models.Contract{
-		ID:            p.Id,
-		Address:       models.Address(p.Address),
-		ABI:           p.Abi,
-		Name:          p.Name,
-		StartBlock:    p.StartBlock,
-		CurrentBlock:  p.CurrentBlock,
-		ConfirmBlocks: int(p.ConfirmBlocks),
+		ID:                    p.Id,
+		ChainID:               p.ChainId,
+		Address:               models.Address(p.Address),
+		ABI:                   p.Abi,
+		Name:                  p.Name,
+		StartBlock:            p.StartBlock,
+		CurrentBlock:          p.CurrentBlock,
+		ConfirmBlocks:         int(p.ConfirmBlocks),
+		IsActive:              p.IsActive,
+		Filter:                contractFilterFromProto(p.Filter),
+		DecodeAnonymousEvents: p.DecodeAnonymousEvents,
 	}
 	if p.CreatedAt != nil {
 		contract.CreatedAt = p.CreatedAt.AsTime()
@@ -120,6 +126,79 @@ func contractFromProto(p *protoapi.Contract) *models.Contract {
 	return contract
 }
 
+func contractFilterFromProto(p *protoapi.ContractFilter) models.ContractFilter {
+	if p == nil {
+		return models.ContractFilter{}
+	}
+	filter := models.ContractFilter{Events: p.Events}
+	for _, t := range p.Topics {
+		filter.Topics = append(filter.Topics, models.TopicFilter{
+			Topic1: t.Topic1,
+			Topic2: t.Topic2,
+			Topic3: t.Topic3,
+		})
+	}
+	return filter
+}
+
+func contractFilterToProto(filter models.ContractFilter) *protoapi.ContractFilter {
+	if filter.IsEmpty() {
+		return nil
+	}
+	result := &protoapi.ContractFilter{Events: filter.Events}
+	for _, t := range filter.Topics {
+		result.Topics = append(result.Topics, &protoapi.TopicFilter{
+			Topic1: t.Topic1,
+			Topic2: t.Topic2,
+			Topic3: t.Topic3,
+		})
+	}
+	return result
+}
+
+func contractFilterToModel(filter models.ContractFilter) *model.ContractFilter {
+	if filter.IsEmpty() {
+		return nil
+	}
+	result := &model.ContractFilter{
+		Events: filter.Events,
+		Topics: make([]*model.TopicFilter, 0, len(filter.Topics)),
+	}
+	if result.Events == nil {
+		result.Events = []string{}
+	}
+	for _, t := range filter.Topics {
+		result.Topics = append(result.Topics, &model.TopicFilter{
+			Topic1: nonNilStrings(t.Topic1),
+			Topic2: nonNilStrings(t.Topic2),
+			Topic3: nonNilStrings(t.Topic3),
+		})
+	}
+	return result
+}
+
+func topicFiltersFromInput(list []*model.TopicFilterInput) []models.TopicFilter {
+	result := make([]models.TopicFilter, 0, len(list))
+	for _, t := range list {
+		if t == nil {
+			continue
+		}
+		result = append(result, models.TopicFilter{
+			Topic1: t.Topic1,
+			Topic2: t.Topic2,
+			Topic3: t.Topic3,
+		})
+	}
+	return result
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 func contractsFromProto(list []*protoapi.Contract) []*models.Contract {
 	result := make([]*models.Contract, 0, len(list))
 	for _, c := range list {
@@ -133,6 +212,7 @@ func statsFromProto(resp *protoapi.StatsResponse) *models.ContractStats {
 		return nil
 	}
 	stats := &models.ContractStats{
+		ChainID:         resp.ChainId,
 		ContractAddress: models.Address(resp.ContractAddress),
 		TotalEvents:     resp.TotalEvents,
 		LatestBlock:     resp.LatestBlock,
@@ -163,6 +243,130 @@ func backfillPayloadFromProto(resp *protoapi.BackfillResponse) *model.BackfillPa
 	return payload
 }
 
+func abiVersionFromProto(p *protoapi.ABIVersion) *model.AbiVersion {
+	if p == nil {
+		return nil
+	}
+	version := &model.AbiVersion{
+		ID:             fmt.Sprintf("%d", p.Id),
+		Abi:            p.Abi,
+		Implementation: stringPtr(p.Implementation),
+	}
+	if p.FromBlock != nil {
+		fromBlock := strconv.FormatInt(*p.FromBlock, 10)
+		version.FromBlock = &fromBlock
+	}
+	if p.CreatedAt != nil {
+		version.CreatedAt = p.CreatedAt.AsTime().Format(time.RFC3339)
+	}
+	return version
+}
+
+func contractFactoryFromProto(p *protoapi.ContractFactory) *model.ContractFactory {
+	if p == nil {
+		return nil
+	}
+	factory := &model.ContractFactory{
+		ID:         fmt.Sprintf("%d", p.Id),
+		EventName:  p.EventName,
+		AddressArg: p.AddressArg,
+		ChildAbi:   p.ChildAbi,
+		ChildName:  p.ChildName,
+		FromBlock:  strconv.FormatInt(p.FromBlock, 10),
+		Children:   int(p.Children),
+	}
+	if p.CreatedAt != nil {
+		factory.CreatedAt = p.CreatedAt.AsTime().Format(time.RFC3339)
+	}
+	return factory
+}
+
+func topicSubscriptionFromProto(p *protoapi.TopicSubscription) *model.TopicSubscription {
+	if p == nil {
+		return nil
+	}
+	subscription := &model.TopicSubscription{
+		ID:               fmt.Sprintf("%d", p.Id),
+		ChainID:          int(p.ChainId),
+		Name:             p.Name,
+		Abi:              p.Abi,
+		EventName:        p.EventName,
+		EventSignature:   p.EventSignature,
+		StartBlock:       strconv.FormatInt(p.StartBlock, 10),
+		ConfirmBlocks:    int(p.ConfirmBlocks),
+		IsActive:         p.IsActive,
+		LastIndexedBlock: strconv.FormatInt(p.LastIndexedBlock, 10),
+	}
+	if p.CreatedAt != nil {
+		subscription.CreatedAt = p.CreatedAt.AsTime().Format(time.RFC3339)
+	}
+	return subscription
+}
+
+func webhookFromProto(p *protoapi.Webhook) *model.Webhook {
+	if p == nil {
+		return nil
+	}
+	webhook := &model.Webhook{
+		ID:              p.Id,
+		ChainID:         int(p.ChainId),
+		URL:             p.Url,
+		Description:     p.Description,
+		ContractAddress: p.ContractAddress,
+		EventName:       p.EventName,
+		ArgFilters:      make([]*model.ArgPredicate, 0, len(p.ArgFilters)),
+		IsActive:        p.IsActive,
+	}
+	for _, predicate := range p.ArgFilters {
+		filter := &model.ArgPredicate{
+			Name:   predicate.Name,
+			Op:     model.ArgOp(strings.ToUpper(predicate.Op)),
+			Values: predicate.Values,
+		}
+		if predicate.Value != "" {
+			filter.Value = stringPtr(predicate.Value)
+		}
+		webhook.ArgFilters = append(webhook.ArgFilters, filter)
+	}
+	if p.CreatedAt != nil {
+		webhook.CreatedAt = p.CreatedAt.AsTime().Format(time.RFC3339)
+	}
+	if p.UpdatedAt != nil {
+		webhook.UpdatedAt = p.UpdatedAt.AsTime().Format(time.RFC3339)
+	}
+	return webhook
+}
+
+func webhookDeliveryFromProto(p *protoapi.WebhookDelivery) *model.WebhookDelivery {
+	if p == nil {
+		return nil
+	}
+	delivery := &model.WebhookDelivery{
+		ID:        fmt.Sprintf("%d", p.Id),
+		WebhookID: p.WebhookId,
+		EventID:   fmt.Sprintf("%d", p.EventId),
+		Status:    model.WebhookDeliveryStatus(strings.ToUpper(p.Status)),
+		Attempts:  int(p.Attempts),
+		LastError: p.LastError,
+		Payload:   p.Payload,
+	}
+	if p.LastStatusCode != nil {
+		code := int(*p.LastStatusCode)
+		delivery.LastStatusCode = &code
+	}
+	if p.NextAttemptAt != nil {
+		delivery.NextAttemptAt = p.NextAttemptAt.AsTime().Format(time.RFC3339)
+	}
+	if p.CreatedAt != nil {
+		delivery.CreatedAt = p.CreatedAt.AsTime().Format(time.RFC3339)
+	}
+	if p.DeliveredAt != nil {
+		deliveredAt := p.DeliveredAt.AsTime().Format(time.RFC3339)
+		delivery.DeliveredAt = &deliveredAt
+	}
+	return delivery
+}
+
 func serviceStatusFromProto(list []*protoapi.ServiceStatus) []*model.ServiceStatus {
 	result := make([]*model.ServiceStatus, 0, len(list))
 	for _, svc := range list {
@@ -188,6 +392,31 @@ func serviceStatusFromProto(list []*protoapi.ServiceStatus) []*model.ServiceStat
 	return result
 }
 
+// argPredicateFromInput converts and validates a GraphQL argument predicate
+func argPredicateFromInput(input *model.ArgPredicateInput) (models.ArgPredicate, error) {
+	predicate := models.ArgPredicate{
+		Name:   input.Name,
+		Op:     models.ArgOpEq,
+		Values: input.Values,
+	}
+	if input.Op != nil {
+		predicate.Op = models.ArgOp(strings.ToLower(string(*input.Op)))
+	}
+	if input.Value != nil {
+		predicate.Value = *input.Value
+	}
+	return predicate, predicate.Validate()
+}
+
+func argPredicateToProto(predicate models.ArgPredicate) *protoapi.ArgPredicate {
+	return &protoapi.ArgPredicate{
+		Name:   predicate.Name,
+		Op:     string(predicate.Op),
+		Value:  predicate.Value,
+		Values: predicate.Values,
+	}
+}
+
 func stringPtr(value string) *string {
 	if value == "" {
 		return nil
@@ -15,10 +15,38 @@ import (
 
 type loadersKey struct{}
 
+// ContractKey identifies a contract across chains.
+type ContractKey struct {
+	ChainID int64
+	Address string
+}
+
+// newContractKey normalizes the address so keys compare case-insensitively.
+func newContractKey(chainID int64, address string) ContractKey {
+	return ContractKey{
+		ChainID: chainID,
+		Address: strings.ToLower(strings.TrimSpace(address)),
+	}
+}
+
+// splitContractKeys returns the parallel chain ID and address arrays used with unnest.
+func splitContractKeys(keys []ContractKey) ([]int64, []string, map[ContractKey][]int) {
+	chainIDs := make([]int64, 0, len(keys))
+	addresses := make([]string, 0, len(keys))
+	keyIndex := make(map[ContractKey][]int)
+	for idx, key := range keys {
+		key = newContractKey(key.ChainID, key.Address)
+		chainIDs = append(chainIDs, key.ChainID)
+		addresses = append(addresses, key.Address)
+		keyIndex[key] = append(keyIndex[key], idx)
+	}
+	return chainIDs, addresses, keyIndex
+}
+
 // Loaders bundles dataloaders for the GraphQL layer.
 type Loaders struct {
-	ContractByAddress *dataloader.Loader[string, *models.Contract]
-	StatsByAddress    *dataloader.Loader[string, *models.ContractStats]
+	ContractByAddress *dataloader.Loader[ContractKey, *models.Contract]
+	StatsByAddress    *dataloader.Loader[ContractKey, *models.ContractStats]
 }
 
 // LoaderFactory builds request-scoped dataloaders.
@@ -54,27 +82,22 @@ func GetLoaders(ctx context.Context) *Loaders {
 	return loaders
 }
 
-func (f *LoaderFactory) contractBatch(ctx context.Context, keys []string) []*dataloader.Result[*models.Contract] {
+func (f *LoaderFactory) contractBatch(ctx context.Context, keys []ContractKey) []*dataloader.Result[*models.Contract] {
 	results := make([]*dataloader.Result[*models.Contract], len(keys))
 	if len(keys) == 0 {
 		return results
 	}
 
-	normalized := make([]string, 0, len(keys))
-	addrIndex := make(map[string][]int)
-	for idx, key := range keys {
-		addr := strings.ToLower(strings.TrimSpace(key))
-		normalized = append(normalized, addr)
-		addrIndex[addr] = append(addrIndex[addr], idx)
-	}
+	chainIDs, addresses, keyIndex := splitContractKeys(keys)
 
 	query := `
-SELECT id, address, abi, name, start_block, current_block, confirm_blocks, created_at, updated_at
+SELECT id, chain_id, address, abi, name, start_block, current_block, confirm_blocks, is_active, event_filter,
+       decode_anonymous_events, created_at, updated_at
 FROM contracts
-WHERE LOWER(address) = ANY($1)
+WHERE (chain_id, LOWER(address)) IN (SELECT * FROM unnest($1::bigint[], $2::text[]))
 `
 
-	rows, err := f.db.QueryContext(ctx, query, pq.Array(normalized))
+	rows, err := f.db.QueryContext(ctx, query, pq.Array(chainIDs), pq.Array(addresses))
 	if err != nil {
 		for i := range results {
 			results[i] = &dataloader.Result[*models.Contract]{Error: err}
@@ -83,17 +106,21 @@ WHERE LOWER(address) = ANY($1)
 	}
 	defer rows.Close()
 
-	found := make(map[string]*models.Contract)
+	found := make(map[ContractKey]*models.Contract)
 	for rows.Next() {
 		var contract models.Contract
 		if err := rows.Scan(
 			&contract.ID,
+			&contract.ChainID,
 			&contract.Address,
 			&contract.ABI,
 			&contract.Name,
 			&contract.StartBlock,
 			&contract.CurrentBlock,
 			&contract.ConfirmBlocks,
+			&contract.IsActive,
+			&contract.Filter,
+			&contract.DecodeAnonymousEvents,
 			&contract.CreatedAt,
 			&contract.UpdatedAt,
 		); err != nil {
@@ -102,10 +129,10 @@ WHERE LOWER(address) = ANY($1)
 			}
 			return results
 		}
-		found[strings.ToLower(string(contract.Address))] = &contract
+		found[newContractKey(contract.ChainID, string(contract.Address))] = &contract
 	}
 
-	for key, indexes := range addrIndex {
+	for key, indexes := range keyIndex {
 		contract := found[key]
 		for _, idx := range indexes {
 			if contract == nil {
@@ -119,30 +146,28 @@ WHERE LOWER(address) = ANY($1)
 	return results
 }
 
-func (f *LoaderFactory) contractStatsBatch(ctx context.Context, keys []string) []*dataloader.Result[*models.ContractStats] {
+func (f *LoaderFactory) contractStatsBatch(ctx context.Context, keys []ContractKey) []*dataloader.Result[*models.ContractStats] {
 	results := make([]*dataloader.Result[*models.ContractStats], len(keys))
 	if len(keys) == 0 {
 		return results
 	}
 
-	normalized := make([]string, 0, len(keys))
-	addrIndex := make(map[string][]int)
-	for idx, key := range keys {
-		addr := strings.ToLower(strings.TrimSpace(key))
-		normalized = append(normalized, addr)
-		addrIndex[addr] = append(addrIndex[addr], idx)
-	}
+	chainIDs, addresses, keyIndex := splitContractKeys(keys)
 
 	query := `
-WITH uniq_addresses AS (
-    SELECT e.contract_address,
+WITH wanted AS (
+    SELECT * FROM unnest($1::bigint[], $2::text[]) AS w(chain_id, address)
+),
+uniq_addresses AS (
+    SELECT e.chain_id, e.contract_address,
            COUNT(DISTINCT LOWER(value)) FILTER (WHERE value LIKE '0x%') AS unique_addresses
-    FROM events e,
+    FROM events e
+    JOIN wanted w ON w.chain_id = e.chain_id AND w.address = LOWER(e.contract_address),
          LATERAL jsonb_each_text(e.args)
-    WHERE LOWER(e.contract_address) = ANY($1)
-    GROUP BY e.contract_address
+    GROUP BY e.chain_id, e.contract_address
 )
 SELECT 
+    c.chain_id,
     c.address,
     COUNT(e.id) AS total_events,
     COALESCE(MAX(e.block_number), c.current_block) AS latest_block,
@@ -151,13 +176,13 @@ SELECT
     COALESCE(MAX(e.created_at), c.updated_at) AS last_updated,
     COALESCE(u.unique_addresses, 0) AS unique_addresses
 FROM contracts c
-LEFT JOIN events e ON c.address = e.contract_address
-LEFT JOIN uniq_addresses u ON u.contract_address = c.address
-WHERE LOWER(c.address) = ANY($1)
-GROUP BY c.address, c.current_block, c.start_block, c.updated_at, u.unique_addresses
+JOIN wanted w ON w.chain_id = c.chain_id AND w.address = LOWER(c.address)
+LEFT JOIN events e ON c.chain_id = e.chain_id AND c.address = e.contract_address
+LEFT JOIN uniq_addresses u ON u.chain_id = c.chain_id AND u.contract_address = c.address
+GROUP BY c.chain_id, c.address, c.current_block, c.start_block, c.updated_at, u.unique_addresses
 `
 
-	rows, err := f.db.QueryContext(ctx, query, pq.Array(normalized))
+	rows, err := f.db.QueryContext(ctx, query, pq.Array(chainIDs), pq.Array(addresses))
 	if err != nil {
 		for i := range results {
 			results[i] = &dataloader.Result[*models.ContractStats]{Error: err}
@@ -166,12 +191,13 @@ GROUP BY c.address, c.current_block, c.start_block, c.updated_at, u.unique_addre
 	}
 	defer rows.Close()
 
-	found := make(map[string]*models.ContractStats)
+	found := make(map[ContractKey]*models.ContractStats)
 	for rows.Next() {
 		var stats models.ContractStats
 		var lastUpdated time.Time
 		var uniqueAddresses int64
 		if err := rows.Scan(
+			&stats.ChainID,
 			&stats.ContractAddress,
 			&stats.TotalEvents,
 			&stats.LatestBlock,
@@ -190,10 +216,10 @@ GROUP BY c.address, c.current_block, c.start_block, c.updated_at, u.unique_addre
 			value := int(uniqueAddresses)
 			stats.UniqueAddresses = &value
 		}
-		found[strings.ToLower(string(stats.ContractAddress))] = &stats
+		found[newContractKey(stats.ChainID, string(stats.ContractAddress))] = &stats
 	}
 
-	for key, indexes := range addrIndex {
+	for key, indexes := range keyIndex {
 		stat := found[key]
 		for _, idx := range indexes {
 			if stat == nil {
@@ -0,0 +1,385 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type AbiVersion struct {
+	ID             string  `json:"id"`
+	Abi            string  `json:"abi"`
+	FromBlock      *string `json:"fromBlock,omitempty"`
+	Implementation *string `json:"implementation,omitempty"`
+	CreatedAt      string  `json:"createdAt"`
+}
+
+type AddContractAbiInput struct {
+	ChainID         *int    `json:"chainId,omitempty"`
+	ContractAddress string  `json:"contractAddress"`
+	Abi             string  `json:"abi"`
+	FromBlock       *string `json:"fromBlock,omitempty"`
+	Implementation  *string `json:"implementation,omitempty"`
+}
+
+type AddContractAbiPayload struct {
+	Success bool        `json:"success"`
+	Version *AbiVersion `json:"version,omitempty"`
+	Message string      `json:"message"`
+}
+
+type AddContractFactoryInput struct {
+	ChainID         *int    `json:"chainId,omitempty"`
+	ContractAddress string  `json:"contractAddress"`
+	EventName       string  `json:"eventName"`
+	AddressArg      string  `json:"addressArg"`
+	ChildAbi        string  `json:"childAbi"`
+	ChildName       *string `json:"childName,omitempty"`
+	FromBlock       *string `json:"fromBlock,omitempty"`
+}
+
+type AddContractFactoryPayload struct {
+	Success bool             `json:"success"`
+	Factory *ContractFactory `json:"factory,omitempty"`
+	Message string           `json:"message"`
+}
+
+type AddContractPayload struct {
+	Success    bool    `json:"success"`
+	ContractID *string `json:"contractId,omitempty"`
+	IsNew      bool    `json:"isNew"`
+	Message    string  `json:"message"`
+}
+
+type ArgPredicate struct {
+	Name   string   `json:"name"`
+	Op     ArgOp    `json:"op"`
+	Value  *string  `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+type ArgPredicateInput struct {
+	Name   string   `json:"name"`
+	Op     *ArgOp   `json:"op,omitempty"`
+	Value  *string  `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+type BackfillInput struct {
+	ChainID         *int   `json:"chainId,omitempty"`
+	ContractAddress string `json:"contractAddress"`
+	FromBlock       string `json:"fromBlock"`
+	ToBlock         string `json:"toBlock"`
+	ChunkSize       *int   `json:"chunkSize,omitempty"`
+}
+
+type BackfillPayload struct {
+	Success       bool    `json:"success"`
+	JobID         *string `json:"jobId,omitempty"`
+	EstimatedTime *int    `json:"estimatedTime,omitempty"`
+	Message       string  `json:"message"`
+}
+
+type ContractFactory struct {
+	ID         string `json:"id"`
+	EventName  string `json:"eventName"`
+	AddressArg string `json:"addressArg"`
+	ChildAbi   string `json:"childAbi"`
+	ChildName  string `json:"childName"`
+	FromBlock  string `json:"fromBlock"`
+	Children   int    `json:"children"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+type ContractFilter struct {
+	Events []string       `json:"events"`
+	Topics []*TopicFilter `json:"topics"`
+}
+
+type CreateTopicSubscriptionInput struct {
+	ChainID       *int    `json:"chainId,omitempty"`
+	Name          string  `json:"name"`
+	Abi           string  `json:"abi"`
+	EventName     *string `json:"eventName,omitempty"`
+	StartBlock    string  `json:"startBlock"`
+	ConfirmBlocks *int    `json:"confirmBlocks,omitempty"`
+}
+
+type CreateTopicSubscriptionPayload struct {
+	Success      bool               `json:"success"`
+	Subscription *TopicSubscription `json:"subscription,omitempty"`
+	Message      string             `json:"message"`
+}
+
+type CreateWebhookInput struct {
+	ChainID         *int                 `json:"chainId,omitempty"`
+	URL             string               `json:"url"`
+	ContractAddress *string              `json:"contractAddress,omitempty"`
+	EventName       *string              `json:"eventName,omitempty"`
+	ArgFilters      []*ArgPredicateInput `json:"argFilters,omitempty"`
+	Secret          *string              `json:"secret,omitempty"`
+	Description     *string              `json:"description,omitempty"`
+}
+
+type CreateWebhookPayload struct {
+	Success bool     `json:"success"`
+	Webhook *Webhook `json:"webhook,omitempty"`
+	Secret  *string  `json:"secret,omitempty"`
+	Message string   `json:"message"`
+}
+
+type DeleteTopicSubscriptionPayload struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type DeleteWebhookPayload struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type HealthCheck struct {
+	Status    string           `json:"status"`
+	Timestamp string           `json:"timestamp"`
+	Services  []*ServiceStatus `json:"services"`
+}
+
+type Mutation struct {
+}
+
+type PaginationInput struct {
+	First  *int    `json:"first,omitempty"`
+	After  *string `json:"after,omitempty"`
+	Last   *int    `json:"last,omitempty"`
+	Before *string `json:"before,omitempty"`
+}
+
+type Query struct {
+}
+
+type RemoveContractPayload struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type RetryWebhookDeliveryPayload struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type ServiceStatus struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Latency   *int   `json:"latency,omitempty"`
+	LastCheck string `json:"lastCheck"`
+}
+
+type Subscription struct {
+}
+
+type SystemStatus struct {
+	IndexerLag       int     `json:"indexerLag"`
+	TotalContracts   int     `json:"totalContracts"`
+	TotalEvents      int     `json:"totalEvents"`
+	CacheHitRate     float64 `json:"cacheHitRate"`
+	LastIndexedBlock *string `json:"lastIndexedBlock,omitempty"`
+	IsHealthy        bool    `json:"isHealthy"`
+	Uptime           int     `json:"uptime"`
+}
+
+type TopicFilter struct {
+	Topic1 []string `json:"topic1"`
+	Topic2 []string `json:"topic2"`
+	Topic3 []string `json:"topic3"`
+}
+
+type TopicFilterInput struct {
+	Topic1 []string `json:"topic1,omitempty"`
+	Topic2 []string `json:"topic2,omitempty"`
+	Topic3 []string `json:"topic3,omitempty"`
+}
+
+type TopicSubscription struct {
+	ID               string `json:"id"`
+	ChainID          int    `json:"chainId"`
+	Name             string `json:"name"`
+	Abi              string `json:"abi"`
+	EventName        string `json:"eventName"`
+	EventSignature   string `json:"eventSignature"`
+	StartBlock       string `json:"startBlock"`
+	ConfirmBlocks    int    `json:"confirmBlocks"`
+	IsActive         bool   `json:"isActive"`
+	LastIndexedBlock string `json:"lastIndexedBlock"`
+	CreatedAt        string `json:"createdAt"`
+}
+
+type Webhook struct {
+	ID              string          `json:"id"`
+	ChainID         int             `json:"chainId"`
+	URL             string          `json:"url"`
+	Description     string          `json:"description"`
+	ContractAddress *string         `json:"contractAddress,omitempty"`
+	EventName       *string         `json:"eventName,omitempty"`
+	ArgFilters      []*ArgPredicate `json:"argFilters"`
+	IsActive        bool            `json:"isActive"`
+	CreatedAt       string          `json:"createdAt"`
+	UpdatedAt       string          `json:"updatedAt"`
+}
+
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	WebhookID      string                `json:"webhookId"`
+	EventID        string                `json:"eventId"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  string                `json:"nextAttemptAt"`
+	LastStatusCode *int                  `json:"lastStatusCode,omitempty"`
+	LastError      *string               `json:"lastError,omitempty"`
+	Payload        string                `json:"payload"`
+	CreatedAt      string                `json:"createdAt"`
+	DeliveredAt    *string               `json:"deliveredAt,omitempty"`
+}
+
+type WebhookDeliveryList struct {
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+	TotalCount int                `json:"totalCount"`
+}
+
+type ArgOp string
+
+const (
+	ArgOpEq  ArgOp = "EQ"
+	ArgOpGt  ArgOp = "GT"
+	ArgOpGte ArgOp = "GTE"
+	ArgOpLt  ArgOp = "LT"
+	ArgOpLte ArgOp = "LTE"
+	ArgOpIn  ArgOp = "IN"
+)
+
+var AllArgOp = []ArgOp{
+	ArgOpEq,
+	ArgOpGt,
+	ArgOpGte,
+	ArgOpLt,
+	ArgOpLte,
+	ArgOpIn,
+}
+
+func (e ArgOp) IsValid() bool {
+	switch e {
+	case ArgOpEq, ArgOpGt, ArgOpGte, ArgOpLt, ArgOpLte, ArgOpIn:
+		return true
+	}
+	return false
+}
+
+func (e ArgOp) String() string {
+	return string(e)
+}
+
+func (e *ArgOp) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ArgOp(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ArgOp", str)
+	}
+	return nil
+}
+
+func (e ArgOp) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Finality string
+
+const (
+	FinalityPending   Finality = "PENDING"
+	FinalityConfirmed Finality = "CONFIRMED"
+	FinalityAny       Finality = "ANY"
+)
+
+var AllFinality = []Finality{
+	FinalityPending,
+	FinalityConfirmed,
+	FinalityAny,
+}
+
+func (e Finality) IsValid() bool {
+	switch e {
+	case FinalityPending, FinalityConfirmed, FinalityAny:
+		return true
+	}
+	return false
+}
+
+func (e Finality) String() string {
+	return string(e)
+}
+
+func (e *Finality) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Finality(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Finality", str)
+	}
+	return nil
+}
+
+func (e Finality) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending    WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusDelivered  WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryStatusDeadLetter WebhookDeliveryStatus = "DEAD_LETTER"
+	WebhookDeliveryStatusCancelled  WebhookDeliveryStatus = "CANCELLED"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusDelivered,
+	WebhookDeliveryStatusDeadLetter,
+	WebhookDeliveryStatusCancelled,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusDelivered, WebhookDeliveryStatusDeadLetter, WebhookDeliveryStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
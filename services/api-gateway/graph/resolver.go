@@ -5,6 +5,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"github.com/smart-contract-event-indexer/api-gateway/internal/config"
+	"github.com/smart-contract-event-indexer/api-gateway/internal/subscription"
 	protoapi "github.com/smart-contract-event-indexer/shared/proto"
 	"github.com/smart-contract-event-indexer/shared/utils"
 )
@@ -15,6 +16,24 @@ type Resolver struct {
 	Redis       *redis.Client
 	QueryClient protoapi.QueryServiceClient
 	AdminClient protoapi.AdminServiceClient
+	Broker      *subscription.Broker
 	Logger      utils.Logger
 	Config      *config.Config
 }
+
+// chainIDOrDefault resolves an optional chainId argument to a concrete chain.
+func (r *Resolver) chainIDOrDefault(chainID *int) int64 {
+	if chainID != nil {
+		return int64(*chainID)
+	}
+	return r.Config.DefaultChainID
+}
+
+// chainIDOrZero converts an optional chainId argument for gRPC requests,
+// where 0 means the service default (or every chain for queries).
+func chainIDOrZero(chainID *int) int64 {
+	if chainID == nil {
+		return 0
+	}
+	return int64(*chainID)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/smart-contract-event-indexer/api-gateway/graph/model"
 	"github.com/smart-contract-event-indexer/shared/models"
 	protoapi "github.com/smart-contract-event-indexer/shared/proto"
+	"google.golang.org/protobuf/proto"
 )
 
 // ID is the resolver for the id field.
@@ -39,10 +40,43 @@ func (r *contractResolver) CurrentBlock(ctx context.Context, obj *models.Contrac
 	return fmt.Sprintf("%d", obj.CurrentBlock), nil
 }
 
-// IsActive is the resolver for the isActive field.
-func (r *contractResolver) IsActive(ctx context.Context, obj *models.Contract) (bool, error) {
-	// All registered contracts are considered active until paused (feature TBD).
-	return true, nil
+// Filter is the resolver for the filter field.
+func (r *contractResolver) Filter(ctx context.Context, obj *models.Contract) (*model.ContractFilter, error) {
+	return contractFilterToModel(obj.Filter), nil
+}
+
+// AbiVersions is the resolver for the abiVersions field.
+func (r *contractResolver) AbiVersions(ctx context.Context, obj *models.Contract) ([]*model.AbiVersion, error) {
+	resp, err := r.AdminClient.ListContractABIVersions(ctx, &protoapi.ListContractABIVersionsRequest{
+		Address: string(obj.Address),
+		ChainId: obj.ChainID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*model.AbiVersion, 0, len(resp.Versions))
+	for _, v := range resp.Versions {
+		versions = append(versions, abiVersionFromProto(v))
+	}
+	return versions, nil
+}
+
+// Factories is the resolver for the factories field.
+func (r *contractResolver) Factories(ctx context.Context, obj *models.Contract) ([]*model.ContractFactory, error) {
+	resp, err := r.AdminClient.ListContractFactories(ctx, &protoapi.ListContractFactoriesRequest{
+		Address: string(obj.Address),
+		ChainId: obj.ChainID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	factories := make([]*model.ContractFactory, 0, len(resp.Factories))
+	for _, f := range resp.Factories {
+		factories = append(factories, contractFactoryFromProto(f))
+	}
+	return factories, nil
 }
 
 // CreatedAt is the resolver for the createdAt field.
@@ -100,17 +134,22 @@ func (r *eventResolver) Args(ctx context.Context, obj *models.Event) ([]*models.
 		return nil, nil
 	}
 
-	args := make([]*models.EventArg, 0, len(obj.Args))
-	for key, value := range obj.Args {
-		args = append(args, &models.EventArg{
-			Name:  key,
-			Type:  fmt.Sprintf("%T", value),
-			Value: fmt.Sprintf("%v", value),
-		})
+	ordered := obj.OrderedArgs()
+	args := make([]*models.EventArg, len(ordered))
+	for k := range ordered {
+		args[k] = &ordered[k]
 	}
 	return args, nil
 }
 
+// Finality is the resolver for the finality field.
+func (r *eventResolver) Finality(ctx context.Context, obj *models.Event) (model.Finality, error) {
+	if obj.Finality == models.FinalityPending {
+		return model.FinalityPending, nil
+	}
+	return model.FinalityConfirmed, nil
+}
+
 // CreatedAt is the resolver for the createdAt field.
 func (r *eventResolver) CreatedAt(ctx context.Context, obj *models.Event) (string, error) {
 	return obj.CreatedAt.UTC().Format(time.RFC3339), nil
@@ -123,17 +162,21 @@ func (r *eventArgResolver) Key(ctx context.Context, obj *models.EventArg) (strin
 
 // Value is the resolver for the value field.
 func (r *eventArgResolver) Value(ctx context.Context, obj *models.EventArg) (string, error) {
-	return fmt.Sprintf("%v", obj.Value), nil
+	return models.FormatArgValue(obj.Value), nil
 }
 
 // AddContract is the resolver for the addContract field.
 func (r *mutationResolver) AddContract(ctx context.Context, input models.AddContractInput) (*model.AddContractPayload, error) {
+	confirmBlocks := int32(input.GetConfirmBlocks())
 	req := &protoapi.AddContractRequest{
-		Address:       string(input.Address),
-		Abi:           input.ABI,
-		Name:          input.Name,
-		StartBlock:    input.StartBlock,
-		ConfirmBlocks: int32(input.GetConfirmBlocks()),
+		ChainId:               input.GetChainID(),
+		Address:               string(input.Address),
+		Abi:                   input.ABI,
+		Name:                  input.Name,
+		StartBlock:            input.StartBlock,
+		ConfirmBlocks:         &confirmBlocks,
+		Filter:                contractFilterToProto(input.Filter),
+		DecodeAnonymousEvents: input.DecodeAnonymousEvents,
 	}
 
 	resp, err := r.AdminClient.AddContract(ctx, req)
@@ -155,9 +198,72 @@ func (r *mutationResolver) AddContract(ctx context.Context, input models.AddCont
 	return payload, nil
 }
 
+// AddContractAbi is the resolver for the addContractAbi field.
+func (r *mutationResolver) AddContractAbi(ctx context.Context, input model.AddContractAbiInput) (*model.AddContractAbiPayload, error) {
+	req := &protoapi.AddContractABIVersionRequest{
+		ChainId: chainIDOrZero(input.ChainID),
+		Address: input.ContractAddress,
+		Abi:     input.Abi,
+	}
+	if input.FromBlock != nil {
+		fromBlock, err := parseBigInt(*input.FromBlock)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fromBlock: %w", err)
+		}
+		req.FromBlock = &fromBlock
+	}
+	if input.Implementation != nil {
+		req.Implementation = *input.Implementation
+	}
+
+	resp, err := r.AdminClient.AddContractABIVersion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AddContractAbiPayload{
+		Success: resp.Success,
+		Version: abiVersionFromProto(resp.Version),
+		Message: resp.Message,
+	}, nil
+}
+
+// AddContractFactory is the resolver for the addContractFactory field.
+func (r *mutationResolver) AddContractFactory(ctx context.Context, input model.AddContractFactoryInput) (*model.AddContractFactoryPayload, error) {
+	req := &protoapi.AddContractFactoryRequest{
+		ChainId:    chainIDOrZero(input.ChainID),
+		Address:    input.ContractAddress,
+		EventName:  input.EventName,
+		AddressArg: input.AddressArg,
+		ChildAbi:   input.ChildAbi,
+	}
+	if input.ChildName != nil {
+		req.ChildName = *input.ChildName
+	}
+	if input.FromBlock != nil {
+		fromBlock, err := parseBigInt(*input.FromBlock)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fromBlock: %w", err)
+		}
+		req.FromBlock = fromBlock
+	}
+
+	resp, err := r.AdminClient.AddContractFactory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AddContractFactoryPayload{
+		Success: resp.Success,
+		Factory: contractFactoryFromProto(resp.Factory),
+		Message: resp.Message,
+	}, nil
+}
+
 // RemoveContract is the resolver for the removeContract field.
-func (r *mutationResolver) RemoveContract(ctx context.Context, address string) (*model.RemoveContractPayload, error) {
+func (r *mutationResolver) RemoveContract(ctx context.Context, address string, chainID *int) (*model.RemoveContractPayload, error) {
 	resp, err := r.AdminClient.RemoveContract(ctx, &protoapi.RemoveContractRequest{
+		ChainId: chainIDOrZero(chainID),
 		Address: string(address),
 	})
 	if err != nil {
@@ -180,11 +286,52 @@ func (r *mutationResolver) TriggerBackfill(ctx context.Context, input model.Back
 		return nil, fmt.Errorf("invalid toBlock: %w", err)
 	}
 
-	resp, err := r.AdminClient.TriggerBackfill(ctx, &protoapi.BackfillRequest{
+	req := &protoapi.BackfillRequest{
+		ChainId:         chainIDOrZero(input.ChainID),
 		ContractAddress: input.ContractAddress,
 		FromBlock:       from,
 		ToBlock:         to,
-	})
+	}
+	if input.ChunkSize != nil {
+		if *input.ChunkSize <= 0 {
+			return nil, errors.New("chunkSize must be positive")
+		}
+		req.ChunkSize = int32(*input.ChunkSize)
+	}
+
+	resp, err := r.AdminClient.TriggerBackfill(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return backfillPayloadFromProto(resp), nil
+}
+
+// TriggerRedecode is the resolver for the triggerRedecode field.
+func (r *mutationResolver) TriggerRedecode(ctx context.Context, input model.BackfillInput) (*model.BackfillPayload, error) {
+	from, err := strconv.ParseInt(input.FromBlock, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fromBlock: %w", err)
+	}
+	to, err := strconv.ParseInt(input.ToBlock, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid toBlock: %w", err)
+	}
+
+	req := &protoapi.RedecodeRequest{
+		ChainId:         chainIDOrZero(input.ChainID),
+		ContractAddress: input.ContractAddress,
+		FromBlock:       from,
+		ToBlock:         to,
+	}
+	if input.ChunkSize != nil {
+		if *input.ChunkSize <= 0 {
+			return nil, errors.New("chunkSize must be positive")
+		}
+		req.ChunkSize = int32(*input.ChunkSize)
+	}
+
+	resp, err := r.AdminClient.TriggerRedecode(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -193,13 +340,14 @@ func (r *mutationResolver) TriggerBackfill(ctx context.Context, input model.Back
 }
 
 // UpdateContract is the resolver for the updateContract field.
-func (r *mutationResolver) UpdateContract(ctx context.Context, address string, confirmBlocks *int, isActive *bool) (*model.AddContractPayload, error) {
-	if confirmBlocks == nil && isActive == nil {
+func (r *mutationResolver) UpdateContract(ctx context.Context, address string, chainID *int, confirmBlocks *int, isActive *bool, events []string, topics []*model.TopicFilterInput, decodeAnonymousEvents *bool) (*model.AddContractPayload, error) {
+	if confirmBlocks == nil && isActive == nil && events == nil && topics == nil && decodeAnonymousEvents == nil {
 		return nil, errors.New("no update fields provided")
 	}
 
+	chain := r.chainIDOrDefault(chainID)
 	addr := strings.ToLower(string(address))
-	contract, err := getContractByAddress(ctx, r.DB, addr)
+	contract, err := getContractByAddress(ctx, r.DB, chain, addr)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("contract %s not found", address)
@@ -214,25 +362,59 @@ func (r *mutationResolver) UpdateContract(ctx context.Context, address string, c
 		if *confirmBlocks < 1 || *confirmBlocks > 100 {
 			return nil, fmt.Errorf("confirmBlocks must be between 1 and 100")
 		}
-		query := `UPDATE contracts SET confirm_blocks = $1, updated_at = NOW() WHERE LOWER(address) = $2`
-		if _, err := r.DB.ExecContext(ctx, query, *confirmBlocks, addr); err != nil {
+		query := `UPDATE contracts SET confirm_blocks = $1, updated_at = NOW() WHERE chain_id = $2 AND LOWER(address) = $3`
+		if _, err := r.DB.ExecContext(ctx, query, *confirmBlocks, chain, addr); err != nil {
 			return nil, err
 		}
 		messages = append(messages, fmt.Sprintf("Confirmation blocks updated to %d", *confirmBlocks))
 		success = true
 	}
 
-	if isActive != nil {
-		if !*isActive {
-			_, err := r.AdminClient.RemoveContract(ctx, &protoapi.RemoveContractRequest{Address: string(address)})
-			if err != nil {
-				return nil, err
-			}
-			messages = append(messages, "Contract deactivated")
-			success = true
+	if decodeAnonymousEvents != nil {
+		query := `UPDATE contracts SET decode_anonymous_events = $1, updated_at = NOW() WHERE chain_id = $2 AND LOWER(address) = $3`
+		if _, err := r.DB.ExecContext(ctx, query, *decodeAnonymousEvents, chain, addr); err != nil {
+			return nil, err
+		}
+		if *decodeAnonymousEvents {
+			messages = append(messages, "Anonymous events are decoded from now on")
 		} else {
-			messages = append(messages, "Contract already active")
+			messages = append(messages, "Anonymous events are no longer decoded")
 		}
+		success = true
+	}
+
+	if isActive != nil {
+		resp, err := r.AdminClient.SetContractActive(ctx, &protoapi.SetContractActiveRequest{
+			ChainId:  chain,
+			Address:  string(contract.Address),
+			IsActive: *isActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, resp.Message)
+		success = resp.Success
+	}
+
+	if events != nil || topics != nil {
+		// The part of the filter that is not given keeps its current value
+		filter := contract.Filter
+		if events != nil {
+			filter.Events = events
+		}
+		if topics != nil {
+			filter.Topics = topicFiltersFromInput(topics)
+		}
+		resp, err := r.AdminClient.UpdateContractFilter(ctx, &protoapi.UpdateContractFilterRequest{
+			ChainId: chain,
+			Address: string(contract.Address),
+			Filter:  contractFilterToProto(filter),
+		})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, resp.Message)
+		success = resp.Success
 	}
 
 	if len(messages) == 0 {
@@ -252,6 +434,124 @@ func (r *mutationResolver) UpdateContract(ctx context.Context, address string, c
 	return payload, nil
 }
 
+// CreateWebhook is the resolver for the createWebhook field.
+func (r *mutationResolver) CreateWebhook(ctx context.Context, input model.CreateWebhookInput) (*model.CreateWebhookPayload, error) {
+	req := &protoapi.CreateWebhookRequest{
+		ChainId: chainIDOrZero(input.ChainID),
+		Url:     input.URL,
+	}
+	if input.ContractAddress != nil {
+		req.ContractAddress = *input.ContractAddress
+	}
+	if input.EventName != nil {
+		req.EventName = *input.EventName
+	}
+	if input.Secret != nil {
+		req.Secret = *input.Secret
+	}
+	if input.Description != nil {
+		req.Description = *input.Description
+	}
+	for _, filter := range input.ArgFilters {
+		predicate, err := argPredicateFromInput(filter)
+		if err != nil {
+			return nil, err
+		}
+		req.ArgFilters = append(req.ArgFilters, argPredicateToProto(predicate))
+	}
+
+	resp, err := r.AdminClient.CreateWebhook(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CreateWebhookPayload{
+		Success: resp.Success,
+		Webhook: webhookFromProto(resp.Webhook),
+		Secret:  stringPtr(resp.Secret),
+		Message: resp.Message,
+	}, nil
+}
+
+// DeleteWebhook is the resolver for the deleteWebhook field.
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, id string) (*model.DeleteWebhookPayload, error) {
+	resp, err := r.AdminClient.DeleteWebhook(ctx, &protoapi.DeleteWebhookRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return &model.DeleteWebhookPayload{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// RetryWebhookDelivery is the resolver for the retryWebhookDelivery field.
+func (r *mutationResolver) RetryWebhookDelivery(ctx context.Context, id string) (*model.RetryWebhookDeliveryPayload, error) {
+	deliveryID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delivery id: %w", err)
+	}
+
+	resp, err := r.AdminClient.RetryWebhookDelivery(ctx, &protoapi.RetryWebhookDeliveryRequest{DeliveryId: deliveryID})
+	if err != nil {
+		return nil, err
+	}
+	return &model.RetryWebhookDeliveryPayload{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
+// CreateTopicSubscription is the resolver for the createTopicSubscription field.
+func (r *mutationResolver) CreateTopicSubscription(ctx context.Context, input model.CreateTopicSubscriptionInput) (*model.CreateTopicSubscriptionPayload, error) {
+	startBlock, err := parseBigInt(input.StartBlock)
+	if err != nil {
+		return nil, fmt.Errorf("invalid startBlock: %w", err)
+	}
+
+	req := &protoapi.CreateTopicSubscriptionRequest{
+		ChainId:    chainIDOrZero(input.ChainID),
+		Name:       input.Name,
+		Abi:        input.Abi,
+		StartBlock: startBlock,
+	}
+	if input.EventName != nil {
+		req.EventName = *input.EventName
+	}
+	if input.ConfirmBlocks != nil {
+		confirmBlocks := int32(*input.ConfirmBlocks)
+		req.ConfirmBlocks = &confirmBlocks
+	}
+
+	resp, err := r.AdminClient.CreateTopicSubscription(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CreateTopicSubscriptionPayload{
+		Success:      resp.Success,
+		Subscription: topicSubscriptionFromProto(resp.Subscription),
+		Message:      resp.Message,
+	}, nil
+}
+
+// DeleteTopicSubscription is the resolver for the deleteTopicSubscription field.
+func (r *mutationResolver) DeleteTopicSubscription(ctx context.Context, id string) (*model.DeleteTopicSubscriptionPayload, error) {
+	subscriptionID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription id: %w", err)
+	}
+
+	resp, err := r.AdminClient.DeleteTopicSubscription(ctx, &protoapi.DeleteTopicSubscriptionRequest{Id: subscriptionID})
+	if err != nil {
+		return nil, err
+	}
+	return &model.DeleteTopicSubscriptionPayload{
+		Success: resp.Success,
+		Message: resp.Message,
+	}, nil
+}
+
 // Events is the resolver for the events field.
 func (r *queryResolver) Events(ctx context.Context, filter *models.EventFilter, pagination *model.PaginationInput) (*models.EventConnection, error) {
 	req := &protoapi.EventQuery{}
@@ -267,9 +567,10 @@ func (r *queryResolver) Events(ctx context.Context, filter *models.EventFilter,
 }
 
 // EventsByTransaction is the resolver for the eventsByTransaction field.
-func (r *queryResolver) EventsByTransaction(ctx context.Context, txHash string) ([]*models.Event, error) {
+func (r *queryResolver) EventsByTransaction(ctx context.Context, txHash string, chainID *int) ([]*models.Event, error) {
 	resp, err := r.QueryClient.GetEventsByTransaction(ctx, &protoapi.TransactionQuery{
 		TransactionHash: txHash,
+		ChainId:         chainIDOrZero(chainID),
 	})
 	if err != nil {
 		return nil, err
@@ -278,20 +579,20 @@ func (r *queryResolver) EventsByTransaction(ctx context.Context, txHash string)
 }
 
 // EventsByAddress is the resolver for the eventsByAddress field.
-func (r *queryResolver) EventsByAddress(ctx context.Context, address string, pagination *model.PaginationInput) (*models.EventConnection, error) {
+func (r *queryResolver) EventsByAddress(ctx context.Context, address string, chainID *int, pagination *model.PaginationInput, role *string) (*models.EventConnection, error) {
 	req := &protoapi.AddressQuery{
 		Address: string(address),
+		ChainId: chainIDOrZero(chainID),
+	}
+	if role != nil {
+		req.Role = proto.String(*role)
 	}
 	if pagination != nil {
 		if pagination.First != nil {
 			req.First = int32(*pagination.First)
 		}
-		if pagination.After != nil {
-			req.After = *pagination.After
-		}
-		if pagination.Before != nil {
-			req.Before = *pagination.Before
-		}
+		req.After = pagination.After
+		req.Before = pagination.Before
 		if pagination.Last != nil {
 			req.Last = int32(*pagination.Last)
 		}
@@ -305,8 +606,8 @@ func (r *queryResolver) EventsByAddress(ctx context.Context, address string, pag
 }
 
 // Contract is the resolver for the contract field.
-func (r *queryResolver) Contract(ctx context.Context, address string) (*models.Contract, error) {
-	contract, err := loadContract(ctx, r.DB, address)
+func (r *queryResolver) Contract(ctx context.Context, address string, chainID *int) (*models.Contract, error) {
+	contract, err := loadContract(ctx, r.DB, r.chainIDOrDefault(chainID), address)
 	if err != nil {
 		return nil, err
 	}
@@ -314,24 +615,22 @@ func (r *queryResolver) Contract(ctx context.Context, address string) (*models.C
 }
 
 // Contracts is the resolver for the contracts field.
-func (r *queryResolver) Contracts(ctx context.Context, isActive *bool) ([]*models.Contract, error) {
+func (r *queryResolver) Contracts(ctx context.Context, isActive *bool, chainID *int) ([]*models.Contract, error) {
 	resp, err := r.AdminClient.ListContracts(ctx, &protoapi.ListContractsRequest{
-		Limit:  50,
-		Offset: 0,
+		Limit:    50,
+		Offset:   0,
+		ChainId:  chainIDOrZero(chainID),
+		IsActive: isActive,
 	})
 	if err != nil {
 		return nil, err
 	}
-	contracts := contractsFromProto(resp.Contracts)
-	if isActive != nil && !*isActive {
-		return []*models.Contract{}, nil
-	}
-	return contracts, nil
+	return contractsFromProto(resp.Contracts), nil
 }
 
 // ContractStats is the resolver for the contractStats field.
-func (r *queryResolver) ContractStats(ctx context.Context, address string) (*models.ContractStats, error) {
-	stats, err := loadContractStats(ctx, r.DB, address)
+func (r *queryResolver) ContractStats(ctx context.Context, address string, chainID *int) (*models.ContractStats, error) {
+	stats, err := loadContractStats(ctx, r.DB, r.chainIDOrDefault(chainID), address)
 	if err != nil {
 		return nil, err
 	}
@@ -362,6 +661,77 @@ func (r *queryResolver) SystemStatus(ctx context.Context) (*model.SystemStatus,
 	}, nil
 }
 
+// Webhooks is the resolver for the webhooks field.
+func (r *queryResolver) Webhooks(ctx context.Context, chainID *int) ([]*model.Webhook, error) {
+	resp, err := r.AdminClient.ListWebhooks(ctx, &protoapi.ListWebhooksRequest{
+		ChainId: chainIDOrZero(chainID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*model.Webhook, 0, len(resp.Webhooks))
+	for _, w := range resp.Webhooks {
+		webhooks = append(webhooks, webhookFromProto(w))
+	}
+	return webhooks, nil
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID *string, status *model.WebhookDeliveryStatus, limit *int, offset *int) (*model.WebhookDeliveryList, error) {
+	req := &protoapi.ListWebhookDeliveriesRequest{}
+	if webhookID != nil {
+		req.WebhookId = *webhookID
+	}
+	if status != nil {
+		req.Status = strings.ToLower(status.String())
+	}
+	if limit != nil {
+		req.Limit = int32(*limit)
+	}
+	if offset != nil {
+		req.Offset = int32(*offset)
+	}
+
+	resp, err := r.AdminClient.ListWebhookDeliveries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0, len(resp.Deliveries))
+	for _, d := range resp.Deliveries {
+		deliveries = append(deliveries, webhookDeliveryFromProto(d))
+	}
+	return &model.WebhookDeliveryList{
+		Deliveries: deliveries,
+		TotalCount: int(resp.TotalCount),
+	}, nil
+}
+
+// TopicSubscriptions is the resolver for the topicSubscriptions field.
+func (r *queryResolver) TopicSubscriptions(ctx context.Context, chainID *int) ([]*model.TopicSubscription, error) {
+	resp, err := r.AdminClient.ListTopicSubscriptions(ctx, &protoapi.ListTopicSubscriptionsRequest{
+		ChainId: chainIDOrZero(chainID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*model.TopicSubscription, 0, len(resp.Subscriptions))
+	for _, sub := range resp.Subscriptions {
+		subscriptions = append(subscriptions, topicSubscriptionFromProto(sub))
+	}
+	return subscriptions, nil
+}
+
+// Events is the resolver for the events field.
+func (r *subscriptionResolver) Events(ctx context.Context, filter *models.EventFilter) (<-chan *models.Event, error) {
+	if r.Broker == nil {
+		return nil, errors.New("event subscriptions are not enabled")
+	}
+	return r.Broker.Subscribe(ctx, filter), nil
+}
+
 // Address is the resolver for the address field.
 func (r *addContractInputResolver) Address(ctx context.Context, obj *models.AddContractInput, data string) error {
 	obj.Address = models.Address(data)
@@ -378,6 +748,18 @@ func (r *addContractInputResolver) StartBlock(ctx context.Context, obj *models.A
 	return nil
 }
 
+// Events is the resolver for the events field.
+func (r *addContractInputResolver) Events(ctx context.Context, obj *models.AddContractInput, data []string) error {
+	obj.Filter.Events = data
+	return nil
+}
+
+// Topics is the resolver for the topics field.
+func (r *addContractInputResolver) Topics(ctx context.Context, obj *models.AddContractInput, data []*model.TopicFilterInput) error {
+	obj.Filter.Topics = topicFiltersFromInput(data)
+	return nil
+}
+
 // ContractAddress is the resolver for the contractAddress field.
 func (r *eventFilterResolver) ContractAddress(ctx context.Context, obj *models.EventFilter, data *string) error {
 	if data == nil {
@@ -442,6 +824,38 @@ func (r *eventFilterResolver) TransactionHash(ctx context.Context, obj *models.E
 	return nil
 }
 
+// Args is the resolver for the args field.
+func (r *eventFilterResolver) Args(ctx context.Context, obj *models.EventFilter, data []*model.ArgPredicateInput) error {
+	obj.Args = nil
+	for _, input := range data {
+		predicate, err := argPredicateFromInput(input)
+		if err != nil {
+			return err
+		}
+		obj.Args = append(obj.Args, predicate)
+	}
+	return nil
+}
+
+// Finality is the resolver for the finality field.
+func (r *eventFilterResolver) Finality(ctx context.Context, obj *models.EventFilter, data *model.Finality) error {
+	if data == nil || *data == model.FinalityAny {
+		obj.Finality = nil
+		return nil
+	}
+	var finality models.Finality
+	switch *data {
+	case model.FinalityPending:
+		finality = models.FinalityPending
+	case model.FinalityConfirmed:
+		finality = models.FinalityConfirmed
+	default:
+		return fmt.Errorf("unsupported finality %s", *data)
+	}
+	obj.Finality = &finality
+	return nil
+}
+
 // Contract returns generated.ContractResolver implementation.
 func (r *Resolver) Contract() generated.ContractResolver { return &contractResolver{r} }
 
@@ -460,6 +874,9 @@ func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResol
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 // AddContractInput returns generated.AddContractInputResolver implementation.
 func (r *Resolver) AddContractInput() generated.AddContractInputResolver {
 	return &addContractInputResolver{r}
@@ -474,6 +891,7 @@ type eventResolver struct{ *Resolver }
 type eventArgResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
 type addContractInputResolver struct{ *Resolver }
 type eventFilterResolver struct{ *Resolver }
 
@@ -483,6 +901,9 @@ type eventFilterResolver struct{ *Resolver }
 //   - When renaming or deleting a resolver the old code will be put in here. You can safely delete
 //     it when you're done.
 //   - You have helper methods in this file. Move them out to keep these resolver files clean.
+func (r *contractResolver) IsActive(ctx context.Context, obj *models.Contract) (bool, error) {
+	return obj.IsActive, nil
+}
 func (r *contractStatsResolver) UniqueAddresses(ctx context.Context, obj *models.ContractStats) (*int, error) {
 	if obj.UniqueAddresses == nil {
 		return nil, nil
@@ -497,20 +918,29 @@ func applyEventFilter(req *protoapi.EventQuery, filter *models.EventFilter) {
 	if filter == nil {
 		return
 	}
+	if filter.ChainID != nil {
+		req.ChainId = *filter.ChainID
+	}
 	if filter.ContractAddress != nil {
-		req.ContractAddress = string(*filter.ContractAddress)
+		req.ContractAddress = proto.String(string(*filter.ContractAddress))
 	}
 	if filter.EventName != nil {
-		req.EventName = *filter.EventName
+		req.EventName = proto.String(*filter.EventName)
+	}
+	if filter.EventSignature != nil {
+		req.EventSignature = proto.String(*filter.EventSignature)
 	}
 	if filter.FromBlock != nil {
-		req.FromBlock = *filter.FromBlock
+		req.FromBlock = proto.Int64(*filter.FromBlock)
 	}
 	if filter.ToBlock != nil {
-		req.ToBlock = *filter.ToBlock
+		req.ToBlock = proto.Int64(*filter.ToBlock)
 	}
 	if filter.TransactionHash != nil {
-		req.TransactionHash = string(*filter.TransactionHash)
+		req.TransactionHash = proto.String(string(*filter.TransactionHash))
+	}
+	if filter.Finality != nil {
+		req.Finality = string(*filter.Finality)
 	}
 	if len(filter.Addresses) > 0 {
 		req.Addresses = make([]string, len(filter.Addresses))
@@ -520,6 +950,12 @@ func applyEventFilter(req *protoapi.EventQuery, filter *models.EventFilter) {
 	} else if filter.Address != nil {
 		req.Addresses = []string{string(*filter.Address)}
 	}
+	if filter.AddressRole != nil {
+		req.AddressRole = proto.String(*filter.AddressRole)
+	}
+	for _, predicate := range filter.Args {
+		req.ArgPredicates = append(req.ArgPredicates, argPredicateToProto(predicate))
+	}
 }
 func applyPagination(req *protoapi.EventQuery, pagination *model.PaginationInput) {
 	if pagination == nil {
@@ -528,47 +964,48 @@ func applyPagination(req *protoapi.EventQuery, pagination *model.PaginationInput
 	if pagination.First != nil {
 		req.First = int32(*pagination.First)
 	}
-	if pagination.After != nil {
-		req.After = *pagination.After
-	}
-	if pagination.Before != nil {
-		req.Before = *pagination.Before
-	}
+	req.After = pagination.After
+	req.Before = pagination.Before
 	if pagination.Last != nil {
 		req.Last = int32(*pagination.Last)
 	}
 }
-func loadContract(ctx context.Context, db *sql.DB, address string) (*models.Contract, error) {
-	key := strings.ToLower(strings.TrimSpace(address))
+func loadContract(ctx context.Context, db *sql.DB, chainID int64, address string) (*models.Contract, error) {
+	key := newContractKey(chainID, address)
 	if loaders := GetLoaders(ctx); loaders != nil && loaders.ContractByAddress != nil {
 		return loaders.ContractByAddress.Load(ctx, key)()
 	}
-	return getContractByAddress(ctx, db, key)
+	return getContractByAddress(ctx, db, key.ChainID, key.Address)
 }
 
-func loadContractStats(ctx context.Context, db *sql.DB, address string) (*models.ContractStats, error) {
-	key := strings.ToLower(strings.TrimSpace(address))
+func loadContractStats(ctx context.Context, db *sql.DB, chainID int64, address string) (*models.ContractStats, error) {
+	key := newContractKey(chainID, address)
 	if loaders := GetLoaders(ctx); loaders != nil && loaders.StatsByAddress != nil {
 		return loaders.StatsByAddress.Load(ctx, key)()
 	}
-	return queryContractStats(ctx, db, key)
+	return queryContractStats(ctx, db, key.ChainID, key.Address)
 }
-func getContractByAddress(ctx context.Context, db *sql.DB, address string) (*models.Contract, error) {
+func getContractByAddress(ctx context.Context, db *sql.DB, chainID int64, address string) (*models.Contract, error) {
 	query := `
-SELECT id, address, abi, name, start_block, current_block, confirm_blocks, created_at, updated_at
+SELECT id, chain_id, address, abi, name, start_block, current_block, confirm_blocks, is_active, event_filter,
+       decode_anonymous_events, created_at, updated_at
 FROM contracts
-WHERE LOWER(address) = $1
+WHERE chain_id = $1 AND LOWER(address) = $2
 `
-	row := db.QueryRowContext(ctx, query, address)
+	row := db.QueryRowContext(ctx, query, chainID, address)
 	var contract models.Contract
 	if err := row.Scan(
 		&contract.ID,
+		&contract.ChainID,
 		&contract.Address,
 		&contract.ABI,
 		&contract.Name,
 		&contract.StartBlock,
 		&contract.CurrentBlock,
 		&contract.ConfirmBlocks,
+		&contract.IsActive,
+		&contract.Filter,
+		&contract.DecodeAnonymousEvents,
 		&contract.CreatedAt,
 		&contract.UpdatedAt,
 	); err != nil {
@@ -576,17 +1013,18 @@ WHERE LOWER(address) = $1
 	}
 	return &contract, nil
 }
-func queryContractStats(ctx context.Context, db *sql.DB, address string) (*models.ContractStats, error) {
+func queryContractStats(ctx context.Context, db *sql.DB, chainID int64, address string) (*models.ContractStats, error) {
 	query := `
 WITH uniq_addresses AS (
     SELECT e.contract_address,
            COUNT(DISTINCT LOWER(value)) FILTER (WHERE value LIKE '0x%') AS unique_addresses
     FROM events e,
          LATERAL jsonb_each_text(e.args)
-    WHERE LOWER(e.contract_address) = $1
+    WHERE e.chain_id = $1 AND LOWER(e.contract_address) = $2
     GROUP BY e.contract_address
 )
 SELECT 
+    c.chain_id,
     c.address,
     COUNT(e.id) AS total_events,
     COALESCE(MAX(e.block_number), c.current_block) AS latest_block,
@@ -595,16 +1033,17 @@ SELECT
     COALESCE(MAX(e.created_at), c.updated_at) AS last_updated,
     COALESCE(u.unique_addresses, 0) AS unique_addresses
 FROM contracts c
-LEFT JOIN events e ON c.address = e.contract_address
+LEFT JOIN events e ON c.chain_id = e.chain_id AND c.address = e.contract_address
 LEFT JOIN uniq_addresses u ON u.contract_address = c.address
-WHERE LOWER(c.address) = $1
-GROUP BY c.address, c.current_block, c.start_block, c.updated_at, u.unique_addresses
+WHERE c.chain_id = $1 AND LOWER(c.address) = $2
+GROUP BY c.chain_id, c.address, c.current_block, c.start_block, c.updated_at, u.unique_addresses
 `
-	row := db.QueryRowContext(ctx, query, address)
+	row := db.QueryRowContext(ctx, query, chainID, address)
 	var stats models.ContractStats
 	var lastUpdated time.Time
 	var uniqueAddresses int64
 	if err := row.Scan(
+		&stats.ChainID,
 		&stats.ContractAddress,
 		&stats.TotalEvents,
 		&stats.LatestBlock,
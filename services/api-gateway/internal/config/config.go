@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	sharedconfig "github.com/smart-contract-event-indexer/shared/config"
 )
 
 // Config holds the configuration for the API Gateway
@@ -42,6 +44,9 @@ type Config struct {
 	// API configuration
 	MaxQueryLimit int `json:"max_query_limit"`
 	DefaultLimit  int `json:"default_limit"`
+
+	// Chain used when a request does not specify one
+	DefaultChainID int64 `json:"default_chain_id"`
 }
 
 // Load loads configuration from environment variables
@@ -65,6 +70,7 @@ func Load(configPath string) (*Config, error) {
 		LogFormat:         getEnvString("LOG_FORMAT", "json"),
 		MaxQueryLimit:     getEnvInt("MAX_QUERY_LIMIT", 1000),
 		DefaultLimit:      getEnvInt("DEFAULT_LIMIT", 20),
+		DefaultChainID:    getEnvInt64("CHAIN_ID", sharedconfig.DefaultChainID),
 	}
 
 	return cfg, nil
@@ -88,6 +94,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvInt64 gets an environment variable as an int64 with a default value
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
 // getEnvDuration gets an environment variable as a duration with a default value
 func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
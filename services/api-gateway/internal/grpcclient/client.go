@@ -159,6 +159,12 @@ func (c *resilientQueryClient) GetContractStats(ctx context.Context, in *protoap
 	return retry(ctx, c.pool, c.retries, c.backoff, call)
 }
 
+// StreamEvents is not retried: once a stream is open, resuming it is up to the
+// caller, which knows the last cursor it received.
+func (c *resilientQueryClient) StreamEvents(ctx context.Context, in *protoapi.EventQuery, opts ...grpc.CallOption) (protoapi.QueryService_StreamEventsClient, error) {
+	return c.pool.pick().StreamEvents(ctx, in, opts...)
+}
+
 type resilientAdminClient struct {
 	pool    *grpcPool[protoapi.AdminServiceClient]
 	retries int
@@ -179,6 +185,48 @@ func (c *resilientAdminClient) RemoveContract(ctx context.Context, in *protoapi.
 	return retry(ctx, c.pool, c.retries, c.backoff, call)
 }
 
+func (c *resilientAdminClient) SetContractActive(ctx context.Context, in *protoapi.SetContractActiveRequest, opts ...grpc.CallOption) (*protoapi.SetContractActiveResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.SetContractActiveResponse, error) {
+		return client.SetContractActive(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) UpdateContractFilter(ctx context.Context, in *protoapi.UpdateContractFilterRequest, opts ...grpc.CallOption) (*protoapi.UpdateContractFilterResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.UpdateContractFilterResponse, error) {
+		return client.UpdateContractFilter(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) AddContractABIVersion(ctx context.Context, in *protoapi.AddContractABIVersionRequest, opts ...grpc.CallOption) (*protoapi.AddContractABIVersionResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.AddContractABIVersionResponse, error) {
+		return client.AddContractABIVersion(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) AddContractFactory(ctx context.Context, in *protoapi.AddContractFactoryRequest, opts ...grpc.CallOption) (*protoapi.AddContractFactoryResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.AddContractFactoryResponse, error) {
+		return client.AddContractFactory(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) ListContractFactories(ctx context.Context, in *protoapi.ListContractFactoriesRequest, opts ...grpc.CallOption) (*protoapi.ListContractFactoriesResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.ListContractFactoriesResponse, error) {
+		return client.ListContractFactories(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) ListContractABIVersions(ctx context.Context, in *protoapi.ListContractABIVersionsRequest, opts ...grpc.CallOption) (*protoapi.ListContractABIVersionsResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.ListContractABIVersionsResponse, error) {
+		return client.ListContractABIVersions(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
 func (c *resilientAdminClient) GetContract(ctx context.Context, in *protoapi.GetContractRequest, opts ...grpc.CallOption) (*protoapi.Contract, error) {
 	call := func(client protoapi.AdminServiceClient) (*protoapi.Contract, error) {
 		return client.GetContract(ctx, in, opts...)
@@ -200,6 +248,13 @@ func (c *resilientAdminClient) TriggerBackfill(ctx context.Context, in *protoapi
 	return retry(ctx, c.pool, c.retries, c.backoff, call)
 }
 
+func (c *resilientAdminClient) TriggerRedecode(ctx context.Context, in *protoapi.RedecodeRequest, opts ...grpc.CallOption) (*protoapi.BackfillResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.BackfillResponse, error) {
+		return client.TriggerRedecode(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
 func (c *resilientAdminClient) GetBackfillStatus(ctx context.Context, in *protoapi.BackfillStatusRequest, opts ...grpc.CallOption) (*protoapi.BackfillJob, error) {
 	call := func(client protoapi.AdminServiceClient) (*protoapi.BackfillJob, error) {
 		return client.GetBackfillStatus(ctx, in, opts...)
@@ -221,6 +276,76 @@ func (c *resilientAdminClient) HealthCheck(ctx context.Context, in *protoapi.Emp
 	return retry(ctx, c.pool, c.retries, c.backoff, call)
 }
 
+func (c *resilientAdminClient) CreateWebhook(ctx context.Context, in *protoapi.CreateWebhookRequest, opts ...grpc.CallOption) (*protoapi.CreateWebhookResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.CreateWebhookResponse, error) {
+		return client.CreateWebhook(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) ListWebhooks(ctx context.Context, in *protoapi.ListWebhooksRequest, opts ...grpc.CallOption) (*protoapi.ListWebhooksResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.ListWebhooksResponse, error) {
+		return client.ListWebhooks(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) DeleteWebhook(ctx context.Context, in *protoapi.DeleteWebhookRequest, opts ...grpc.CallOption) (*protoapi.DeleteWebhookResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.DeleteWebhookResponse, error) {
+		return client.DeleteWebhook(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) ListWebhookDeliveries(ctx context.Context, in *protoapi.ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*protoapi.ListWebhookDeliveriesResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.ListWebhookDeliveriesResponse, error) {
+		return client.ListWebhookDeliveries(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) RetryWebhookDelivery(ctx context.Context, in *protoapi.RetryWebhookDeliveryRequest, opts ...grpc.CallOption) (*protoapi.RetryWebhookDeliveryResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.RetryWebhookDeliveryResponse, error) {
+		return client.RetryWebhookDelivery(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) ListUnknownLogs(ctx context.Context, in *protoapi.ListUnknownLogsRequest, opts ...grpc.CallOption) (*protoapi.ListUnknownLogsResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.ListUnknownLogsResponse, error) {
+		return client.ListUnknownLogs(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) RetryUnknownLogs(ctx context.Context, in *protoapi.RetryUnknownLogsRequest, opts ...grpc.CallOption) (*protoapi.RetryUnknownLogsResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.RetryUnknownLogsResponse, error) {
+		return client.RetryUnknownLogs(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) CreateTopicSubscription(ctx context.Context, in *protoapi.CreateTopicSubscriptionRequest, opts ...grpc.CallOption) (*protoapi.CreateTopicSubscriptionResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.CreateTopicSubscriptionResponse, error) {
+		return client.CreateTopicSubscription(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) ListTopicSubscriptions(ctx context.Context, in *protoapi.ListTopicSubscriptionsRequest, opts ...grpc.CallOption) (*protoapi.ListTopicSubscriptionsResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.ListTopicSubscriptionsResponse, error) {
+		return client.ListTopicSubscriptions(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
+func (c *resilientAdminClient) DeleteTopicSubscription(ctx context.Context, in *protoapi.DeleteTopicSubscriptionRequest, opts ...grpc.CallOption) (*protoapi.DeleteTopicSubscriptionResponse, error) {
+	call := func(client protoapi.AdminServiceClient) (*protoapi.DeleteTopicSubscriptionResponse, error) {
+		return client.DeleteTopicSubscription(ctx, in, opts...)
+	}
+	return retry(ctx, c.pool, c.retries, c.backoff, call)
+}
+
 func retry[T any, C interface{}](ctx context.Context, pool *grpcPool[C], retries int, backoff time.Duration, call func(client C) (T, error)) (T, error) {
 	var zero T
 	var lastErr error
@@ -47,11 +47,49 @@ func NewContractHandler(
 
 // AddContractRequest represents the request to add a contract
 type AddContractRequest struct {
-	Address       string `json:"address" binding:"required"`
-	Name          string `json:"name"`
-	ABI           string `json:"abi" binding:"required"`
-	StartBlock    int64  `json:"start_block" binding:"required"`
-	ConfirmBlocks int32  `json:"confirm_blocks"`
+	ChainID               int64                 `json:"chain_id"`
+	Address               string                `json:"address" binding:"required"`
+	Name                  string                `json:"name"`
+	ABI                   string                `json:"abi" binding:"required"`
+	StartBlock            int64                 `json:"start_block" binding:"required"`
+	ConfirmBlocks         int32                 `json:"confirm_blocks"`
+	Filter                models.ContractFilter `json:"filter"`
+	DecodeAnonymousEvents bool                  `json:"decode_anonymous_events"` // match logs without a known topic0 against the ABI's anonymous events
+}
+
+// AddContractABIVersionRequest represents the request to attach an ABI version
+// to a proxy contract. Exactly one of FromBlock and Implementation is set.
+type AddContractABIVersionRequest struct {
+	ChainID        int64  `json:"chain_id"`
+	ABI            string `json:"abi" binding:"required"`
+	FromBlock      *int64 `json:"from_block"`
+	Implementation string `json:"implementation"`
+}
+
+// AddContractFactoryRequest represents the request to register a factory rule
+type AddContractFactoryRequest struct {
+	ChainID    int64  `json:"chain_id"`
+	EventName  string `json:"event_name" binding:"required"`
+	AddressArg string `json:"address_arg" binding:"required"`
+	ChildABI   string `json:"child_abi" binding:"required"`
+	ChildName  string `json:"child_name"`
+	FromBlock  int64  `json:"from_block"`
+}
+
+// RetryUnknownLogsRequest represents the optional block range of a retry;
+// zero bounds leave that side of the range open
+type RetryUnknownLogsRequest struct {
+	ChainID   int64 `json:"chain_id"`
+	FromBlock int64 `json:"from_block"`
+	ToBlock   int64 `json:"to_block"`
+}
+
+// RedecodeRequest represents the block range of a redecode job
+type RedecodeRequest struct {
+	ChainID   int64 `json:"chain_id"`
+	FromBlock int64 `json:"from_block" binding:"required"`
+	ToBlock   int64 `json:"to_block" binding:"required"`
+	ChunkSize int32 `json:"chunk_size"`
 }
 
 // GetContracts handles GET /api/v1/contracts
@@ -70,10 +108,21 @@ func (h *ContractHandler) GetContracts(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.adminClient.ListContracts(c.Request.Context(), &protoapi.ListContractsRequest{
-		Limit:  int32(limit),
-		Offset: int32(offset),
-	})
+	req := &protoapi.ListContractsRequest{
+		Limit:   int32(limit),
+		Offset:  int32(offset),
+		ChainId: chainIDFromQuery(c),
+	}
+	if v := c.Query("is_active"); v != "" {
+		isActive, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "is_active must be true or false"})
+			return
+		}
+		req.IsActive = &isActive
+	}
+
+	resp, err := h.adminClient.ListContracts(c.Request.Context(), req)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to list contracts via admin service")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query contracts"})
@@ -107,11 +156,14 @@ func (h *ContractHandler) AddContract(c *gin.Context) {
 	if req.ConfirmBlocks == 0 {
 		req.ConfirmBlocks = 6 // Default balanced mode
 	}
+	if req.ChainID == 0 {
+		req.ChainID = h.config.DefaultChainID
+	}
 
 	// Check if contract already exists
 	var existingID int32
-	checkQuery := "SELECT id FROM contracts WHERE address = $1"
-	err := h.db.QueryRow(checkQuery, req.Address).Scan(&existingID)
+	checkQuery := "SELECT id FROM contracts WHERE chain_id = $1 AND address = $2"
+	err := h.db.QueryRow(checkQuery, req.ChainID, req.Address).Scan(&existingID)
 
 	if err == nil {
 		// Contract exists, return existing contract info
@@ -136,11 +188,14 @@ func (h *ContractHandler) AddContract(c *gin.Context) {
 	}
 
 	resp, err := h.adminClient.AddContract(c.Request.Context(), &protoapi.AddContractRequest{
-		Address:       req.Address,
-		Abi:           req.ABI,
-		Name:          req.Name,
-		StartBlock:    req.StartBlock,
-		ConfirmBlocks: req.ConfirmBlocks,
+		ChainId:               req.ChainID,
+		Address:               req.Address,
+		Abi:                   req.ABI,
+		Name:                  req.Name,
+		StartBlock:            req.StartBlock,
+		ConfirmBlocks:         &req.ConfirmBlocks,
+		Filter:                contractFilterToProto(req.Filter),
+		DecodeAnonymousEvents: req.DecodeAnonymousEvents,
 	})
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to add contract via admin service")
@@ -170,6 +225,7 @@ func (h *ContractHandler) GetContract(c *gin.Context) {
 
 	resp, err := h.adminClient.GetContract(c.Request.Context(), &protoapi.GetContractRequest{
 		Address: address,
+		ChainId: chainIDFromQuery(c),
 	})
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
@@ -196,6 +252,7 @@ func (h *ContractHandler) RemoveContract(c *gin.Context) {
 
 	resp, err := h.adminClient.RemoveContract(c.Request.Context(), &protoapi.RemoveContractRequest{
 		Address: address,
+		ChainId: chainIDFromQuery(c),
 	})
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to remove contract")
@@ -214,6 +271,385 @@ func (h *ContractHandler) RemoveContract(c *gin.Context) {
 	})
 }
 
+// PauseContract handles POST /api/v1/contracts/:address/pause
+func (h *ContractHandler) PauseContract(c *gin.Context) {
+	h.setContractActive(c, false)
+}
+
+// ResumeContract handles POST /api/v1/contracts/:address/resume
+func (h *ContractHandler) ResumeContract(c *gin.Context) {
+	h.setContractActive(c, true)
+}
+
+// setContractActive pauses or resumes indexing for the contract in the path.
+// Unlike RemoveContract, the contract's events and indexing position are kept.
+func (h *ContractHandler) setContractActive(c *gin.Context, active bool) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address is required"})
+		return
+	}
+
+	resp, err := h.adminClient.SetContractActive(c.Request.Context(), &protoapi.SetContractActiveRequest{
+		Address:  address,
+		ChainId:  chainIDFromQuery(c),
+		IsActive: active,
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to update contract active flag")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update contract"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": resp.Message})
+		return
+	}
+
+	payload := gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+	}
+	if resp.Contract != nil {
+		payload["contract"] = restContractFromProto(resp.Contract)
+	}
+
+	c.JSON(http.StatusOK, payload)
+}
+
+// UpdateContractFilter handles PUT /api/v1/contracts/:address/filter. The body
+// replaces the contract's filter; an empty body indexes every event again.
+func (h *ContractHandler) UpdateContractFilter(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address is required"})
+		return
+	}
+
+	var filter models.ContractFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.adminClient.UpdateContractFilter(c.Request.Context(), &protoapi.UpdateContractFilterRequest{
+		Address: address,
+		ChainId: chainIDFromQuery(c),
+		Filter:  contractFilterToProto(filter),
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to update contract filter")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update contract"})
+		return
+	}
+
+	if !resp.Success {
+		statusCode := http.StatusBadRequest
+		if resp.Message == "Contract not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{"error": resp.Message})
+		return
+	}
+
+	payload := gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+	}
+	if resp.Contract != nil {
+		payload["contract"] = restContractFromProto(resp.Contract)
+	}
+
+	c.JSON(http.StatusOK, payload)
+}
+
+// AddContractABIVersion handles POST /api/v1/contracts/:address/abis
+func (h *ContractHandler) AddContractABIVersion(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address is required"})
+		return
+	}
+
+	var req AddContractABIVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.adminClient.AddContractABIVersion(c.Request.Context(), &protoapi.AddContractABIVersionRequest{
+		Address:        address,
+		ChainId:        req.ChainID,
+		Abi:            req.ABI,
+		FromBlock:      req.FromBlock,
+		Implementation: req.Implementation,
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to add ABI version")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add ABI version"})
+		return
+	}
+
+	if !resp.Success {
+		statusCode := http.StatusBadRequest
+		if resp.Message == "Contract not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+		"version": restABIVersionFromProto(resp.Version),
+	})
+}
+
+// ListContractABIVersions handles GET /api/v1/contracts/:address/abis
+func (h *ContractHandler) ListContractABIVersions(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address is required"})
+		return
+	}
+
+	resp, err := h.adminClient.ListContractABIVersions(c.Request.Context(), &protoapi.ListContractABIVersionsRequest{
+		Address: address,
+		ChainId: chainIDFromQuery(c),
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list ABI versions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list ABI versions"})
+		return
+	}
+
+	versions := make([]models.ABIVersion, 0, len(resp.Versions))
+	for _, v := range resp.Versions {
+		versions = append(versions, restABIVersionFromProto(v))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+// AddContractFactory handles POST /api/v1/contracts/:address/factories
+func (h *ContractHandler) AddContractFactory(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address is required"})
+		return
+	}
+
+	var req AddContractFactoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.adminClient.AddContractFactory(c.Request.Context(), &protoapi.AddContractFactoryRequest{
+		Address:    address,
+		ChainId:    req.ChainID,
+		EventName:  req.EventName,
+		AddressArg: req.AddressArg,
+		ChildAbi:   req.ChildABI,
+		ChildName:  req.ChildName,
+		FromBlock:  req.FromBlock,
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to add contract factory")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add contract factory"})
+		return
+	}
+
+	if !resp.Success {
+		statusCode := http.StatusBadRequest
+		if resp.Message == "Contract not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+		"factory": restContractFactoryFromProto(resp.Factory),
+	})
+}
+
+// ListContractFactories handles GET /api/v1/contracts/:address/factories
+func (h *ContractHandler) ListContractFactories(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address is required"})
+		return
+	}
+
+	resp, err := h.adminClient.ListContractFactories(c.Request.Context(), &protoapi.ListContractFactoriesRequest{
+		Address: address,
+		ChainId: chainIDFromQuery(c),
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list contract factories")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list contract factories"})
+		return
+	}
+
+	factories := make([]models.ContractFactory, 0, len(resp.Factories))
+	for _, f := range resp.Factories {
+		factories = append(factories, restContractFactoryFromProto(f))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"factories": factories})
+}
+
+// ListUnknownLogs handles GET /api/v1/contracts/:address/unknown-logs
+func (h *ContractHandler) ListUnknownLogs(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address is required"})
+		return
+	}
+
+	req := &protoapi.ListUnknownLogsRequest{
+		ContractAddress: address,
+		ChainId:         chainIDFromQuery(c),
+		Limit:           int32(h.config.DefaultLimit),
+	}
+	if v := c.Query("from_block"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			req.FromBlock = parsed
+		}
+	}
+	if v := c.Query("to_block"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			req.ToBlock = parsed
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
+			req.Limit = int32(parsed)
+		}
+	}
+	if v := c.Query("offset"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
+			req.Offset = int32(parsed)
+		}
+	}
+
+	resp, err := h.adminClient.ListUnknownLogs(c.Request.Context(), req)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list unknown logs")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query unknown logs"})
+		return
+	}
+
+	logs := make([]models.UnknownLog, 0, len(resp.Logs))
+	for _, l := range resp.Logs {
+		logs = append(logs, restUnknownLogFromProto(l))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"logs":        logs,
+		"total_count": resp.TotalCount,
+		"limit":       req.Limit,
+		"offset":      req.Offset,
+	})
+}
+
+// RetryUnknownLogs handles POST /api/v1/contracts/:address/unknown-logs/retry.
+// The body is optional; without it every unknown log of the contract is retried.
+func (h *ContractHandler) RetryUnknownLogs(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address is required"})
+		return
+	}
+
+	var req RetryUnknownLogsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if req.ChainID == 0 {
+		req.ChainID = chainIDFromQuery(c)
+	}
+
+	resp, err := h.adminClient.RetryUnknownLogs(c.Request.Context(), &protoapi.RetryUnknownLogsRequest{
+		ContractAddress: address,
+		ChainId:         req.ChainID,
+		FromBlock:       req.FromBlock,
+		ToBlock:         req.ToBlock,
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to retry unknown logs")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retry unknown logs"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": resp.Success,
+		"queued":  resp.Queued,
+		"message": resp.Message,
+	})
+}
+
+// TriggerRedecode handles POST /api/v1/contracts/:address/redecode.
+// It queues a job that decodes the stored events in the range again with the
+// contract's current ABIs; progress is read like a backfill's.
+func (h *ContractHandler) TriggerRedecode(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address is required"})
+		return
+	}
+
+	var req RedecodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ChainID == 0 {
+		req.ChainID = chainIDFromQuery(c)
+	}
+
+	resp, err := h.adminClient.TriggerRedecode(c.Request.Context(), &protoapi.RedecodeRequest{
+		ContractAddress: address,
+		ChainId:         req.ChainID,
+		FromBlock:       req.FromBlock,
+		ToBlock:         req.ToBlock,
+		ChunkSize:       req.ChunkSize,
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to trigger redecode")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to trigger redecode"})
+		return
+	}
+
+	if !resp.Success {
+		statusCode := http.StatusBadRequest
+		if resp.Message == "Contract not found" {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success":           resp.Success,
+		"job_id":            resp.JobId,
+		"estimated_minutes": resp.EstimatedTime,
+		"message":           resp.Message,
+	})
+}
+
 // GetContractStats handles GET /api/v1/contracts/:address/stats
 func (h *ContractHandler) GetContractStats(c *gin.Context) {
 	address := c.Param("address")
@@ -224,6 +660,7 @@ func (h *ContractHandler) GetContractStats(c *gin.Context) {
 
 	stats, err := h.queryClient.GetContractStats(c.Request.Context(), &protoapi.StatsQuery{
 		ContractAddress: address,
+		ChainId:         chainIDFromQuery(c),
 	})
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to fetch contract stats")
@@ -232,6 +669,7 @@ func (h *ContractHandler) GetContractStats(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
+		"chain_id":         stats.ChainId,
 		"contract_address": stats.ContractAddress,
 		"total_events":     stats.TotalEvents,
 		"latest_block":     stats.LatestBlock,
@@ -245,13 +683,26 @@ func restContractFromProto(contract *protoapi.Contract) models.Contract {
 		return models.Contract{}
 	}
 	result := models.Contract{
-		ID:            contract.Id,
-		Address:       models.Address(contract.Address),
-		ABI:           contract.Abi,
-		Name:          contract.Name,
-		StartBlock:    contract.StartBlock,
-		CurrentBlock:  contract.CurrentBlock,
-		ConfirmBlocks: int(contract.ConfirmBlocks),
+		ID:                    contract.Id,
+		ChainID:               contract.ChainId,
+		Address:               models.Address(contract.Address),
+		ABI:                   contract.Abi,
+		Name:                  contract.Name,
+		StartBlock:            contract.StartBlock,
+		CurrentBlock:          contract.CurrentBlock,
+		ConfirmBlocks:         int(contract.ConfirmBlocks),
+		IsActive:              contract.IsActive,
+		DecodeAnonymousEvents: contract.DecodeAnonymousEvents,
+	}
+	if contract.Filter != nil {
+		result.Filter.Events = contract.Filter.Events
+		for _, t := range contract.Filter.Topics {
+			result.Filter.Topics = append(result.Filter.Topics, models.TopicFilter{
+				Topic1: t.Topic1,
+				Topic2: t.Topic2,
+				Topic3: t.Topic3,
+			})
+		}
 	}
 	if contract.CreatedAt != nil {
 		result.CreatedAt = contract.CreatedAt.AsTime()
@@ -263,6 +714,78 @@ func restContractFromProto(contract *protoapi.Contract) models.Contract {
 	return result
 }
 
+func restABIVersionFromProto(version *protoapi.ABIVersion) models.ABIVersion {
+	if version == nil {
+		return models.ABIVersion{}
+	}
+	result := models.ABIVersion{
+		ID:              version.Id,
+		ChainID:         version.ChainId,
+		ContractAddress: models.Address(version.ContractAddress),
+		ABI:             version.Abi,
+		FromBlock:       version.FromBlock,
+	}
+	if version.Implementation != "" {
+		implementation := models.Address(version.Implementation)
+		result.Implementation = &implementation
+	}
+	if version.CreatedAt != nil {
+		result.CreatedAt = version.CreatedAt.AsTime()
+	}
+	return result
+}
+
+func restContractFactoryFromProto(factory *protoapi.ContractFactory) models.ContractFactory {
+	if factory == nil {
+		return models.ContractFactory{}
+	}
+	result := models.ContractFactory{
+		ID:              factory.Id,
+		ChainID:         factory.ChainId,
+		ContractAddress: models.Address(factory.ContractAddress),
+		EventName:       factory.EventName,
+		AddressArg:      factory.AddressArg,
+		ChildABI:        factory.ChildAbi,
+		ChildName:       factory.ChildName,
+		FromBlock:       factory.FromBlock,
+		Children:        factory.Children,
+	}
+	if factory.CreatedAt != nil {
+		result.CreatedAt = factory.CreatedAt.AsTime()
+	}
+	return result
+}
+
+func restUnknownLogFromProto(log *protoapi.UnknownLog) models.UnknownLog {
+	if log == nil {
+		return models.UnknownLog{}
+	}
+	result := models.UnknownLog{
+		ID:               log.Id,
+		ChainID:          log.ChainId,
+		ContractAddress:  models.Address(log.ContractAddress),
+		BlockNumber:      log.BlockNumber,
+		BlockHash:        models.Hash(log.BlockHash),
+		TransactionHash:  models.Hash(log.TransactionHash),
+		TransactionIndex: int(log.TransactionIndex),
+		LogIndex:         int(log.LogIndex),
+		Topics:           log.Topics,
+		Data:             log.Data,
+		Reason:           log.Reason,
+	}
+	if log.BlockTimestamp != nil {
+		result.BlockTimestamp = log.BlockTimestamp.AsTime()
+	}
+	if log.CreatedAt != nil {
+		result.CreatedAt = log.CreatedAt.AsTime()
+	}
+	if log.RetryRequestedAt != nil {
+		requested := log.RetryRequestedAt.AsTime()
+		result.RetryRequestedAt = &requested
+	}
+	return result
+}
+
 func restContractsFromProto(list []*protoapi.Contract) []models.Contract {
 	results := make([]models.Contract, 0, len(list))
 	for _, contract := range list {
@@ -270,3 +793,18 @@ func restContractsFromProto(list []*protoapi.Contract) []models.Contract {
 	}
 	return results
 }
+
+func contractFilterToProto(filter models.ContractFilter) *protoapi.ContractFilter {
+	if filter.IsEmpty() {
+		return nil
+	}
+	result := &protoapi.ContractFilter{Events: filter.Events}
+	for _, t := range filter.Topics {
+		result.Topics = append(result.Topics, &protoapi.TopicFilter{
+			Topic1: t.Topic1,
+			Topic2: t.Topic2,
+			Topic3: t.Topic3,
+		})
+	}
+	return result
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/smart-contract-event-indexer/shared/models"
 	protoapi "github.com/smart-contract-event-indexer/shared/proto"
 	"github.com/smart-contract-event-indexer/shared/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // EventHandler handles event-related HTTP requests
@@ -40,45 +43,64 @@ func NewEventHandler(
 	}
 }
 
-// GetEvents handles GET /api/v1/events
+// GetEvents handles GET /api/v1/events. Each arg parameter adds a condition
+// on a decoded argument written as name:op:value, e.g.
+// arg=value:gt:1000000000000000000000000 or arg=to:in:0xabc...,0xdef...
 func (h *EventHandler) GetEvents(c *gin.Context) {
-	req := &protoapi.EventQuery{}
+	req := &protoapi.EventQuery{
+		ChainId: chainIDFromQuery(c),
+	}
 
 	if v := c.Query("contract"); v != "" {
-		req.ContractAddress = v
+		req.ContractAddress = proto.String(v)
 	}
 	if v := c.Query("event_name"); v != "" {
-		req.EventName = v
+		req.EventName = proto.String(v)
+	}
+	if v := c.Query("event_signature"); v != "" {
+		req.EventSignature = proto.String(v)
+	}
+	for _, v := range c.QueryArray("arg") {
+		predicate, err := models.ParseArgPredicate(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.ArgPredicates = append(req.ArgPredicates, &protoapi.ArgPredicate{
+			Name:   predicate.Name,
+			Op:     string(predicate.Op),
+			Value:  predicate.Value,
+			Values: predicate.Values,
+		})
+	}
+	if v := c.Query("finality"); v != "" {
+		if !models.Finality(v).IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "finality must be 'pending' or 'confirmed'"})
+			return
+		}
+		req.Finality = v
 	}
 	if v := c.Query("from_block"); v != "" {
 		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-			req.FromBlock = parsed
+			req.FromBlock = proto.Int64(parsed)
 		}
 	}
 	if v := c.Query("to_block"); v != "" {
 		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-			req.ToBlock = parsed
+			req.ToBlock = proto.Int64(parsed)
 		}
 	}
 
-	limit := h.config.DefaultLimit
-	if v := c.Query("limit"); v != "" {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 && parsed <= h.config.MaxQueryLimit {
-			limit = parsed
-		}
-	}
-	offset := 0
-	if v := c.Query("offset"); v != "" {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			offset = parsed
-		}
-	}
-	req.Limit = int32(limit)
-	req.Offset = int32(offset)
+	page := h.pageFromQuery(c)
+	req.First, req.Last, req.After, req.Before = page.first, page.last, page.after, page.before
 
 	ctx := c.Request.Context()
 	resp, err := h.queryClient.GetEvents(ctx, req)
 	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+			return
+		}
 		h.logger.WithError(err).Error("Failed to fetch events via query service")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch events"})
 		return
@@ -87,8 +109,8 @@ func (h *EventHandler) GetEvents(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"events":      restEventsFromProto(resp.Events),
 		"total_count": resp.TotalCount,
-		"limit":       limit,
-		"offset":      offset,
+		"limit":       page.limit,
+		"page_info":   restPageInfoFromProto(resp.PageInfo),
 	})
 }
 
@@ -102,6 +124,7 @@ func (h *EventHandler) GetEventsByTransaction(c *gin.Context) {
 
 	resp, err := h.queryClient.GetEventsByTransaction(c.Request.Context(), &protoapi.TransactionQuery{
 		TransactionHash: txHash,
+		ChainId:         chainIDFromQuery(c),
 	})
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to fetch events by transaction")
@@ -116,7 +139,9 @@ func (h *EventHandler) GetEventsByTransaction(c *gin.Context) {
 	})
 }
 
-// GetEventsByAddress handles GET /api/v1/events/address/:address
+// GetEventsByAddress handles GET /api/v1/events/address/:address. The
+// optional role parameter only matches the address in the argument of that
+// name, e.g. role=to.
 func (h *EventHandler) GetEventsByAddress(c *gin.Context) {
 	address := c.Param("address")
 	if address == "" {
@@ -124,19 +149,24 @@ func (h *EventHandler) GetEventsByAddress(c *gin.Context) {
 		return
 	}
 
-	limit := h.config.DefaultLimit
-	limitStr := c.Query("limit")
-	if limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= h.config.MaxQueryLimit {
-			limit = parsedLimit
-		}
-	}
-
-	resp, err := h.queryClient.GetEventsByAddress(c.Request.Context(), &protoapi.AddressQuery{
+	page := h.pageFromQuery(c)
+	req := &protoapi.AddressQuery{
 		Address: address,
-		First:   int32(limit),
-	})
+		First:   page.first,
+		Last:    page.last,
+		After:   page.after,
+		Before:  page.before,
+		ChainId: chainIDFromQuery(c),
+	}
+	if role := c.Query("role"); role != "" {
+		req.Role = proto.String(role)
+	}
+	resp, err := h.queryClient.GetEventsByAddress(c.Request.Context(), req)
 	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+			return
+		}
 		h.logger.WithError(err).Error("Failed to fetch events by address")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query events"})
 		return
@@ -147,9 +177,61 @@ func (h *EventHandler) GetEventsByAddress(c *gin.Context) {
 		"events":      events,
 		"total_count": len(events),
 		"address":     address,
+		"page_info":   restPageInfoFromProto(resp.PageInfo),
 	})
 }
 
+// restPage holds the paging parameters of a REST event listing
+type restPage struct {
+	limit       int
+	first, last int32
+	after       *string
+	before      *string
+}
+
+// pageFromQuery reads the limit, after and before query parameters. Cursors
+// come from a previous response's page_info; a before cursor alone pages
+// backwards, returning the limit events just ahead of it.
+func (h *EventHandler) pageFromQuery(c *gin.Context) restPage {
+	page := restPage{
+		limit:  h.config.DefaultLimit,
+		after:  optionalQuery(c, "after"),
+		before: optionalQuery(c, "before"),
+	}
+	if v := c.Query("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 && parsed <= h.config.MaxQueryLimit {
+			page.limit = parsed
+		}
+	}
+
+	if page.before != nil && page.after == nil {
+		page.last = int32(page.limit)
+	} else {
+		page.first = int32(page.limit)
+	}
+	return page
+}
+
+// optionalQuery returns the query parameter, or nil when it is absent or empty
+func optionalQuery(c *gin.Context, key string) *string {
+	if v := c.Query(key); v != "" {
+		return &v
+	}
+	return nil
+}
+
+func restPageInfoFromProto(info *protoapi.PageInfo) gin.H {
+	if info == nil {
+		return gin.H{"has_next_page": false, "has_previous_page": false}
+	}
+	return gin.H{
+		"has_next_page":     info.HasNextPage,
+		"has_previous_page": info.HasPreviousPage,
+		"start_cursor":      info.StartCursor,
+		"end_cursor":        info.EndCursor,
+	}
+}
+
 func restEventsFromProto(evts []*protoapi.Event) []models.Event {
 	results := make([]models.Event, 0, len(evts))
 	for _, evt := range evts {
@@ -158,14 +240,18 @@ func restEventsFromProto(evts []*protoapi.Event) []models.Event {
 		}
 		event := models.Event{
 			ID:               evt.Id,
+			ChainID:          evt.ChainId,
 			ContractAddress:  models.Address(evt.ContractAddress),
 			EventName:        evt.EventName,
+			EventSignature:   evt.EventSignature,
 			BlockNumber:      evt.BlockNumber,
 			BlockHash:        models.Hash(evt.BlockHash),
 			TransactionHash:  models.Hash(evt.TransactionHash),
 			TransactionIndex: int(evt.TransactionIndex),
 			LogIndex:         int(evt.LogIndex),
 			Args:             argsMapFromProto(evt.Args),
+			ArgTypes:         argTypesFromProto(evt.Args),
+			Finality:         models.Finality(evt.Finality),
 		}
 		if evt.Timestamp != nil {
 			event.Timestamp = evt.Timestamp.AsTime()
@@ -179,6 +265,17 @@ func restEventsFromProto(evts []*protoapi.Event) []models.Event {
 	return results
 }
 
+// chainIDFromQuery reads the optional chain_id query parameter; 0 lets the
+// backing service apply its default (or match every chain for event queries)
+func chainIDFromQuery(c *gin.Context) int64 {
+	if v := c.Query("chain_id"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return 0
+}
+
 func argsMapFromProto(args []*protoapi.EventArg) models.JSONB {
 	if len(args) == 0 {
 		return models.JSONB{}
@@ -193,6 +290,18 @@ func argsMapFromProto(args []*protoapi.EventArg) models.JSONB {
 	return result
 }
 
+// argTypesFromProto keeps the ABI order and Solidity types of the arguments
+func argTypesFromProto(args []*protoapi.EventArg) models.ArgTypes {
+	result := make(models.ArgTypes, 0, len(args))
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		result = append(result, models.ArgType{Name: arg.Key, Type: arg.Type, Indexed: arg.Indexed})
+	}
+	return result
+}
+
 func rawLogFromArgs(args models.JSONB) *string {
 	if len(args) == 0 {
 		return nil
@@ -6,15 +6,21 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	gqlhandler "github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/redis/go-redis/v9"
 	"github.com/smart-contract-event-indexer/api-gateway/graph"
 	"github.com/smart-contract-event-indexer/api-gateway/graph/generated"
 	"github.com/smart-contract-event-indexer/api-gateway/internal/config"
 	"github.com/smart-contract-event-indexer/api-gateway/internal/handler"
 	"github.com/smart-contract-event-indexer/api-gateway/internal/middleware"
+	"github.com/smart-contract-event-indexer/api-gateway/internal/subscription"
 	protoapi "github.com/smart-contract-event-indexer/shared/proto"
 	"github.com/smart-contract-event-indexer/shared/utils"
 )
@@ -31,6 +37,7 @@ func NewHTTPServer(
 	redisClient *redis.Client,
 	queryClient protoapi.QueryServiceClient,
 	adminClient protoapi.AdminServiceClient,
+	broker *subscription.Broker,
 	logger utils.Logger,
 	cfg *config.Config,
 ) *http.Server {
@@ -62,12 +69,14 @@ func NewHTTPServer(
 		Redis:       redisClient,
 		QueryClient: queryClient,
 		AdminClient: adminClient,
+		Broker:      broker,
 		Logger:      logger,
 		Config:      cfg,
 	}
 	loaderFactory := graph.NewLoaderFactory(db, logger)
-	gqlServer := gqlhandler.NewDefaultServer(
+	gqlServer := newGraphQLServer(
 		generated.NewExecutableSchema(generated.Config{Resolvers: resolver}),
+		cfg.CORSOrigins,
 	)
 
 	// API routes
@@ -88,6 +97,16 @@ func NewHTTPServer(
 			contracts.POST("", contractHandler.AddContract)
 			contracts.GET("/:address", contractHandler.GetContract)
 			contracts.DELETE("/:address", contractHandler.RemoveContract)
+			contracts.POST("/:address/pause", contractHandler.PauseContract)
+			contracts.POST("/:address/resume", contractHandler.ResumeContract)
+			contracts.PUT("/:address/filter", contractHandler.UpdateContractFilter)
+			contracts.GET("/:address/abis", contractHandler.ListContractABIVersions)
+			contracts.POST("/:address/abis", contractHandler.AddContractABIVersion)
+			contracts.GET("/:address/factories", contractHandler.ListContractFactories)
+			contracts.POST("/:address/factories", contractHandler.AddContractFactory)
+			contracts.GET("/:address/unknown-logs", contractHandler.ListUnknownLogs)
+			contracts.POST("/:address/unknown-logs/retry", contractHandler.RetryUnknownLogs)
+			contracts.POST("/:address/redecode", contractHandler.TriggerRedecode)
 			contracts.GET("/:address/stats", contractHandler.GetContractStats)
 		}
 
@@ -101,6 +120,12 @@ func NewHTTPServer(
 		gqlServer.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
 	})
 	router.GET("/graphql", func(c *gin.Context) {
+		if c.IsWebsocket() {
+			// Subscriptions outlive the server's request timeouts
+			rc := http.NewResponseController(c.Writer)
+			_ = rc.SetReadDeadline(time.Time{})
+			_ = rc.SetWriteDeadline(time.Time{})
+		}
 		ctx := graph.WithLoaders(c.Request.Context(), loaderFactory.New())
 		gqlServer.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
 	})
@@ -119,3 +144,41 @@ func NewHTTPServer(
 
 	return server
 }
+
+// newGraphQLServer builds the gqlgen handler with the default transports. The
+// WebSocket transport serves subscriptions (graphql-ws) and accepts the same
+// origins as the CORS middleware.
+func newGraphQLServer(schema graphql.ExecutableSchema, origins []string) *gqlhandler.Server {
+	srv := gqlhandler.New(schema)
+
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if origin == "" {
+					return true
+				}
+				for _, allowed := range origins {
+					if origin == allowed {
+						return true
+					}
+				}
+				return false
+			},
+		},
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+
+	srv.SetQueryCache(lru.New(1000))
+
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New(100),
+	})
+
+	return srv
+}
@@ -0,0 +1,133 @@
+package subscription
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/pubsub"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// subscriberBuffer is how many events may queue for a subscriber before new
+// events are dropped for it.
+const subscriberBuffer = 256
+
+// subscriber is a single live GraphQL subscription.
+type subscriber struct {
+	filter *models.EventFilter
+	ch     chan *models.Event
+}
+
+// Broker fans events published by the indexer out to GraphQL subscribers.
+// A single Redis subscription is shared by every subscriber of the gateway.
+type Broker struct {
+	redis  *redis.Client
+	logger utils.Logger
+
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+// NewBroker creates a broker reading from the indexer's event channels.
+func NewBroker(redisClient *redis.Client, logger utils.Logger) *Broker {
+	return &Broker{
+		redis:       redisClient,
+		logger:      logger,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Run receives published events until ctx is cancelled, resubscribing if the
+// Redis connection drops.
+func (b *Broker) Run(ctx context.Context) {
+	for {
+		b.consume(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// consume reads from a single Redis subscription until it fails or ctx ends.
+func (b *Broker) consume(ctx context.Context) {
+	ps := b.redis.PSubscribe(ctx, pubsub.EventChannelPattern)
+	defer ps.Close()
+
+	if _, err := ps.Receive(ctx); err != nil {
+		if ctx.Err() == nil {
+			b.logger.WithError(err).Error("Failed to subscribe to event channels")
+		}
+		return
+	}
+
+	ch := ps.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				b.logger.Warn("Event subscription closed, reconnecting")
+				return
+			}
+			events, err := pubsub.DecodeEvents(msg.Payload)
+			if err != nil {
+				b.logger.WithError(err).Warn("Dropping malformed event message")
+				continue
+			}
+			b.dispatch(events)
+		}
+	}
+}
+
+// dispatch delivers events to every subscriber whose filter matches. Slow
+// subscribers never block the broker; events that do not fit in their buffer
+// are dropped.
+func (b *Broker) dispatch(events []*models.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers {
+		for _, event := range events {
+			if !sub.filter.Matches(event) {
+				continue
+			}
+			select {
+			case sub.ch <- event:
+			default:
+				b.logger.Warn("Subscriber is falling behind, dropping event", "event_id", event.ID)
+			}
+		}
+	}
+}
+
+// Subscribe registers a subscriber for events matching filter. The returned
+// channel is closed once ctx is done.
+func (b *Broker) Subscribe(ctx context.Context, filter *models.EventFilter) <-chan *models.Event {
+	sub := &subscriber{
+		filter: filter,
+		ch:     make(chan *models.Event, subscriberBuffer),
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers, sub)
+		b.mu.Unlock()
+
+		close(sub.ch)
+	}()
+
+	return sub.ch
+}
@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
 	"time"
 
@@ -15,7 +17,11 @@ import (
 	"github.com/smart-contract-event-indexer/indexer-service/internal/blockchain"
 	"github.com/smart-contract-event-indexer/indexer-service/internal/config"
 	"github.com/smart-contract-event-indexer/indexer-service/internal/indexer"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/reorg"
 	"github.com/smart-contract-event-indexer/indexer-service/internal/storage"
+	sharedconfig "github.com/smart-contract-event-indexer/shared/config"
+	"github.com/smart-contract-event-indexer/shared/database"
+	"github.com/smart-contract-event-indexer/shared/pubsub"
 	"github.com/smart-contract-event-indexer/shared/utils"
 )
 
@@ -36,8 +42,15 @@ func main() {
 	// Initialize logger
 	logger := utils.NewLogger("indexer-service", cfg.LogLevel, cfg.LogFormat)
 	logger.Info("Starting Indexer Service")
+	// Resolve the chains to follow
+	chains, err := cfg.ChainRegistry()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid chain configuration: %v\n", err)
+		os.Exit(1)
+	}
+	
 	logger.WithFields(map[string]interface{}{
-		"rpc_endpoint":   cfg.RPCEndpoint,
+		"chains":         len(chains.Chains()),
 		"poll_interval":  cfg.PollInterval,
 		"batch_size":     cfg.BatchSize,
 		"confirm_blocks": cfg.ConfirmBlocks,
@@ -66,41 +79,128 @@ func main() {
 		logger.WithError(err).Fatal("Failed to ping database")
 	}
 	
-	// Initialize blockchain client
-	client := blockchain.NewClient(cfg.RPCEndpoint, logger)
-	if err := client.Connect(ctx); err != nil {
-		logger.WithError(err).Fatal("Failed to connect to blockchain")
+	// Initialize Redis connection (block hash cache for reorg detection)
+	redisClient, err := database.NewRedisClient(sharedconfig.RedisConfig{URL: cfg.RedisURL}, logger)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to connect to Redis")
 	}
-	defer client.Close()
+	defer redisClient.Close()
 	
 	// Initialize storage layers
 	contractStorage := storage.NewContractStorage(db, logger)
 	eventStorage := storage.NewEventStorage(db, logger)
 	stateStorage := storage.NewStateStorage(db, logger)
+	backfillStorage := storage.NewBackfillStorage(db, logger)
+	reorgStorage := storage.NewReorgStorage(db, logger)
+	
+	// New events are announced on Redis pub/sub for live subscribers
+	publisher := pubsub.NewEventPublisher(redisClient.Client, logger)
+	
+	// Matching events are pushed to registered webhooks
+	webhookPolicy := indexer.DefaultWebhookRetryPolicy()
+	webhookPolicy.MaxAttempts = cfg.WebhookMaxAttempts
+	webhookDispatcher := indexer.NewWebhookDispatcher(
+		storage.NewWebhookStorage(db, logger),
+		webhookPolicy,
+		cfg.WebhookTimeout,
+		cfg.WebhookPollInterval,
+		logger,
+	)
 	
-	// Initialize indexer
-	idx := indexer.NewIndexer(
-		client,
+	// Initialize an RPC manager (primary plus fallbacks) and an indexer for each chain
+	managers := make(map[int64]*blockchain.RPCManager)
+	clients := make(map[int64]blockchain.ChainClient)
+	indexers := make([]*indexer.Indexer, 0, len(chains.Chains()))
+	for _, chain := range chains.Chains() {
+		chainLogger := logger.WithFields(map[string]interface{}{
+			"chain_id":   chain.ID,
+			"chain_name": chain.Name,
+		})
+		
+		// Endpoints reporting a different chain ID are never used, so a chain
+		// cannot be indexed under the wrong ID
+		client := blockchain.NewRPCManager(&blockchain.RPCConfig{
+			PrimaryEndpoint:     chain.PrimaryEndpoint(),
+			FallbackEndpoints:   chain.FallbackEndpoints(),
+			ChainID:             chain.ID,
+			HealthCheckInterval: cfg.RPCHealthCheckInterval,
+			MaxRetries:          cfg.MaxRetries,
+			RetryDelay:          cfg.RetryDelay,
+		}, chainLogger)
+		if err := client.Connect(ctx); err != nil {
+			// The other chains are indexed regardless; this one reports
+			// unhealthy on /health until one of its endpoints answers
+			chainLogger.WithError(err).Error("Failed to connect to blockchain, retrying in the background")
+			go client.ConnectInBackground(ctx, cfg.RPCHealthCheckInterval)
+		}
+		defer client.Close()
+		
+		// Probe every endpoint and fail back to the primary once it recovers
+		go client.StartHealthCheck(ctx)
+		
+		// Reorg detection uses a per-chain block hash cache
+		detector := reorg.NewDetector(chain.ID, client, redisClient.Client, logger)
+		reorgHandler := reorg.NewHandler(db, contractStorage, eventStorage, stateStorage, reorgStorage, detector, logger)
+		
+		managers[chain.ID] = client
+		clients[chain.ID] = client
+		indexers = append(indexers, indexer.NewIndexer(
+			chain.ID,
+			client,
+			contractStorage,
+			eventStorage,
+			stateStorage,
+			detector,
+			reorgHandler,
+			publisher,
+			webhookDispatcher,
+			cfg.PollInterval,
+			cfg.BatchSize,
+			cfg.MaxConcurrent,
+			cfg.ContractTimeout,
+			logger,
+		))
+	}
+	
+	// Initialize backfill worker
+	backfillWorker := indexer.NewBackfillWorker(
+		clients,
 		contractStorage,
 		eventStorage,
-		stateStorage,
-		cfg.PollInterval,
-		cfg.BatchSize,
+		backfillStorage,
+		cfg.BackfillPollInterval,
+		cfg.BackfillStaleAfter,
 		logger,
 	)
 	
 	// Start health check server
-	healthServer := startHealthCheckServer(cfg.HealthPort, logger, db, client)
+	healthServer := startHealthCheckServer(cfg.HealthPort, logger, db, managers)
 	defer func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		healthServer.Shutdown(ctx)
 	}()
 	
-	// Start indexer in a goroutine
-	errChan := make(chan error, 1)
+	// Start one indexer per chain, plus the backfill worker and webhook dispatcher
+	errChan := make(chan error, len(indexers)+2)
+	for _, idx := range indexers {
+		go func(idx *indexer.Indexer) {
+			if err := idx.Start(ctx); err != nil && err != context.Canceled {
+				errChan <- err
+			}
+		}(idx)
+	}
+	
+	// Start backfill worker in a goroutine
+	go func() {
+		if err := backfillWorker.Start(ctx); err != nil && err != context.Canceled {
+			errChan <- err
+		}
+	}()
+	
+	// Start webhook dispatcher in a goroutine
 	go func() {
-		if err := idx.Start(ctx); err != nil && err != context.Canceled {
+		if err := webhookDispatcher.Start(ctx); err != nil && err != context.Canceled {
 			errChan <- err
 		}
 	}()
@@ -128,8 +228,16 @@ func main() {
 	logger.Info("Indexer service stopped")
 }
 
+// chainHealth is the /health report for one chain
+type chainHealth struct {
+	ChainID   int64                       `json:"chain_id"`
+	Healthy   bool                        `json:"healthy"`
+	Error     string                      `json:"error,omitempty"`
+	Endpoints []blockchain.EndpointStatus `json:"endpoints"`
+}
+
 // startHealthCheckServer starts an HTTP server for health checks
-func startHealthCheckServer(port int, logger utils.Logger, db *sqlx.DB, client *blockchain.Client) *http.Server {
+func startHealthCheckServer(port int, logger utils.Logger, db *sqlx.DB, managers map[int64]*blockchain.RPCManager) *http.Server {
 	mux := http.NewServeMux()
 	
 	// Health check endpoint
@@ -137,6 +245,8 @@ func startHealthCheckServer(port int, logger utils.Logger, db *sqlx.DB, client *
 		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 		defer cancel()
 		
+		w.Header().Set("Content-Type", "application/json")
+		
 		// Check database
 		if err := db.PingContext(ctx); err != nil {
 			logger.WithError(err).Error("Database health check failed")
@@ -145,16 +255,47 @@ func startHealthCheckServer(port int, logger utils.Logger, db *sqlx.DB, client *
 			return
 		}
 		
-		// Check blockchain connection
-		if err := client.HealthCheck(ctx); err != nil {
-			logger.WithError(err).Error("Blockchain health check failed")
-			w.WriteHeader(http.StatusServiceUnavailable)
-			w.Write([]byte(`{"status":"unhealthy","reason":"blockchain connection failed"}`))
-			return
+		// Check blockchain connections. A chain on a fallback endpoint, or with
+		// an unhealthy endpoint, is reported as degraded.
+		status := "healthy"
+		code := http.StatusOK
+		chainIDs := make([]int64, 0, len(managers))
+		for chainID := range managers {
+			chainIDs = append(chainIDs, chainID)
 		}
+		sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
 		
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"healthy"}`))
+		report := make([]chainHealth, 0, len(chainIDs))
+		for _, chainID := range chainIDs {
+			manager := managers[chainID]
+			health := chainHealth{ChainID: chainID, Healthy: true}
+			if err := manager.HealthCheck(ctx); err != nil {
+				logger.WithError(err).WithField("chain_id", chainID).Error("Blockchain health check failed")
+				health.Healthy = false
+				health.Error = err.Error()
+				status = "unhealthy"
+				code = http.StatusServiceUnavailable
+			}
+			
+			health.Endpoints = manager.EndpointStatuses()
+			for _, endpoint := range health.Endpoints {
+				if status == "healthy" && (!endpoint.Healthy || (endpoint.Active && endpoint.Role != "primary")) {
+					status = "degraded"
+				}
+			}
+			report = append(report, health)
+		}
+		
+		body, err := json.Marshal(map[string]interface{}{
+			"status": status,
+			"chains": report,
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(code)
+		w.Write(body)
 	})
 	
 	// Readiness endpoint
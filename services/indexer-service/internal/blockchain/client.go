@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -12,11 +13,26 @@ import (
 	"github.com/smart-contract-event-indexer/shared/utils"
 )
 
-// Client wraps an Ethereum client for blockchain interactions
+// ChainClient is the RPC surface the indexer depends on. Client implements it
+// for a single endpoint and RPCManager for a primary with fallbacks.
+type ChainClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	GetLatestBlockNumber(ctx context.Context) (int64, error)
+	GetHeaderByNumber(ctx context.Context, blockNumber int64) (*types.Header, error)
+	GetHeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	GetLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	GetLogsForContract(ctx context.Context, contractAddress common.Address, fromBlock, toBlock int64) ([]types.Log, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// Client wraps an Ethereum client for blockchain interactions. It is safe for
+// concurrent use, including calls racing with Connect.
 type Client struct {
 	endpoint string
-	client   *ethclient.Client
 	logger   utils.Logger
+
+	mu     sync.RWMutex
+	client *ethclient.Client // nil until connected, guarded by mu
 }
 
 // NewClient creates a new blockchain client
@@ -36,10 +52,18 @@ func (c *Client) Connect(ctx context.Context) error {
 		return fmt.Errorf("failed to connect to Ethereum node: %w", err)
 	}
 	
-	c.client = client
+	// Keep the connection of a concurrent Connect that got there first
+	c.mu.Lock()
+	if c.client != nil {
+		client.Close()
+		client = c.client
+	} else {
+		c.client = client
+	}
+	c.mu.Unlock()
 	
 	// Verify connection by getting chain ID
-	chainID, err := c.client.ChainID(ctx)
+	chainID, err := client.ChainID(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get chain ID: %w", err)
 	}
@@ -48,17 +72,29 @@ func (c *Client) Connect(ctx context.Context) error {
 	return nil
 }
 
+// Endpoint returns the RPC URL the client dials
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
 // Close closes the connection to the Ethereum node
 func (c *Client) Close() {
-	if c.client != nil {
-		c.client.Close()
+	if client := c.conn(); client != nil {
+		client.Close()
 		c.logger.Info("Disconnected from Ethereum node")
 	}
 }
 
+// conn returns the underlying connection, nil if not connected
+func (c *Client) conn() *ethclient.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.client
+}
+
 // GetLatestBlockNumber returns the latest block number
 func (c *Client) GetLatestBlockNumber(ctx context.Context) (int64, error) {
-	blockNumber, err := c.client.BlockNumber(ctx)
+	blockNumber, err := c.conn().BlockNumber(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get latest block number: %w", err)
 	}
@@ -67,16 +103,34 @@ func (c *Client) GetLatestBlockNumber(ctx context.Context) (int64, error) {
 
 // GetBlockByNumber returns a block by its number
 func (c *Client) GetBlockByNumber(ctx context.Context, blockNumber int64) (*types.Block, error) {
-	block, err := c.client.BlockByNumber(ctx, big.NewInt(blockNumber))
+	block, err := c.conn().BlockByNumber(ctx, big.NewInt(blockNumber))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block %d: %w", blockNumber, err)
 	}
 	return block, nil
 }
 
+// GetHeaderByNumber returns a block header by its number
+func (c *Client) GetHeaderByNumber(ctx context.Context, blockNumber int64) (*types.Header, error) {
+	header, err := c.conn().HeaderByNumber(ctx, big.NewInt(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header %d: %w", blockNumber, err)
+	}
+	return header, nil
+}
+
+// GetHeaderByHash returns a block header by its hash
+func (c *Client) GetHeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	header, err := c.conn().HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header by hash %s: %w", hash.Hex(), err)
+	}
+	return header, nil
+}
+
 // GetBlockByHash returns a block by its hash
 func (c *Client) GetBlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
-	block, err := c.client.BlockByHash(ctx, hash)
+	block, err := c.conn().BlockByHash(ctx, hash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block by hash %s: %w", hash.Hex(), err)
 	}
@@ -85,7 +139,7 @@ func (c *Client) GetBlockByHash(ctx context.Context, hash common.Hash) (*types.B
 
 // GetLogs retrieves logs for a given filter query
 func (c *Client) GetLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
-	logs, err := c.client.FilterLogs(ctx, query)
+	logs, err := c.conn().FilterLogs(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get logs: %w", err)
 	}
@@ -111,12 +165,12 @@ func (c *Client) GetLogsForContract(ctx context.Context, contractAddress common.
 
 // ChainID returns the chain ID
 func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
-	return c.client.ChainID(ctx)
+	return c.conn().ChainID(ctx)
 }
 
 // IsConnected checks if the client is connected
 func (c *Client) IsConnected() bool {
-	return c.client != nil
+	return c.conn() != nil
 }
 
 // HealthCheck performs a health check on the connection
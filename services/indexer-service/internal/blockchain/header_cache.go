@@ -0,0 +1,124 @@
+package blockchain
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultHeaderCacheSize is the number of block headers kept per chain
+const DefaultHeaderCacheSize = 1024
+
+// HeaderSource fetches block headers by hash
+type HeaderSource interface {
+	GetHeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+}
+
+// HeaderCache is a bounded LRU cache of block timestamps keyed by block hash.
+// Keying by hash rather than number keeps entries valid across reorgs.
+type HeaderCache struct {
+	source HeaderSource
+	size   int
+
+	mu      sync.Mutex
+	entries map[common.Hash]*list.Element
+	order   *list.List // front is most recently used
+}
+
+type headerCacheEntry struct {
+	hash      common.Hash
+	timestamp time.Time
+}
+
+// NewHeaderCache creates a header cache holding at most size blocks
+func NewHeaderCache(source HeaderSource, size int) *HeaderCache {
+	if size <= 0 {
+		size = DefaultHeaderCacheSize
+	}
+	return &HeaderCache{
+		source:  source,
+		size:    size,
+		entries: make(map[common.Hash]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Add stores a header that was fetched elsewhere
+func (c *HeaderCache) Add(header *types.Header) {
+	c.put(header.Hash(), time.Unix(int64(header.Time), 0).UTC())
+}
+
+// Timestamp returns the timestamp of the block with the given hash, fetching
+// the header on a cache miss
+func (c *HeaderCache) Timestamp(ctx context.Context, hash common.Hash) (time.Time, error) {
+	if timestamp, ok := c.get(hash); ok {
+		return timestamp, nil
+	}
+
+	header, err := c.source.GetHeaderByHash(ctx, hash)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	timestamp := time.Unix(int64(header.Time), 0).UTC()
+	c.put(hash, timestamp)
+	return timestamp, nil
+}
+
+// TimestampsForLogs resolves the timestamp of every distinct block the logs
+// belong to. Each block is fetched at most once.
+func (c *HeaderCache) TimestampsForLogs(ctx context.Context, logs []types.Log) (map[common.Hash]time.Time, error) {
+	timestamps := make(map[common.Hash]time.Time)
+	for _, log := range logs {
+		if _, ok := timestamps[log.BlockHash]; ok {
+			continue
+		}
+		timestamp, err := c.Timestamp(ctx, log.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		timestamps[log.BlockHash] = timestamp
+	}
+	return timestamps, nil
+}
+
+// Len returns the number of cached headers
+func (c *HeaderCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *HeaderCache) get(hash common.Hash) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[hash]
+	if !ok {
+		return time.Time{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*headerCacheEntry).timestamp, true
+}
+
+func (c *HeaderCache) put(hash common.Hash, timestamp time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[hash]; ok {
+		elem.Value.(*headerCacheEntry).timestamp = timestamp
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[hash] = c.order.PushFront(&headerCacheEntry{hash: hash, timestamp: timestamp})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*headerCacheEntry).hash)
+	}
+}
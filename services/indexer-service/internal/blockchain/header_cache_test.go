@@ -0,0 +1,133 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeHeaderSource serves headers from a map and counts fetches
+type fakeHeaderSource struct {
+	headers map[common.Hash]*types.Header
+	fetches int
+}
+
+func newFakeHeaderSource(blocks ...int64) (*fakeHeaderSource, []common.Hash) {
+	source := &fakeHeaderSource{headers: make(map[common.Hash]*types.Header)}
+	hashes := make([]common.Hash, 0, len(blocks))
+	for _, number := range blocks {
+		header := &types.Header{Number: big.NewInt(number), Time: uint64(1700000000 + number*12)}
+		source.headers[header.Hash()] = header
+		hashes = append(hashes, header.Hash())
+	}
+	return source, hashes
+}
+
+func (s *fakeHeaderSource) GetHeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	s.fetches++
+	header, ok := s.headers[hash]
+	if !ok {
+		return nil, fmt.Errorf("header %s not found", hash.Hex())
+	}
+	return header, nil
+}
+
+func TestHeaderCache_TimestampsForLogs(t *testing.T) {
+	source, hashes := newFakeHeaderSource(100, 101, 102)
+	cache := NewHeaderCache(source, 10)
+
+	logs := []types.Log{
+		{BlockNumber: 100, BlockHash: hashes[0], Index: 0},
+		{BlockNumber: 100, BlockHash: hashes[0], Index: 1},
+		{BlockNumber: 101, BlockHash: hashes[1], Index: 0},
+		{BlockNumber: 102, BlockHash: hashes[2], Index: 0},
+		{BlockNumber: 102, BlockHash: hashes[2], Index: 1},
+	}
+
+	timestamps, err := cache.TimestampsForLogs(context.Background(), logs)
+	if err != nil {
+		t.Fatalf("TimestampsForLogs failed: %v", err)
+	}
+
+	if source.fetches != 3 {
+		t.Errorf("Expected 3 header fetches, got %d", source.fetches)
+	}
+
+	for i, number := range []int64{100, 101, 102} {
+		expected := time.Unix(1700000000+number*12, 0).UTC()
+		if !timestamps[hashes[i]].Equal(expected) {
+			t.Errorf("Block %d: expected timestamp %v, got %v", number, expected, timestamps[hashes[i]])
+		}
+	}
+
+	// A second batch over the same blocks is served from the cache
+	if _, err := cache.TimestampsForLogs(context.Background(), logs); err != nil {
+		t.Fatalf("TimestampsForLogs failed: %v", err)
+	}
+	if source.fetches != 3 {
+		t.Errorf("Expected cached lookups, got %d fetches", source.fetches)
+	}
+}
+
+func TestHeaderCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	source, hashes := newFakeHeaderSource(1, 2, 3)
+	cache := NewHeaderCache(source, 2)
+	ctx := context.Background()
+
+	for _, hash := range hashes[:2] {
+		if _, err := cache.Timestamp(ctx, hash); err != nil {
+			t.Fatalf("Timestamp failed: %v", err)
+		}
+	}
+
+	// Touch block 1 so block 2 becomes the eviction candidate
+	if _, err := cache.Timestamp(ctx, hashes[0]); err != nil {
+		t.Fatalf("Timestamp failed: %v", err)
+	}
+	if _, err := cache.Timestamp(ctx, hashes[2]); err != nil {
+		t.Fatalf("Timestamp failed: %v", err)
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected cache size 2, got %d", cache.Len())
+	}
+
+	fetches := source.fetches
+	if _, err := cache.Timestamp(ctx, hashes[0]); err != nil {
+		t.Fatalf("Timestamp failed: %v", err)
+	}
+	if source.fetches != fetches {
+		t.Error("Expected block 1 to still be cached")
+	}
+
+	if _, err := cache.Timestamp(ctx, hashes[1]); err != nil {
+		t.Fatalf("Timestamp failed: %v", err)
+	}
+	if source.fetches != fetches+1 {
+		t.Error("Expected block 2 to have been evicted")
+	}
+}
+
+func TestHeaderCache_AddSkipsFetch(t *testing.T) {
+	source, _ := newFakeHeaderSource()
+	cache := NewHeaderCache(source, 0)
+
+	header := &types.Header{Number: big.NewInt(5), Time: 1700000060}
+	cache.Add(header)
+
+	timestamp, err := cache.Timestamp(context.Background(), header.Hash())
+	if err != nil {
+		t.Fatalf("Timestamp failed: %v", err)
+	}
+	if source.fetches != 0 {
+		t.Errorf("Expected no fetches, got %d", source.fetches)
+	}
+	if !timestamp.Equal(time.Unix(1700000060, 0).UTC()) {
+		t.Errorf("Unexpected timestamp %v", timestamp)
+	}
+}
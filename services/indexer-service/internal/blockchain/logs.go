@@ -0,0 +1,162 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// logRangeErrors are fragments of the errors providers return when an
+// eth_getLogs query covers too many blocks or matches too many logs
+var logRangeErrors = []string{
+	"query returned more than",   // Infura and geth-based nodes
+	"log response size exceeded", // Alchemy
+	"block range too large",      // QuickNode
+	"eth_getlogs is limited to",  // QuickNode
+	"block range is too wide",    // Ankr
+	"range is too large",
+	"exceed maximum block range", // Erigon, BSC
+	"max block range",
+	"query exceeds max results",
+	"response size should not greater", // Nethermind-based providers
+	"too many logs",
+}
+
+// IsLogRangeError reports whether err is a provider rejecting an eth_getLogs
+// query as too large. Such a query succeeds once its range is split.
+func IsLogRangeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	errStr := strings.ToLower(err.Error())
+	for _, fragment := range logRangeErrors {
+		if strings.Contains(errStr, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLogsSplit fetches the logs of every address between fromBlock and toBlock
+// that match topics, which follows the eth_getLogs topic rules (nil matches
+// everything). When the provider rejects a query as too large, the block
+// range is halved and both halves are fetched in turn; a single block that is
+// still too large is split by address instead. Logs are returned in block
+// order together with the widest block span that the provider accepted in one
+// query.
+func GetLogsSplit(ctx context.Context, client ChainClient, addresses []common.Address, topics [][]common.Hash, fromBlock, toBlock int64) ([]types.Log, int64, error) {
+	logs, err := client.GetLogs(ctx, ethereum.FilterQuery{
+		FromBlock: big.NewInt(fromBlock),
+		ToBlock:   big.NewInt(toBlock),
+		Addresses: addresses,
+		Topics:    topics,
+	})
+	if err == nil {
+		return logs, toBlock - fromBlock + 1, nil
+	}
+	if !IsLogRangeError(err) || ctx.Err() != nil {
+		return nil, 0, err
+	}
+
+	if fromBlock < toBlock {
+		mid := fromBlock + (toBlock-fromBlock)/2
+		first, firstSpan, err := GetLogsSplit(ctx, client, addresses, topics, fromBlock, mid)
+		if err != nil {
+			return nil, 0, err
+		}
+		second, secondSpan, err := GetLogsSplit(ctx, client, addresses, topics, mid+1, toBlock)
+		if err != nil {
+			return nil, 0, err
+		}
+		if secondSpan > firstSpan {
+			firstSpan = secondSpan
+		}
+		return append(first, second...), firstSpan, nil
+	}
+
+	if len(addresses) > 1 {
+		mid := len(addresses) / 2
+		first, _, err := GetLogsSplit(ctx, client, addresses[:mid], topics, fromBlock, toBlock)
+		if err != nil {
+			return nil, 0, err
+		}
+		second, _, err := GetLogsSplit(ctx, client, addresses[mid:], topics, fromBlock, toBlock)
+		if err != nil {
+			return nil, 0, err
+		}
+		return mergeLogs(first, second), 1, nil
+	}
+
+	return nil, 0, fmt.Errorf("logs of block %d cannot be split further: %w", fromBlock, err)
+}
+
+// GetFilteredLogs fetches the logs of every address between fromBlock and
+// toBlock that match any of topicSets, running one GetLogsSplit per set. A
+// log matching several sets is returned once. With no sets every log is
+// fetched. The returned span is the narrowest of the spans accepted for each
+// set, so a range size learned from it suits every query.
+func GetFilteredLogs(ctx context.Context, client ChainClient, addresses []common.Address, topicSets [][][]common.Hash, fromBlock, toBlock int64) ([]types.Log, int64, error) {
+	if len(topicSets) == 0 {
+		return GetLogsSplit(ctx, client, addresses, nil, fromBlock, toBlock)
+	}
+	if len(topicSets) == 1 {
+		return GetLogsSplit(ctx, client, addresses, topicSets[0], fromBlock, toBlock)
+	}
+
+	type logKey struct {
+		block uint64
+		index uint
+	}
+	var (
+		logs     []types.Log
+		seen     = make(map[logKey]bool)
+		accepted = toBlock - fromBlock + 1
+	)
+	for _, topics := range topicSets {
+		matched, span, err := GetLogsSplit(ctx, client, addresses, topics, fromBlock, toBlock)
+		if err != nil {
+			return nil, 0, err
+		}
+		if span < accepted {
+			accepted = span
+		}
+		for _, log := range matched {
+			key := logKey{block: log.BlockNumber, index: log.Index}
+			if !seen[key] {
+				seen[key] = true
+				logs = append(logs, log)
+			}
+		}
+	}
+
+	sort.SliceStable(logs, func(a, b int) bool {
+		if logs[a].BlockNumber != logs[b].BlockNumber {
+			return logs[a].BlockNumber < logs[b].BlockNumber
+		}
+		return logs[a].Index < logs[b].Index
+	})
+	return logs, accepted, nil
+}
+
+// mergeLogs merges two lists of logs from the same block, keeping them in log
+// index order
+func mergeLogs(a, b []types.Log) []types.Log {
+	merged := make([]types.Log, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if b[0].Index < a[0].Index {
+			merged = append(merged, b[0])
+			b = b[1:]
+		} else {
+			merged = append(merged, a[0])
+			a = a[1:]
+		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...)
+}
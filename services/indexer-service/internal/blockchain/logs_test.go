@@ -0,0 +1,219 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// limitedLogSource serves eth_getLogs like a provider capping both the block
+// range and the number of results per query. Every address emits one log in
+// every block.
+type limitedLogSource struct {
+	ChainClient
+	maxRange   int64
+	maxResults int
+	err        error
+	queries    int
+}
+
+func (s *limitedLogSource) GetLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	s.queries++
+	if s.err != nil {
+		return nil, s.err
+	}
+	from, to := query.FromBlock.Int64(), query.ToBlock.Int64()
+	if to-from+1 > s.maxRange {
+		return nil, errors.New("failed to get logs: block range too large")
+	}
+
+	var logs []types.Log
+	for block := from; block <= to; block++ {
+		for _, address := range query.Addresses {
+			logs = append(logs, types.Log{Address: address, BlockNumber: uint64(block), Index: uint(address[19])})
+		}
+	}
+	if len(logs) > s.maxResults {
+		return nil, fmt.Errorf("failed to get logs: query returned more than %d results", s.maxResults)
+	}
+	return logs, nil
+}
+
+func testAddresses(n int) []common.Address {
+	addresses := make([]common.Address, n)
+	for k := range addresses {
+		addresses[k] = common.HexToAddress(fmt.Sprintf("0x%040x", k+1))
+	}
+	return addresses
+}
+
+func TestGetLogsSplit_SplitsOversizedRanges(t *testing.T) {
+	source := &limitedLogSource{maxRange: 1000, maxResults: 50}
+	addresses := testAddresses(2)
+
+	logs, accepted, err := GetLogsSplit(context.Background(), source, addresses, nil, 1, 200)
+	if err != nil {
+		t.Fatalf("GetLogsSplit: %v", err)
+	}
+	if len(logs) != 400 {
+		t.Fatalf("got %d logs, want 400", len(logs))
+	}
+	for k := 1; k < len(logs); k++ {
+		if logs[k].BlockNumber < logs[k-1].BlockNumber {
+			t.Fatalf("logs out of order at %d: block %d after %d", k, logs[k].BlockNumber, logs[k-1].BlockNumber)
+		}
+	}
+	if accepted != 25 {
+		t.Errorf("accepted span = %d, want 25", accepted)
+	}
+}
+
+func TestGetLogsSplit_NoSplitWhenAccepted(t *testing.T) {
+	source := &limitedLogSource{maxRange: 1000, maxResults: 10000}
+
+	logs, accepted, err := GetLogsSplit(context.Background(), source, testAddresses(3), nil, 10, 109)
+	if err != nil {
+		t.Fatalf("GetLogsSplit: %v", err)
+	}
+	if len(logs) != 300 || accepted != 100 || source.queries != 1 {
+		t.Errorf("got %d logs, span %d in %d queries; want 300 logs, span 100 in 1 query", len(logs), accepted, source.queries)
+	}
+}
+
+func TestGetLogsSplit_SplitsAddressesWithinABlock(t *testing.T) {
+	source := &limitedLogSource{maxRange: 1000, maxResults: 2}
+	addresses := testAddresses(5)
+
+	logs, accepted, err := GetLogsSplit(context.Background(), source, addresses, nil, 7, 7)
+	if err != nil {
+		t.Fatalf("GetLogsSplit: %v", err)
+	}
+	if len(logs) != 5 || accepted != 1 {
+		t.Fatalf("got %d logs with span %d, want 5 logs with span 1", len(logs), accepted)
+	}
+	for k := 1; k < len(logs); k++ {
+		if logs[k].Index < logs[k-1].Index {
+			t.Errorf("logs out of index order: %d after %d", logs[k].Index, logs[k-1].Index)
+		}
+	}
+}
+
+func TestGetLogsSplit_GivesUpOnSingleBlock(t *testing.T) {
+	source := &limitedLogSource{maxRange: 1000, maxResults: 0}
+
+	_, _, err := GetLogsSplit(context.Background(), source, testAddresses(1), nil, 5, 5)
+	if !IsLogRangeError(err) {
+		t.Fatalf("err = %v, want the provider's range error", err)
+	}
+}
+
+func TestGetLogsSplit_DoesNotSplitOtherErrors(t *testing.T) {
+	source := &limitedLogSource{maxRange: 1000, maxResults: 10000, err: errors.New("execution reverted")}
+
+	if _, _, err := GetLogsSplit(context.Background(), source, testAddresses(2), nil, 1, 100); err == nil {
+		t.Fatal("expected the provider error")
+	}
+	if source.queries != 1 {
+		t.Errorf("made %d queries, want 1", source.queries)
+	}
+}
+
+// topicLogSource serves a fixed set of logs, applying the eth_getLogs topic
+// matching rules
+type topicLogSource struct {
+	ChainClient
+	logs    []types.Log
+	queries int
+}
+
+func (s *topicLogSource) GetLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	s.queries++
+	var logs []types.Log
+	for _, log := range s.logs {
+		if matchesTopics(log, query.Topics) {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func matchesTopics(log types.Log, topics [][]common.Hash) bool {
+	for position, accepted := range topics {
+		if len(accepted) == 0 {
+			continue
+		}
+		if position >= len(log.Topics) {
+			return false
+		}
+		found := false
+		for _, topic := range accepted {
+			if log.Topics[position] == topic {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGetFilteredLogs_MergesTopicSets(t *testing.T) {
+	transfer, alice, bob := common.HexToHash("0x01"), common.HexToHash("0xa1"), common.HexToHash("0xb0")
+	source := &topicLogSource{logs: []types.Log{
+		{BlockNumber: 1, Index: 0, Topics: []common.Hash{transfer, alice, bob}},
+		{BlockNumber: 1, Index: 1, Topics: []common.Hash{transfer, bob, bob}},
+		{BlockNumber: 2, Index: 0, Topics: []common.Hash{transfer, bob, alice}},
+		{BlockNumber: 3, Index: 4, Topics: []common.Hash{transfer, common.HexToHash("0xc0"), common.HexToHash("0xc0")}},
+	}}
+
+	// Logs from alice or to alice; the first log matches both
+	topicSets := [][][]common.Hash{
+		{{transfer}, {alice}},
+		{{transfer}, nil, {alice}},
+	}
+	logs, accepted, err := GetFilteredLogs(context.Background(), source, nil, topicSets, 1, 3)
+	if err != nil {
+		t.Fatalf("GetFilteredLogs: %v", err)
+	}
+	if len(logs) != 2 || logs[0].BlockNumber != 1 || logs[1].BlockNumber != 2 {
+		t.Fatalf("got %+v, want the logs of blocks 1 and 2 once each", logs)
+	}
+	if accepted != 3 || source.queries != 2 {
+		t.Errorf("got span %d in %d queries, want span 3 in 2 queries", accepted, source.queries)
+	}
+}
+
+func TestGetFilteredLogs_NoTopicSetsFetchesEverything(t *testing.T) {
+	source := &topicLogSource{logs: []types.Log{{BlockNumber: 1}, {BlockNumber: 2}}}
+
+	logs, _, err := GetFilteredLogs(context.Background(), source, nil, nil, 1, 2)
+	if err != nil {
+		t.Fatalf("GetFilteredLogs: %v", err)
+	}
+	if len(logs) != 2 || source.queries != 1 {
+		t.Errorf("got %d logs in %d queries, want 2 logs in 1 query", len(logs), source.queries)
+	}
+}
+
+func TestIsLogRangeError(t *testing.T) {
+	tests := map[string]bool{
+		"query returned more than 10000 results":                                            true,
+		"Log response size exceeded. You can make eth_getLogs requests with...":             true,
+		"block range too large":                                                             true,
+		"exceed maximum block range: 5000":                                                  true,
+		"all RPC endpoints failed after 3 attempts: query returned more than 10000 results": true,
+		"connection refused":                                                                false,
+		"execution reverted":                                                                false,
+	}
+	for msg, want := range tests {
+		if got := IsLogRangeError(errors.New(msg)); got != want {
+			t.Errorf("IsLogRangeError(%q) = %v, want %v", msg, got, want)
+		}
+	}
+}
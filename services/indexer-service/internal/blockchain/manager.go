@@ -3,14 +3,25 @@ package blockchain
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/smart-contract-event-indexer/shared/utils"
 )
 
+// healthCheckTimeout bounds a single endpoint probe
+const healthCheckTimeout = 10 * time.Second
+
+// defaultReconnectInterval spaces connection attempts for a chain that could
+// not connect at startup when no interval is configured
+const defaultReconnectInterval = 30 * time.Second
+
 // RPCManager manages multiple RPC endpoints with fallback support
 type RPCManager struct {
 	primaryClient   *Client
@@ -18,7 +29,17 @@ type RPCManager struct {
 	currentClient   *Client
 	mu              sync.RWMutex
 	logger          utils.Logger
-	
+
+	// Serializes Connect and switchToFallback, so callers failing at the
+	// same time switch endpoints once instead of racing each other
+	failoverMu sync.Mutex
+
+	// Chain the endpoints must serve; 0 skips the check
+	chainID int64
+
+	// Last known state of each endpoint, guarded by mu
+	statuses map[*Client]*EndpointStatus
+
 	// Health check settings
 	healthCheckInterval time.Duration
 	maxRetries          int
@@ -27,66 +48,165 @@ type RPCManager struct {
 
 // RPCConfig holds configuration for RPC manager
 type RPCConfig struct {
-	PrimaryEndpoint    string
-	FallbackEndpoints  []string
+	PrimaryEndpoint     string
+	FallbackEndpoints   []string
+	ChainID             int64 // endpoints reporting another chain are never used; 0 skips the check
 	HealthCheckInterval time.Duration
-	MaxRetries         int
-	RetryDelay         time.Duration
+	MaxRetries          int
+	RetryDelay          time.Duration
+}
+
+// EndpointStatus describes one RPC endpoint as last seen by the manager
+type EndpointStatus struct {
+	Endpoint    string    `json:"endpoint"` // scheme and host only, paths often carry API keys
+	Role        string    `json:"role"`     // primary or fallback-N
+	Active      bool      `json:"active"`   // currently serving calls
+	Healthy     bool      `json:"healthy"`
+	LatestBlock int64     `json:"latest_block,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastChecked time.Time `json:"last_checked,omitempty"`
+
+	verified bool // connected and confirmed to serve the expected chain
 }
 
 // NewRPCManager creates a new RPC manager with fallback support
 func NewRPCManager(config *RPCConfig, logger utils.Logger) *RPCManager {
 	primaryClient := NewClient(config.PrimaryEndpoint, logger)
-	
+	statuses := map[*Client]*EndpointStatus{
+		primaryClient: {Endpoint: redactEndpoint(config.PrimaryEndpoint), Role: "primary"},
+	}
+
 	fallbackClients := make([]*Client, 0, len(config.FallbackEndpoints))
-	for _, endpoint := range config.FallbackEndpoints {
-		fallbackClients = append(fallbackClients, NewClient(endpoint, logger))
+	for i, endpoint := range config.FallbackEndpoints {
+		client := NewClient(endpoint, logger)
+		fallbackClients = append(fallbackClients, client)
+		statuses[client] = &EndpointStatus{Endpoint: redactEndpoint(endpoint), Role: fmt.Sprintf("fallback-%d", i)}
 	}
-	
+
+	maxRetries := config.MaxRetries
+	if maxRetries < 1 {
+		maxRetries = 1
+	}
+
 	return &RPCManager{
 		primaryClient:       primaryClient,
 		fallbackClients:     fallbackClients,
 		currentClient:       primaryClient,
 		logger:              logger,
+		chainID:             config.ChainID,
+		statuses:            statuses,
 		healthCheckInterval: config.HealthCheckInterval,
-		maxRetries:          config.MaxRetries,
+		maxRetries:          maxRetries,
 		retryDelay:          config.RetryDelay,
 	}
 }
 
 // Connect establishes connection to the primary RPC endpoint
 func (m *RPCManager) Connect(ctx context.Context) error {
+	m.failoverMu.Lock()
+	defer m.failoverMu.Unlock()
+
 	m.logger.Info("Connecting to primary RPC endpoint")
-	
+
 	// Try primary endpoint first
-	if err := m.primaryClient.Connect(ctx); err != nil {
+	if err := m.ensureReady(ctx, m.primaryClient); err != nil {
 		m.logger.WithError(err).Warn("Failed to connect to primary RPC, trying fallbacks")
-		
+
 		// Try fallback endpoints
 		for i, client := range m.fallbackClients {
 			m.logger.WithField("fallback_index", i).Info("Trying fallback RPC endpoint")
-			
-			if err := client.Connect(ctx); err != nil {
+
+			if err := m.ensureReady(ctx, client); err != nil {
 				m.logger.WithError(err).WithField("fallback_index", i).Warn("Failed to connect to fallback RPC")
 				continue
 			}
-			
+
 			// Successfully connected to fallback
 			m.mu.Lock()
 			m.currentClient = client
 			m.mu.Unlock()
-			
+
 			m.logger.WithField("fallback_index", i).Info("Connected to fallback RPC endpoint")
 			return nil
 		}
-		
+
 		return fmt.Errorf("failed to connect to any RPC endpoint")
 	}
-	
+
+	m.mu.Lock()
+	m.currentClient = m.primaryClient
+	m.mu.Unlock()
+
 	m.logger.Info("Successfully connected to primary RPC endpoint")
 	return nil
 }
 
+// ConnectInBackground retries Connect every interval until an endpoint is
+// ready or ctx is done. It is used for chains whose endpoints were all down at
+// startup, so that one unreachable chain does not stop the others.
+func (m *RPCManager) ConnectInBackground(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultReconnectInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Calls and health checks may already have brought an endpoint up
+		if m.ready() {
+			return
+		}
+		if err := m.Connect(ctx); err != nil {
+			m.logger.WithError(err).Warn("RPC endpoints still unavailable")
+			continue
+		}
+		m.logger.Info("RPC connection established after startup failure")
+		return
+	}
+}
+
+// ensureReady connects a client if needed and checks that it answers for the
+// expected chain, recording the outcome in the endpoint's status
+func (m *RPCManager) ensureReady(ctx context.Context, client *Client) error {
+	err := func() error {
+		if !client.IsConnected() {
+			if err := client.Connect(ctx); err != nil {
+				return err
+			}
+		}
+
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get chain ID: %w", err)
+		}
+		if m.chainID != 0 && chainID.Int64() != m.chainID {
+			return fmt.Errorf("endpoint serves chain %d, expected %d", chainID.Int64(), m.chainID)
+		}
+		return nil
+	}()
+
+	m.mu.Lock()
+	m.statuses[client].verified = err == nil
+	m.mu.Unlock()
+
+	m.recordStatus(client, 0, err)
+	return err
+}
+
+// ready reports whether the active endpoint has been connected and verified
+func (m *RPCManager) ready() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.statuses[m.currentClient].verified
+}
+
 // GetCurrentClient returns the currently active client
 func (m *RPCManager) GetCurrentClient() *Client {
 	m.mu.RLock()
@@ -94,6 +214,17 @@ func (m *RPCManager) GetCurrentClient() *Client {
 	return m.currentClient
 }
 
+// ChainID returns the chain ID reported by the active endpoint
+func (m *RPCManager) ChainID(ctx context.Context) (*big.Int, error) {
+	result, err := m.executeWithFallback(ctx, func(client *Client) (interface{}, error) {
+		return client.ChainID(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*big.Int), nil
+}
+
 // GetLatestBlockNumber returns the latest block number with fallback
 func (m *RPCManager) GetLatestBlockNumber(ctx context.Context) (int64, error) {
 	result, err := m.executeWithFallback(ctx, func(client *Client) (interface{}, error) {
@@ -106,21 +237,64 @@ func (m *RPCManager) GetLatestBlockNumber(ctx context.Context) (int64, error) {
 	return result.(int64), nil
 }
 
-// GetLogs retrieves logs with fallback support
-func (m *RPCManager) GetLogs(ctx context.Context, contractAddress string, fromBlock, toBlock int64) (interface{}, error) {
-	return m.executeWithFallback(ctx, func(client *Client) (interface{}, error) {
-		return client.GetLogsForContract(ctx, 
-			common.HexToAddress(contractAddress),
-			fromBlock,
-			toBlock,
-		)
+// GetHeaderByNumber returns a block header by its number with fallback
+func (m *RPCManager) GetHeaderByNumber(ctx context.Context, blockNumber int64) (*types.Header, error) {
+	result, err := m.executeWithFallback(ctx, func(client *Client) (interface{}, error) {
+		return client.GetHeaderByNumber(ctx, blockNumber)
 	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.Header), nil
 }
 
-// executeWithFallback executes a function with automatic fallback on failure
+// GetHeaderByHash returns a block header by its hash with fallback
+func (m *RPCManager) GetHeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	result, err := m.executeWithFallback(ctx, func(client *Client) (interface{}, error) {
+		return client.GetHeaderByHash(ctx, hash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.Header), nil
+}
+
+// GetLogs retrieves logs for a filter query with fallback
+func (m *RPCManager) GetLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	result, err := m.executeWithFallback(ctx, func(client *Client) (interface{}, error) {
+		return client.GetLogs(ctx, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]types.Log), nil
+}
+
+// GetLogsForContract retrieves logs for a contract with fallback
+func (m *RPCManager) GetLogsForContract(ctx context.Context, contractAddress common.Address, fromBlock, toBlock int64) ([]types.Log, error) {
+	result, err := m.executeWithFallback(ctx, func(client *Client) (interface{}, error) {
+		return client.GetLogsForContract(ctx, contractAddress, fromBlock, toBlock)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]types.Log), nil
+}
+
+// HealthCheck checks the active endpoint, failing over if it is unreachable
+func (m *RPCManager) HealthCheck(ctx context.Context) error {
+	_, err := m.executeWithFallback(ctx, func(client *Client) (interface{}, error) {
+		return nil, client.HealthCheck(ctx)
+	})
+	return err
+}
+
+// executeWithFallback executes a function with automatic fallback on failure.
+// Only connection-level errors are retried; other errors (bad requests,
+// oversized log ranges) are returned to the caller straight away.
 func (m *RPCManager) executeWithFallback(ctx context.Context, fn func(*Client) (interface{}, error)) (interface{}, error) {
 	var lastErr error
-	
+
 	for attempt := 0; attempt < m.maxRetries; attempt++ {
 		if attempt > 0 {
 			// Wait before retrying
@@ -130,25 +304,37 @@ func (m *RPCManager) executeWithFallback(ctx context.Context, fn func(*Client) (
 			case <-time.After(m.retryDelay):
 			}
 		}
-		
+
 		client := m.GetCurrentClient()
+		if !client.IsConnected() {
+			lastErr = fmt.Errorf("client not connected")
+			if err := m.switchToFallback(ctx, client); err != nil {
+				m.logger.WithError(err).Warn("Failed to switch to fallback")
+			}
+			continue
+		}
+
 		result, err := fn(client)
-		
+
 		if err == nil {
 			return result, nil
 		}
-		
+
 		lastErr = err
-		m.logger.WithError(err).WithField("attempt", attempt+1).Warn("RPC call failed")
-		
+
 		// Check if we should fallback
-		if m.shouldFallback(err) {
-			if err := m.switchToFallback(ctx); err != nil {
-				m.logger.WithError(err).Warn("Failed to switch to fallback")
-			}
+		if !m.shouldFallback(err) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		m.logger.WithError(err).WithField("attempt", attempt+1).Warn("RPC call failed")
+		m.recordStatus(client, 0, err)
+
+		if err := m.switchToFallback(ctx, client); err != nil {
+			m.logger.WithError(err).Warn("Failed to switch to fallback")
 		}
 	}
-	
+
 	return nil, fmt.Errorf("all RPC endpoints failed after %d attempts: %w", m.maxRetries, lastErr)
 }
 
@@ -157,7 +343,7 @@ func (m *RPCManager) shouldFallback(err error) bool {
 	if err == nil {
 		return false
 	}
-	
+
 	// Check for common connection/timeout errors
 	errStr := err.Error()
 	return contains(errStr, []string{
@@ -167,45 +353,55 @@ func (m *RPCManager) shouldFallback(err error) bool {
 		"timeout",
 		"EOF",
 		"broken pipe",
+		"no such host",
+		"client not connected",
+		"Too Many Requests",
+		"Bad Gateway",
+		"Service Unavailable",
+		"Gateway Timeout",
 	})
 }
 
-// switchToFallback switches to the next available fallback endpoint
-func (m *RPCManager) switchToFallback(ctx context.Context) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	
-	currentClient := m.currentClient
-	
-	// If we're on primary, try first fallback
-	if currentClient == m.primaryClient && len(m.fallbackClients) > 0 {
-		if err := m.fallbackClients[0].Connect(ctx); err == nil {
-			m.currentClient = m.fallbackClients[0]
-			m.logger.Info("Switched to first fallback RPC")
-			return nil
+// switchToFallback switches to the next available endpoint after failed,
+// wrapping around to the primary. It does nothing if another caller has
+// already switched away from failed.
+func (m *RPCManager) switchToFallback(ctx context.Context, failed *Client) error {
+	m.failoverMu.Lock()
+	defer m.failoverMu.Unlock()
+
+	current := m.GetCurrentClient()
+	if current != failed {
+		return nil
+	}
+
+	endpoints := append([]*Client{m.primaryClient}, m.fallbackClients...)
+	start := 0
+	for i, client := range endpoints {
+		if client == current {
+			start = i
+			break
 		}
 	}
-	
-	// Try other fallbacks
-	for i, client := range m.fallbackClients {
-		if client == currentClient {
+
+	for offset := 1; offset <= len(endpoints); offset++ {
+		client := endpoints[(start+offset)%len(endpoints)]
+		if client == current && len(endpoints) > 1 {
 			continue
 		}
-		
-		if err := client.Connect(ctx); err == nil {
-			m.currentClient = client
-			m.logger.WithField("fallback_index", i).Info("Switched to fallback RPC")
-			return nil
+
+		if err := m.ensureReady(ctx, client); err != nil {
+			continue
 		}
-	}
-	
-	// Try to reconnect to primary
-	if err := m.primaryClient.Connect(ctx); err == nil {
-		m.currentClient = m.primaryClient
-		m.logger.Info("Reconnected to primary RPC")
+
+		m.mu.Lock()
+		m.currentClient = client
+		role := m.statuses[client].Role
+		m.mu.Unlock()
+
+		m.logger.WithField("endpoint", role).Info("Switched RPC endpoint")
 		return nil
 	}
-	
+
 	return fmt.Errorf("no healthy RPC endpoints available")
 }
 
@@ -214,51 +410,106 @@ func (m *RPCManager) StartHealthCheck(ctx context.Context) {
 	if m.healthCheckInterval == 0 {
 		return
 	}
-	
+
 	ticker := time.NewTicker(m.healthCheckInterval)
 	defer ticker.Stop()
-	
+
 	m.logger.WithField("interval", m.healthCheckInterval).Info("Starting RPC health checks")
-	
+
 	for {
 		select {
 		case <-ctx.Done():
 			m.logger.Info("Stopping RPC health checks")
 			return
-			
+
 		case <-ticker.C:
 			m.checkHealth(ctx)
 		}
 	}
 }
 
-// checkHealth performs health checks on all endpoints
+// checkHealth probes every endpoint and fails back to the primary once it
+// has recovered
 func (m *RPCManager) checkHealth(ctx context.Context) {
 	// Check primary
-	if err := m.primaryClient.HealthCheck(ctx); err != nil {
+	if err := m.probe(ctx, m.primaryClient); err != nil {
 		m.logger.WithError(err).Warn("Primary RPC health check failed")
 	} else {
 		// If primary is healthy and we're on fallback, switch back
-		m.mu.RLock()
+		m.mu.Lock()
 		isOnFallback := m.currentClient != m.primaryClient
-		m.mu.RUnlock()
-		
+		m.currentClient = m.primaryClient
+		m.mu.Unlock()
+
 		if isOnFallback {
-			m.mu.Lock()
-			m.currentClient = m.primaryClient
-			m.mu.Unlock()
 			m.logger.Info("Primary RPC is healthy, switched back from fallback")
 		}
 	}
-	
+
 	// Check fallbacks
 	for i, client := range m.fallbackClients {
-		if err := client.HealthCheck(ctx); err != nil {
+		if err := m.probe(ctx, client); err != nil {
 			m.logger.WithError(err).WithField("fallback_index", i).Debug("Fallback RPC health check failed")
 		}
 	}
 }
 
+// probe checks a single endpoint, connecting and verifying it first if that
+// has not succeeded yet
+func (m *RPCManager) probe(ctx context.Context, client *Client) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	m.mu.RLock()
+	verified := m.statuses[client].verified
+	m.mu.RUnlock()
+
+	if !verified {
+		if err := m.ensureReady(ctx, client); err != nil {
+			return err
+		}
+	}
+
+	latestBlock, err := client.GetLatestBlockNumber(ctx)
+	m.recordStatus(client, latestBlock, err)
+	return err
+}
+
+// recordStatus stores the outcome of a call or probe against an endpoint
+func (m *RPCManager) recordStatus(client *Client, latestBlock int64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status, ok := m.statuses[client]
+	if !ok {
+		return
+	}
+
+	status.Healthy = err == nil
+	status.LastChecked = time.Now().UTC()
+	status.LastError = ""
+	if err != nil {
+		status.LastError = err.Error()
+	}
+	if latestBlock > 0 {
+		status.LatestBlock = latestBlock
+	}
+}
+
+// EndpointStatuses returns the last known state of every endpoint, primary first
+func (m *RPCManager) EndpointStatuses() []EndpointStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]EndpointStatus, 0, 1+len(m.fallbackClients))
+	for _, client := range append([]*Client{m.primaryClient}, m.fallbackClients...) {
+		status := *m.statuses[client]
+		status.Active = client == m.currentClient
+		result = append(result, status)
+	}
+	return result
+}
+
 // Close closes all RPC connections
 func (m *RPCManager) Close() {
 	m.primaryClient.Close()
@@ -272,26 +523,29 @@ func (m *RPCManager) Close() {
 func (m *RPCManager) GetStats() map[string]interface{} {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	
+
 	currentEndpoint := "unknown"
-	if m.currentClient == m.primaryClient {
-		currentEndpoint = "primary"
-	} else {
-		for i, client := range m.fallbackClients {
-			if client == m.currentClient {
-				currentEndpoint = fmt.Sprintf("fallback-%d", i)
-				break
-			}
-		}
+	if status, ok := m.statuses[m.currentClient]; ok {
+		currentEndpoint = status.Role
 	}
-	
+
 	return map[string]interface{}{
-		"current_endpoint":  currentEndpoint,
-		"fallback_count":    len(m.fallbackClients),
+		"current_endpoint":      currentEndpoint,
+		"fallback_count":        len(m.fallbackClients),
 		"health_check_interval": m.healthCheckInterval.String(),
 	}
 }
 
+// redactEndpoint strips credentials, paths and query strings from an RPC URL;
+// hosted providers put API keys there
+func redactEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "invalid-endpoint"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
 // Helper function to check if string contains any substring
 func contains(s string, substrs []string) bool {
 	for _, substr := range substrs {
@@ -301,4 +555,3 @@ func contains(s string, substrs []string) bool {
 	}
 	return false
 }
-
@@ -10,14 +10,14 @@ import (
 
 // BlockMonitor monitors the blockchain for new blocks
 type BlockMonitor struct {
-	client       *Client
+	client       ChainClient
 	pollInterval time.Duration
 	logger       utils.Logger
 	lastBlock    int64
 }
 
 // NewBlockMonitor creates a new block monitor
-func NewBlockMonitor(client *Client, pollInterval time.Duration, logger utils.Logger) *BlockMonitor {
+func NewBlockMonitor(client ChainClient, pollInterval time.Duration, logger utils.Logger) *BlockMonitor {
 	return &BlockMonitor{
 		client:       client,
 		pollInterval: pollInterval,
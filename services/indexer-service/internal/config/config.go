@@ -4,14 +4,25 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	sharedconfig "github.com/smart-contract-event-indexer/shared/config"
 )
 
 // Config holds all configuration for the indexer service
 type Config struct {
 	// RPC Configuration
-	RPCEndpoint  string
-	RPCFallbacks []string
+	RPCEndpoint            string
+	RPCFallbacks           []string
+	RPCHealthCheckInterval time.Duration
+
+	// Chain Configuration. When ChainsConfigPath is set the chain registry is
+	// loaded from that file; otherwise a single chain is built from ChainID and
+	// the RPC settings above.
+	ChainID          int64
+	BlockTime        time.Duration
+	ChainsConfigPath string
 
 	// Database Configuration
 	DatabaseURL string
@@ -24,6 +35,16 @@ type Config struct {
 	MaxRetries       int
 	RetryDelay       time.Duration
 	MaxConcurrent    int
+	ContractTimeout  time.Duration
+
+	// Backfill Settings
+	BackfillPollInterval time.Duration
+	BackfillStaleAfter   time.Duration
+
+	// Webhook Settings
+	WebhookPollInterval time.Duration
+	WebhookTimeout      time.Duration
+	WebhookMaxAttempts  int
 
 	// Logging
 	LogLevel  string
@@ -41,6 +62,12 @@ func Load() (*Config, error) {
 		// RPC defaults
 		RPCEndpoint: getEnvOrDefault("RPC_ENDPOINT", "http://localhost:8545"),
 		RPCFallbacks: []string{},
+		RPCHealthCheckInterval: parseDurationOrDefault("RPC_HEALTH_CHECK_INTERVAL", 30*time.Second),
+
+		// Chain defaults
+		ChainID:          parseInt64OrDefault("CHAIN_ID", sharedconfig.DefaultChainID),
+		BlockTime:        parseDurationOrDefault("BLOCK_TIME", 12*time.Second),
+		ChainsConfigPath: os.Getenv("CHAINS_CONFIG"),
 
 		// Database defaults
 		DatabaseURL: os.Getenv("DATABASE_URL"),
@@ -53,6 +80,16 @@ func Load() (*Config, error) {
 		MaxRetries:    parseIntOrDefault("MAX_RETRIES", 3),
 		RetryDelay:    parseDurationOrDefault("RETRY_DELAY", 5*time.Second),
 		MaxConcurrent: parseIntOrDefault("MAX_CONCURRENT_CONTRACTS", 5),
+		ContractTimeout: parseDurationOrDefault("CONTRACT_TIMEOUT", 2*time.Minute),
+
+		// Backfill defaults
+		BackfillPollInterval: parseDurationOrDefault("BACKFILL_POLL_INTERVAL", 10*time.Second),
+		BackfillStaleAfter:   parseDurationOrDefault("BACKFILL_STALE_AFTER", 5*time.Minute),
+
+		// Webhook defaults
+		WebhookPollInterval: parseDurationOrDefault("WEBHOOK_POLL_INTERVAL", 2*time.Second),
+		WebhookTimeout:      parseDurationOrDefault("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookMaxAttempts:  parseIntOrDefault("WEBHOOK_MAX_ATTEMPTS", 8),
 
 		// Logging defaults
 		LogLevel:  getEnvOrDefault("LOG_LEVEL", "info"),
@@ -64,11 +101,9 @@ func Load() (*Config, error) {
 		HealthPort:  parseIntOrDefault("HEALTH_PORT", 8081),
 	}
 
-	// Parse fallback RPC endpoints if provided
+	// Parse fallback RPC endpoints if provided (comma-separated, in order of preference)
 	if fallbacks := os.Getenv("RPC_FALLBACKS"); fallbacks != "" {
-		// Simple comma-separated parsing
-		// In production, use proper config parser
-		cfg.RPCFallbacks = []string{fallbacks}
+		cfg.RPCFallbacks = parseList(fallbacks)
 	}
 
 	// Validate required fields
@@ -81,9 +116,12 @@ func Load() (*Config, error) {
 
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
-	if c.RPCEndpoint == "" {
+	if c.ChainsConfigPath == "" && c.RPCEndpoint == "" {
 		return fmt.Errorf("RPC_ENDPOINT cannot be empty")
 	}
+	if c.ChainID <= 0 {
+		return fmt.Errorf("CHAIN_ID must be positive")
+	}
 	if c.DatabaseURL == "" {
 		return fmt.Errorf("DATABASE_URL cannot be empty")
 	}
@@ -93,12 +131,47 @@ func (c *Config) Validate() error {
 	if c.BatchSize <= 0 {
 		return fmt.Errorf("BATCH_SIZE must be positive")
 	}
+	if c.RPCHealthCheckInterval < 0 {
+		return fmt.Errorf("RPC_HEALTH_CHECK_INTERVAL cannot be negative")
+	}
+	if c.MaxRetries < 1 {
+		return fmt.Errorf("MAX_RETRIES must be at least 1")
+	}
+	if c.MaxConcurrent < 1 {
+		return fmt.Errorf("MAX_CONCURRENT_CONTRACTS must be at least 1")
+	}
+	if c.ContractTimeout <= 0 {
+		return fmt.Errorf("CONTRACT_TIMEOUT must be positive")
+	}
+	if c.BackfillPollInterval <= 0 {
+		return fmt.Errorf("BACKFILL_POLL_INTERVAL must be positive")
+	}
+	if c.WebhookPollInterval <= 0 {
+		return fmt.Errorf("WEBHOOK_POLL_INTERVAL must be positive")
+	}
+	if c.WebhookMaxAttempts < 1 {
+		return fmt.Errorf("WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
 	if c.ConfirmBlocks < 1 || c.ConfirmBlocks > 100 {
 		return fmt.Errorf("CONFIRM_BLOCKS must be between 1 and 100")
 	}
 	return nil
 }
 
+// ChainRegistry returns the chains this indexer should follow
+func (c *Config) ChainRegistry() (*sharedconfig.ChainRegistry, error) {
+	if c.ChainsConfigPath != "" {
+		return sharedconfig.LoadChainRegistry(c.ChainsConfigPath)
+	}
+
+	return sharedconfig.NewChainRegistry([]sharedconfig.ChainConfig{{
+		ID:            c.ChainID,
+		RPCEndpoints:  append([]string{c.RPCEndpoint}, c.RPCFallbacks...),
+		BlockTime:     c.BlockTime,
+		ConfirmBlocks: c.ConfirmBlocks,
+	}})
+}
+
 // Helper functions
 
 func getEnvOrDefault(key, defaultValue string) string {
@@ -117,6 +190,15 @@ func parseIntOrDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func parseInt64OrDefault(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 func parseDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if parsed, err := time.ParseDuration(value); err == nil {
@@ -126,3 +208,13 @@ func parseDurationOrDefault(key string, defaultValue time.Duration) time.Duratio
 	return defaultValue
 }
 
+// parseList splits a comma-separated value, dropping blank entries
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
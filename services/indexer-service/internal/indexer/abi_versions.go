@@ -0,0 +1,185 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/parser"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// contractParser is the event parser of a contract together with the ABI
+// versions it was built from. Versions waiting for an Upgraded event are kept
+// aside until the indexer sees the upgrade.
+type contractParser struct {
+	eventParser     *parser.EventParser
+	versionsKey     string
+	decodeAnonymous bool
+	pending         []pendingVersion
+}
+
+// pendingVersion is an ABI version that takes effect at the proxy's Upgraded
+// event to implementation
+type pendingVersion struct {
+	id             int64
+	implementation common.Address
+	abiParser      *parser.ABIParser
+}
+
+// watchesUpgrades reports whether the contract's Upgraded events must be
+// fetched to activate a pending version
+func (p *contractParser) watchesUpgrades() bool {
+	return len(p.pending) > 0
+}
+
+// pendingParsers returns the ABI parsers of the pending versions
+func (p *contractParser) pendingParsers() []*parser.ABIParser {
+	parsers := make([]*parser.ABIParser, len(p.pending))
+	for k, version := range p.pending {
+		parsers[k] = version.abiParser
+	}
+	return parsers
+}
+
+// abiVersionsKey identifies a set of ABI versions, so a cached parser is
+// rebuilt once a version is attached or activated
+func abiVersionsKey(versions []*models.ABIVersion) string {
+	var key strings.Builder
+	for _, version := range versions {
+		fmt.Fprintf(&key, "%d:", version.ID)
+		if version.FromBlock != nil {
+			fmt.Fprintf(&key, "%d", *version.FromBlock)
+		}
+		key.WriteString(",")
+	}
+	return key.String()
+}
+
+// newContractParser builds the parser of a contract from its own ABI, which
+// applies from its first block, and its later ABI versions. Contracts that
+// opted in also decode anonymous events.
+func newContractParser(contract *models.Contract, versions []*models.ABIVersion, logger utils.Logger) (*contractParser, error) {
+	abiParser, err := parser.NewABIParser(contract.ABI, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ABI parser: %w", err)
+	}
+
+	cp := &contractParser{
+		eventParser:     parser.NewEventParser(abiParser, logger),
+		versionsKey:     abiVersionsKey(versions),
+		decodeAnonymous: contract.DecodeAnonymousEvents,
+	}
+	if contract.DecodeAnonymousEvents {
+		cp.eventParser.DecodeAnonymousEvents()
+	}
+	for _, version := range versions {
+		versionParser, err := parser.NewABIParser(version.ABI, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ABI parser for version %d: %w", version.ID, err)
+		}
+		if version.FromBlock != nil {
+			cp.eventParser.AddVersion(uint64(*version.FromBlock), versionParser)
+			continue
+		}
+		if version.Implementation == nil {
+			continue
+		}
+		cp.pending = append(cp.pending, pendingVersion{
+			id:             version.ID,
+			implementation: version.Implementation.ToCommonAddress(),
+			abiParser:      versionParser,
+		})
+	}
+
+	return cp, nil
+}
+
+// upgradedImplementation returns the new implementation announced by an
+// Upgraded(address) log
+func upgradedImplementation(log types.Log) (common.Address, bool) {
+	if len(log.Topics) < 2 || log.Topics[0] != parser.UpgradedEventID {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(log.Topics[1].Bytes()), true
+}
+
+// parserForContract returns the contract's parser, building it again when its
+// ABI versions or anonymous decoding changed since it was cached
+func (i *Indexer) parserForContract(contract *models.Contract, versions []*models.ABIVersion) (*contractParser, error) {
+	key := abiVersionsKey(versions)
+	i.parsersMu.RLock()
+	cp, ok := i.parsers[contract.Address]
+	i.parsersMu.RUnlock()
+	if ok && cp.versionsKey == key && cp.decodeAnonymous == contract.DecodeAnonymousEvents {
+		return cp, nil
+	}
+
+	cp, err := newContractParser(contract, versions, i.logger)
+	if err != nil {
+		return nil, err
+	}
+	i.parsersMu.Lock()
+	i.parsers[contract.Address] = cp
+	i.parsersMu.Unlock()
+
+	i.logger.WithFields(map[string]interface{}{
+		"contract":     contract.Address,
+		"name":         contract.Name,
+		"abi_versions": len(versions) + 1,
+	}).Debug("Parser created for contract")
+
+	return cp, nil
+}
+
+// applyUpgrades activates the pending ABI versions whose Upgraded event is
+// among logs, effective from the event's block, and returns the parser to
+// decode logs with. Logs after an upgrade in the same range are decoded with
+// the new version.
+func (i *Indexer) applyUpgrades(ctx context.Context, contract *models.Contract, cp *contractParser, logs []types.Log) (*parser.EventParser, error) {
+	if !cp.watchesUpgrades() {
+		return cp.eventParser, nil
+	}
+
+	activated := false
+	for _, log := range logs {
+		implementation, ok := upgradedImplementation(log)
+		if !ok {
+			continue
+		}
+		for _, version := range cp.pending {
+			if version.implementation != implementation {
+				continue
+			}
+			ok, err := i.contractStorage.ActivateABIVersion(ctx, version.id, int64(log.BlockNumber))
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			activated = true
+			i.logger.WithFields(map[string]interface{}{
+				"contract":       contract.Address,
+				"implementation": implementation.Hex(),
+				"block":          log.BlockNumber,
+			}).Info("Proxy upgraded, switching ABI version")
+		}
+	}
+	if !activated {
+		return cp.eventParser, nil
+	}
+
+	versions, err := i.contractStorage.GetABIVersions(ctx, i.chainID, contract.Address)
+	if err != nil {
+		return nil, err
+	}
+	cp, err = i.parserForContract(contract, versions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parser: %w", err)
+	}
+	return cp.eventParser, nil
+}
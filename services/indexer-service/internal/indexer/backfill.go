@@ -0,0 +1,338 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/blockchain"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/parser"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/storage"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// errBackfillStopped signals that a job was cancelled or taken over while running
+var errBackfillStopped = errors.New("backfill job is no longer running")
+
+// BackfillWorker executes historical backfill jobs queued in the backfill_jobs table.
+// It only claims jobs for chains it has a client for.
+type BackfillWorker struct {
+	clients         map[int64]blockchain.ChainClient
+	headers         map[int64]*blockchain.HeaderCache
+	chainIDs        []int64
+	contractStorage *storage.ContractStorage
+	eventStorage    *storage.EventStorage
+	backfillStorage *storage.BackfillStorage
+	classifier      *ErrorClassifier
+	pollInterval    time.Duration
+	staleAfter      time.Duration
+	logger          utils.Logger
+}
+
+// NewBackfillWorker creates a new backfill worker
+func NewBackfillWorker(
+	clients map[int64]blockchain.ChainClient,
+	contractStorage *storage.ContractStorage,
+	eventStorage *storage.EventStorage,
+	backfillStorage *storage.BackfillStorage,
+	pollInterval time.Duration,
+	staleAfter time.Duration,
+	logger utils.Logger,
+) *BackfillWorker {
+	chainIDs := make([]int64, 0, len(clients))
+	headers := make(map[int64]*blockchain.HeaderCache, len(clients))
+	for chainID, client := range clients {
+		chainIDs = append(chainIDs, chainID)
+		headers[chainID] = blockchain.NewHeaderCache(client, blockchain.DefaultHeaderCacheSize)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+
+	return &BackfillWorker{
+		clients:         clients,
+		headers:         headers,
+		chainIDs:        chainIDs,
+		contractStorage: contractStorage,
+		eventStorage:    eventStorage,
+		backfillStorage: backfillStorage,
+		classifier:      NewErrorClassifier(DefaultRetryPolicy(), logger),
+		pollInterval:    pollInterval,
+		staleAfter:      staleAfter,
+		logger:          logger,
+	}
+}
+
+// Start polls for pending backfill jobs and runs them one at a time
+func (w *BackfillWorker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	w.logger.WithFields(map[string]interface{}{
+		"poll_interval": w.pollInterval,
+		"chains":        w.chainIDs,
+	}).Info("Backfill worker started")
+
+	for {
+		// Drain the queue before waiting for the next tick
+		for {
+			ran, err := w.runNextJob(ctx)
+			if err != nil {
+				w.logger.WithError(err).Error("Error running backfill job")
+			}
+			if !ran || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Backfill worker stopping")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNextJob claims and executes a single job. Returns false if no job was available.
+func (w *BackfillWorker) runNextJob(ctx context.Context) (bool, error) {
+	job, err := w.backfillStorage.ClaimNextJob(ctx, w.chainIDs, w.staleAfter)
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	err = w.executeJob(ctx, job)
+	switch {
+	case err == nil:
+		if err := w.backfillStorage.CompleteJob(ctx, job.ID); err != nil {
+			return true, err
+		}
+	case errors.Is(err, errBackfillStopped):
+		w.logger.WithField("job_id", job.ID).Info("Backfill job stopped externally")
+	case ctx.Err() != nil:
+		// Shutting down: hand the job back so it resumes from its last checkpoint
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := w.backfillStorage.ReleaseJob(releaseCtx, job.ID); err != nil {
+			return true, err
+		}
+	default:
+		if failErr := w.backfillStorage.FailJob(ctx, job.ID, err.Error()); failErr != nil {
+			return true, failErr
+		}
+		return true, fmt.Errorf("backfill job %s failed: %w", job.ID, err)
+	}
+
+	return true, nil
+}
+
+// executeJob walks the job's block range in chunks, resuming after current_block.
+// Redecode jobs read stored raw logs instead of fetching them.
+func (w *BackfillWorker) executeJob(ctx context.Context, job *models.BackfillJob) error {
+	client, ok := w.clients[job.ChainID]
+	if !ok {
+		return fmt.Errorf("no RPC client configured for chain %d", job.ChainID)
+	}
+
+	contract, err := w.contractStorage.GetContract(ctx, job.ChainID, job.ContractAddress)
+	if err != nil {
+		return fmt.Errorf("failed to load contract: %w", err)
+	}
+
+	// Each log is decoded with the ABI version in effect at its block.
+	// Upgrades are only detected by the live indexer, so versions still
+	// pending stay out of the backfill.
+	versions, err := w.contractStorage.GetABIVersions(ctx, job.ChainID, job.ContractAddress)
+	if err != nil {
+		return err
+	}
+	cp, err := newContractParser(contract, versions, w.logger)
+	if err != nil {
+		return err
+	}
+	eventParser := cp.eventParser
+
+	if job.Kind == models.BackfillKindRedecode {
+		return w.executeRedecodeJob(ctx, job, contract, eventParser)
+	}
+
+	// Children created in the backfilled blocks are registered from their
+	// creation block and caught up by the live indexer
+	factories, err := w.contractStorage.GetFactories(ctx, job.ChainID, job.ContractAddress)
+	if err != nil {
+		return err
+	}
+
+	// Backfills honour the contract's current event filter, which is how
+	// events allowed by a widened filter are picked up for older blocks
+	topics, err := eventParser.FilterTopics(contract.Filter, cp.pendingParsers()...)
+	if err != nil {
+		return fmt.Errorf("invalid event filter: %w", err)
+	}
+
+	chunkSize := int64(job.ChunkSize)
+	if chunkSize <= 0 {
+		chunkSize = 1000
+	}
+
+	// Backfilled events are stored as confirmed without a reorg check, so
+	// blocks without enough confirmations are left to the live indexer
+	latestBlock, err := client.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	fromBlock := resumeBlock(job)
+	toBlock := backfillEnd(job, latestBlock-int64(contract.ConfirmBlocks))
+
+	w.logger.WithFields(map[string]interface{}{
+		"job_id":     job.ID,
+		"chain_id":   job.ChainID,
+		"contract":   job.ContractAddress,
+		"from_block": fromBlock,
+		"to_block":   toBlock,
+		"chunk_size": chunkSize,
+	}).Info("Executing backfill job")
+	if toBlock < job.ToBlock {
+		w.logger.WithFields(map[string]interface{}{
+			"job_id":          job.ID,
+			"requested_to":    job.ToBlock,
+			"confirmed_block": toBlock,
+		}).Info("Backfill stops at the confirmed head; later blocks are left to the live indexer")
+	}
+
+	for start := fromBlock; start <= toBlock; start += chunkSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := start + chunkSize - 1
+		if end > toBlock {
+			end = toBlock
+		}
+
+		if err := w.processChunk(ctx, client, w.headers[job.ChainID], contract, eventParser, factories, topics, start, end); err != nil {
+			return fmt.Errorf("blocks %d-%d: %w", start, end, err)
+		}
+
+		running, err := w.backfillStorage.UpdateProgress(ctx, job.ID, end)
+		if err != nil {
+			return err
+		}
+		if !running {
+			return errBackfillStopped
+		}
+	}
+
+	return nil
+}
+
+// resumeBlock returns the first block a job still has to process. Jobs are
+// queued with current_block one below from_block, so a job starting at the
+// genesis block resumes from block 0 rather than treating it as done.
+func resumeBlock(job *models.BackfillJob) int64 {
+	if job.CurrentBlock >= job.FromBlock {
+		return job.CurrentBlock + 1
+	}
+	return job.FromBlock
+}
+
+// backfillEnd returns the last block a job backfills: its to_block, or the
+// highest confirmed block if that is lower
+func backfillEnd(job *models.BackfillJob, confirmedBlock int64) int64 {
+	if confirmedBlock < job.ToBlock {
+		return confirmedBlock
+	}
+	return job.ToBlock
+}
+
+// processChunk fetches, parses and stores the events for a single block range
+func (w *BackfillWorker) processChunk(
+	ctx context.Context,
+	client blockchain.ChainClient,
+	headers *blockchain.HeaderCache,
+	contract *models.Contract,
+	eventParser *parser.EventParser,
+	factories []*models.ContractFactory,
+	topics [][][]common.Hash,
+	fromBlock, toBlock int64,
+) error {
+	var (
+		events  []*models.Event
+		unknown []*models.UnknownLog
+	)
+
+	err := w.classifier.ExecuteWithRetry(ctx, "backfill_chunk", func() error {
+		// Dense contracts can exceed the provider's log limit for a chunk, in
+		// which case the chunk is fetched in smaller pieces
+		logs, _, err := blockchain.GetFilteredLogs(
+			ctx,
+			client,
+			[]common.Address{common.HexToAddress(string(contract.Address))},
+			topics,
+			fromBlock,
+			toBlock,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to get logs: %w", err)
+		}
+
+		if len(logs) == 0 {
+			events, unknown = nil, nil
+			return nil
+		}
+
+		blockTimestamps, err := headers.TimestampsForLogs(ctx, logs)
+		if err != nil {
+			return fmt.Errorf("failed to get block timestamps: %w", err)
+		}
+
+		events, unknown, err = eventParser.ParseLogs(logs, blockTimestamps)
+		if err != nil {
+			return fmt.Errorf("failed to parse logs: %w", err)
+		}
+		for _, event := range events {
+			event.ChainID = contract.ChainID
+		}
+		for _, log := range unknown {
+			log.ChainID = contract.ChainID
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(events) > 0 {
+		// Inserts are idempotent, so re-running a chunk after a crash is safe
+		if _, err := w.eventStorage.InsertEvents(ctx, events); err != nil {
+			return fmt.Errorf("failed to insert events: %w", err)
+		}
+		// Logs kept as unknown by an earlier run may decode with the current ABI
+		if _, err := w.eventStorage.DeleteDecodedUnknownLogs(ctx, events); err != nil {
+			return fmt.Errorf("failed to delete decoded unknown logs: %w", err)
+		}
+		if _, err := registerChildren(ctx, w.contractStorage, factories, events, w.logger); err != nil {
+			return fmt.Errorf("failed to register factory children: %w", err)
+		}
+	}
+	if err := w.eventStorage.InsertUnknownLogs(ctx, unknown); err != nil {
+		return fmt.Errorf("failed to store unknown logs: %w", err)
+	}
+
+	w.logger.WithFields(map[string]interface{}{
+		"chain_id":     contract.ChainID,
+		"contract":     contract.Address,
+		"from_block":   fromBlock,
+		"to_block":     toBlock,
+		"events_found": len(events),
+		"unknown_logs": len(unknown),
+	}).Debug("Backfill chunk processed")
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+func TestResumeBlock(t *testing.T) {
+	tests := []struct {
+		name                   string
+		from, current, resumed int64
+	}{
+		{name: "queued at genesis", from: 0, current: -1, resumed: 0},
+		{name: "genesis block done", from: 0, current: 0, resumed: 1},
+		{name: "queued", from: 500, current: 499, resumed: 500},
+		{name: "partly done", from: 500, current: 1499, resumed: 1500},
+		{name: "queued before the cursor migration", from: 500, current: 0, resumed: 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &models.BackfillJob{FromBlock: tt.from, ToBlock: 5000, CurrentBlock: tt.current}
+			if got := resumeBlock(job); got != tt.resumed {
+				t.Errorf("resumeBlock(from=%d, current=%d) = %d, want %d", tt.from, tt.current, got, tt.resumed)
+			}
+		})
+	}
+}
+
+func TestBackfillEnd(t *testing.T) {
+	tests := []struct {
+		name               string
+		toBlock, confirmed int64
+		want               int64
+	}{
+		{name: "range fully confirmed", toBlock: 5000, confirmed: 9000, want: 5000},
+		{name: "range reaches the confirmed head", toBlock: 5000, confirmed: 5000, want: 5000},
+		{name: "range runs past the confirmed head", toBlock: 5000, confirmed: 4990, want: 4990},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &models.BackfillJob{FromBlock: 1000, ToBlock: tt.toBlock}
+			if got := backfillEnd(job, tt.confirmed); got != tt.want {
+				t.Errorf("backfillEnd(to=%d, confirmed=%d) = %d, want %d", tt.toBlock, tt.confirmed, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package indexer
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// maxAddressesPerLogQuery caps how many contracts share one eth_getLogs call
+const maxAddressesPerLogQuery = 100
+
+// logRange is a block range whose logs are fetched with a single eth_getLogs
+// call covering every contract in it (one call per topic set when the
+// contracts filter their events). A contract whose cursor is already past
+// fromBlock only indexes the part of the range after its own cursor.
+type logRange struct {
+	fromBlock int64
+	toBlock   int64
+	contracts []*models.Contract
+	topics    [][][]common.Hash
+	filters   map[models.Address]logFilter
+}
+
+// addresses returns the addresses of the contracts in the range
+func (r *logRange) addresses() []common.Address {
+	addresses := make([]common.Address, len(r.contracts))
+	for k, contract := range r.contracts {
+		addresses[k] = contract.Address.ToCommonAddress()
+	}
+	return addresses
+}
+
+// planLogRanges groups contracts whose next block to index falls in the same
+// window, so each window costs one eth_getLogs call no matter how many
+// contracts it covers. Windows start at the lowest cursor not yet covered and
+// take in every contract whose next block lies inside them, up to maxAddresses
+// per window. A window spans at most sizeOf blocks for each of its contracts.
+// Contracts catching up therefore get their own windows while those at the
+// head share one, and every cursor still advances on its own. Contracts with
+// no new blocks up to latestBlock are left out.
+func planLogRanges(contracts []*models.Contract, latestBlock int64, sizeOf func(*models.Contract) int64, maxAddresses int) []*logRange {
+	if maxAddresses < 1 {
+		maxAddresses = 1
+	}
+
+	pending := make([]*models.Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		if contract.CurrentBlock < latestBlock {
+			pending = append(pending, contract)
+		}
+	}
+	sort.SliceStable(pending, func(a, b int) bool {
+		return pending[a].CurrentBlock < pending[b].CurrentBlock
+	})
+
+	var (
+		ranges  []*logRange
+		current *logRange
+	)
+	for _, contract := range pending {
+		fromBlock := contract.CurrentBlock + 1
+		if current != nil && len(current.contracts) < maxAddresses {
+			// A contract joining the window may narrow it to its own size
+			toBlock := current.toBlock
+			if limit := current.fromBlock + sizeOf(contract) - 1; limit < toBlock {
+				toBlock = limit
+			}
+			if fromBlock <= toBlock {
+				current.toBlock = toBlock
+				current.contracts = append(current.contracts, contract)
+				continue
+			}
+		}
+
+		toBlock := fromBlock + sizeOf(contract) - 1
+		if toBlock > latestBlock {
+			toBlock = latestBlock
+		}
+		current = &logRange{fromBlock: fromBlock, toBlock: toBlock, contracts: []*models.Contract{contract}}
+		ranges = append(ranges, current)
+	}
+
+	return ranges
+}
+
+// routeLogs splits the logs of a multi-address query by emitting contract
+func routeLogs(logs []types.Log) map[common.Address][]types.Log {
+	routed := make(map[common.Address][]types.Log)
+	for _, log := range logs {
+		routed[log.Address] = append(routed[log.Address], log)
+	}
+	return routed
+}
+
+// logsAfter returns the logs emitted after block, keeping their order
+func logsAfter(logs []types.Log, block int64) []types.Log {
+	filtered := logs[:0:0]
+	for _, log := range logs {
+		if int64(log.BlockNumber) > block {
+			filtered = append(filtered, log)
+		}
+	}
+	return filtered
+}
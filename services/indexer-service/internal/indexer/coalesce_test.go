@@ -0,0 +1,183 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+func contractAt(address string, currentBlock int64) *models.Contract {
+	return &models.Contract{
+		Address:      models.Address(address),
+		CurrentBlock: currentBlock,
+		IsActive:     true,
+	}
+}
+
+func fixedSize(size int64) func(*models.Contract) int64 {
+	return func(*models.Contract) int64 { return size }
+}
+
+func rangeAddresses(r *logRange) []models.Address {
+	addresses := make([]models.Address, len(r.contracts))
+	for k, contract := range r.contracts {
+		addresses[k] = contract.Address
+	}
+	return addresses
+}
+
+func TestPlanLogRanges(t *testing.T) {
+	const (
+		a = "0x000000000000000000000000000000000000000a"
+		b = "0x000000000000000000000000000000000000000b"
+		c = "0x000000000000000000000000000000000000000c"
+		d = "0x000000000000000000000000000000000000000d"
+		e = "0x000000000000000000000000000000000000000e"
+	)
+
+	tests := []struct {
+		name      string
+		contracts []*models.Contract
+		latest    int64
+		maxAddrs  int
+		want      [][3]int64 // fromBlock, toBlock, contract count
+	}{
+		{
+			name:      "contracts at the head share one query",
+			contracts: []*models.Contract{contractAt(a, 995), contractAt(b, 995), contractAt(c, 995)},
+			latest:    1000,
+			maxAddrs:  100,
+			want:      [][3]int64{{996, 1000, 3}},
+		},
+		{
+			name:      "a contract catching up gets its own range",
+			contracts: []*models.Contract{contractAt(a, 995), contractAt(b, 100), contractAt(c, 995)},
+			latest:    1000,
+			maxAddrs:  100,
+			want:      [][3]int64{{101, 110, 1}, {996, 1000, 2}},
+		},
+		{
+			name:      "contracts with cursors inside one window are merged",
+			contracts: []*models.Contract{contractAt(a, 104), contractAt(b, 100), contractAt(c, 111)},
+			latest:    1000,
+			maxAddrs:  100,
+			want:      [][3]int64{{101, 110, 2}, {112, 121, 1}},
+		},
+		{
+			name:      "contracts with no new blocks are left out",
+			contracts: []*models.Contract{contractAt(a, 1000), contractAt(b, 999)},
+			latest:    1000,
+			maxAddrs:  100,
+			want:      [][3]int64{{1000, 1000, 1}},
+		},
+		{
+			name: "large windows are split by address count",
+			contracts: []*models.Contract{
+				contractAt(a, 500), contractAt(b, 500), contractAt(c, 500), contractAt(d, 500), contractAt(e, 500),
+			},
+			latest:   1000,
+			maxAddrs: 2,
+			want:     [][3]int64{{501, 510, 2}, {501, 510, 2}, {501, 510, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges := planLogRanges(tt.contracts, tt.latest, fixedSize(10), tt.maxAddrs)
+			if len(ranges) != len(tt.want) {
+				t.Fatalf("planned %d ranges, want %d", len(ranges), len(tt.want))
+			}
+			for k, r := range ranges {
+				want := tt.want[k]
+				if r.fromBlock != want[0] || r.toBlock != want[1] || int64(len(r.contracts)) != want[2] {
+					t.Errorf("range %d = %d-%d with %v, want %d-%d with %d contracts",
+						k, r.fromBlock, r.toBlock, rangeAddresses(r), want[0], want[1], want[2])
+				}
+			}
+		})
+	}
+}
+
+func TestPlanLogRanges_LearnedSizes(t *testing.T) {
+	dense := contractAt("0x000000000000000000000000000000000000000a", 100)
+	sparse := contractAt("0x000000000000000000000000000000000000000b", 102)
+	late := contractAt("0x000000000000000000000000000000000000000c", 110)
+	sizes := map[models.Address]int64{dense.Address: 5, sparse.Address: 100, late.Address: 100}
+	sizeOf := func(contract *models.Contract) int64 { return sizes[contract.Address] }
+
+	// The dense contract's learned size caps the window it shares with the
+	// sparse one; the late contract falls outside it
+	ranges := planLogRanges([]*models.Contract{sparse, late, dense}, 1000, sizeOf, 100)
+	if len(ranges) != 2 {
+		t.Fatalf("planned %d ranges, want 2", len(ranges))
+	}
+	if r := ranges[0]; r.fromBlock != 101 || r.toBlock != 105 || len(r.contracts) != 2 {
+		t.Errorf("first range = %d-%d with %v, want 101-105 with the dense and sparse contracts", r.fromBlock, r.toBlock, rangeAddresses(r))
+	}
+	if r := ranges[1]; r.fromBlock != 111 || r.toBlock != 210 {
+		t.Errorf("second range = %d-%d, want 111-210", r.fromBlock, r.toBlock)
+	}
+
+	// A contract joining with a smaller size narrows the window it joins
+	sizes[sparse.Address] = 5
+	sizes[dense.Address] = 100
+	ranges = planLogRanges([]*models.Contract{sparse, dense}, 1000, sizeOf, 100)
+	if len(ranges) != 1 || ranges[0].toBlock != 105 {
+		t.Errorf("planned %d ranges ending at %d, want one ending at 105", len(ranges), ranges[0].toBlock)
+	}
+}
+
+func TestPlanLogRanges_CursorsAdvanceIndependently(t *testing.T) {
+	// One contract at the head, one catching up from far behind
+	head := contractAt("0x000000000000000000000000000000000000000a", 990)
+	behind := contractAt("0x000000000000000000000000000000000000000b", 900)
+
+	latest := int64(1000)
+	for poll := 0; poll < 20; poll++ {
+		for _, r := range planLogRanges([]*models.Contract{head, behind}, latest, fixedSize(10), 100) {
+			for _, contract := range r.contracts {
+				if contract.CurrentBlock >= r.toBlock {
+					t.Fatalf("contract %s at %d planned for range %d-%d", contract.Address, contract.CurrentBlock, r.fromBlock, r.toBlock)
+				}
+				contract.CurrentBlock = r.toBlock
+			}
+		}
+		latest++
+	}
+
+	if head.CurrentBlock != latest-1 || behind.CurrentBlock != latest-1 {
+		t.Errorf("cursors = %d, %d; want both at the head %d", head.CurrentBlock, behind.CurrentBlock, latest-1)
+	}
+}
+
+func TestRouteLogs(t *testing.T) {
+	a := common.HexToAddress("0x000000000000000000000000000000000000000a")
+	b := common.HexToAddress("0x000000000000000000000000000000000000000b")
+	logs := []types.Log{
+		{Address: a, BlockNumber: 101, Index: 0},
+		{Address: b, BlockNumber: 101, Index: 1},
+		{Address: a, BlockNumber: 105, Index: 0},
+		{Address: a, BlockNumber: 108, Index: 3},
+	}
+
+	routed := routeLogs(logs)
+	if len(routed[a]) != 3 || len(routed[b]) != 1 {
+		t.Fatalf("routed %d logs to a and %d to b, want 3 and 1", len(routed[a]), len(routed[b]))
+	}
+	for k := 1; k < len(routed[a]); k++ {
+		if routed[a][k].BlockNumber < routed[a][k-1].BlockNumber {
+			t.Errorf("logs for a out of order: %d after %d", routed[a][k].BlockNumber, routed[a][k-1].BlockNumber)
+		}
+	}
+
+	// A contract whose cursor is at 104 only indexes the logs after it
+	after := logsAfter(routed[a], 104)
+	if len(after) != 2 || after[0].BlockNumber != 105 || after[1].BlockNumber != 108 {
+		t.Errorf("logsAfter(104) = %+v, want the logs of blocks 105 and 108", after)
+	}
+	if len(routed[a]) != 3 {
+		t.Error("logsAfter modified the routed logs")
+	}
+}
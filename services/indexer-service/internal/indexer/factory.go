@@ -0,0 +1,78 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smart-contract-event-indexer/indexer-service/internal/storage"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// childContract is a contract a factory created, to be indexed from the block
+// of its creation event
+type childContract struct {
+	factory    *models.ContractFactory
+	address    models.Address
+	startBlock int64
+}
+
+// childContracts returns the contracts created by the factory events among
+// events, in event order. An address created more than once keeps its first
+// creation.
+func childContracts(factories []*models.ContractFactory, events []*models.Event) []childContract {
+	if len(factories) == 0 {
+		return nil
+	}
+
+	var (
+		children []childContract
+		seen     = make(map[models.Address]bool)
+	)
+	for _, event := range events {
+		for _, factory := range factories {
+			address, ok := factory.ChildAddress(event)
+			if !ok || seen[address] {
+				continue
+			}
+			seen[address] = true
+			children = append(children, childContract{
+				factory:    factory,
+				address:    address,
+				startBlock: event.BlockNumber,
+			})
+		}
+	}
+	return children
+}
+
+// registerChildren adds the contracts created by the factory events among
+// events and returns how many were new. Contracts already monitored are left
+// as they are, so indexing the same events again registers nothing.
+func registerChildren(
+	ctx context.Context,
+	contractStorage *storage.ContractStorage,
+	factories []*models.ContractFactory,
+	events []*models.Event,
+	logger utils.Logger,
+) (int, error) {
+	registered := 0
+	for _, child := range childContracts(factories, events) {
+		added, err := contractStorage.RegisterChildContract(ctx, child.factory, child.address, child.startBlock)
+		if err != nil {
+			return registered, fmt.Errorf("failed to register child %s: %w", child.address, err)
+		}
+		if added {
+			registered++
+		}
+	}
+
+	if registered > 0 {
+		logger.WithFields(map[string]interface{}{
+			"factory":  factories[0].ContractAddress,
+			"children": registered,
+		}).Info("Factory children discovered")
+	}
+
+	return registered, nil
+}
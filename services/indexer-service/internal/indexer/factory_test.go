@@ -0,0 +1,57 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+func TestChildContracts(t *testing.T) {
+	factory := &models.ContractFactory{
+		ID:         1,
+		EventName:  "PairCreated",
+		AddressArg: "pair",
+		FromBlock:  100,
+	}
+	pair := "0x742d35cc6634c0532925a3b844bc9e7595f0beb0"
+	other := "0x8ba1f109551bD432803012645Ac136ddd64DBA72"
+
+	events := []*models.Event{
+		// Before the rule's first block
+		{EventName: "PairCreated", BlockNumber: 99, Args: models.JSONB{"pair": other}},
+		{EventName: "Sync", BlockNumber: 100, Args: models.JSONB{"pair": other}},
+		{EventName: "PairCreated", BlockNumber: 101, Args: models.JSONB{"pair": pair}},
+		// Created again in a later event: the first creation wins
+		{EventName: "PairCreated", BlockNumber: 105, Args: models.JSONB{"pair": pair}},
+		{EventName: "PairCreated", BlockNumber: 106, Args: models.JSONB{"pair": "not an address"}},
+		{EventName: "PairCreated", BlockNumber: 107, Args: models.JSONB{"token0": other}},
+		{EventName: "PairCreated", BlockNumber: 108, Args: models.JSONB{"pair": other}},
+	}
+
+	children := childContracts([]*models.ContractFactory{factory}, events)
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d: %+v", len(children), children)
+	}
+
+	if children[0].address != models.Address(pair).Normalize() {
+		t.Errorf("expected the checksummed pair address, got %s", children[0].address)
+	}
+	if children[0].startBlock != 101 {
+		t.Errorf("expected the pair to start at its creation block 101, got %d", children[0].startBlock)
+	}
+	if children[0].factory != factory {
+		t.Error("expected the child to keep the rule that found it")
+	}
+	if children[1].address != models.Address(other).Normalize() || children[1].startBlock != 108 {
+		t.Errorf("unexpected second child %+v", children[1])
+	}
+}
+
+func TestChildContracts_NoFactories(t *testing.T) {
+	events := []*models.Event{
+		{EventName: "PairCreated", BlockNumber: 1, Args: models.JSONB{"pair": "0x742d35cc6634c0532925a3b844bc9e7595f0beb0"}},
+	}
+	if children := childContracts(nil, events); children != nil {
+		t.Errorf("expected no children without factory rules, got %+v", children)
+	}
+}
@@ -0,0 +1,128 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/parser"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// logFilter is a contract's event filter compiled against its ABIs into the
+// topic conditions of the eth_getLogs queries that fetch its logs. No topic
+// sets means every log is fetched. While the contract waits for a proxy
+// upgrade, queries also fetch its Upgraded events, which are only indexed if
+// the filter itself allows them.
+type logFilter struct {
+	key     string
+	topics  [][][]common.Hash
+	queries [][][]common.Hash
+}
+
+// newLogFilter wraps compiled topic sets with a key that is equal for equal
+// queries, so contracts fetching the same way can share a query
+func newLogFilter(topics [][][]common.Hash, watchUpgrades bool) logFilter {
+	queries := topics
+	if watchUpgrades && len(topics) > 0 {
+		queries = append(topics[:len(topics):len(topics)], [][]common.Hash{{parser.UpgradedEventID}})
+	}
+
+	var key strings.Builder
+	for _, set := range queries {
+		key.WriteString("|")
+		for _, position := range set {
+			key.WriteString("/")
+			for _, topic := range position {
+				key.WriteString(topic.Hex())
+				key.WriteString(",")
+			}
+		}
+	}
+	return logFilter{key: key.String(), topics: topics, queries: queries}
+}
+
+// allowedLogs returns the logs the contract's own filter accepts, keeping
+// their order
+func (f logFilter) allowedLogs(logs []types.Log) []types.Log {
+	if len(f.topics) == 0 {
+		return logs
+	}
+	allowed := logs[:0:0]
+	for _, log := range logs {
+		for _, set := range f.topics {
+			if matchesTopicSet(log, set) {
+				allowed = append(allowed, log)
+				break
+			}
+		}
+	}
+	return allowed
+}
+
+// matchesTopicSet applies the eth_getLogs topic rules: every non-empty
+// position must hold one of its values
+func matchesTopicSet(log types.Log, set [][]common.Hash) bool {
+	for position, accepted := range set {
+		if len(accepted) == 0 {
+			continue
+		}
+		if position >= len(log.Topics) {
+			return false
+		}
+		found := false
+		for _, topic := range accepted {
+			if log.Topics[position] == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// planFilteredLogRanges plans the log ranges of contracts like planLogRanges,
+// but only groups contracts whose filters are the same: one eth_getLogs call
+// carries one set of topics for all its addresses. Contracts missing from
+// filters are left out. Ranges are returned in block order.
+func planFilteredLogRanges(
+	contracts []*models.Contract,
+	filters map[models.Address]logFilter,
+	latestBlock int64,
+	sizeOf func(*models.Contract) int64,
+	maxAddresses int,
+) []*logRange {
+	var (
+		keys   []string
+		groups = make(map[string][]*models.Contract)
+	)
+	for _, contract := range contracts {
+		filter, ok := filters[contract.Address]
+		if !ok {
+			continue
+		}
+		if _, seen := groups[filter.key]; !seen {
+			keys = append(keys, filter.key)
+		}
+		groups[filter.key] = append(groups[filter.key], contract)
+	}
+
+	var ranges []*logRange
+	for _, key := range keys {
+		group := groups[key]
+		queries := filters[group[0].Address].queries
+		for _, r := range planLogRanges(group, latestBlock, sizeOf, maxAddresses) {
+			r.topics = queries
+			r.filters = filters
+			ranges = append(ranges, r)
+		}
+	}
+	sort.SliceStable(ranges, func(a, b int) bool {
+		return ranges[a].fromBlock < ranges[b].fromBlock
+	})
+	return ranges
+}
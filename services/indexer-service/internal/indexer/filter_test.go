@@ -0,0 +1,97 @@
+package indexer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/parser"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+func TestNewLogFilter_Key(t *testing.T) {
+	transfer := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	holder := common.HexToHash("0x742d35cc6634c0532925a3b844bc9e7595f0beb0")
+
+	everything := newLogFilter(nil, false)
+	transfers := newLogFilter([][][]common.Hash{{{transfer}}}, false)
+	fromHolder := newLogFilter([][][]common.Hash{{{transfer}, {holder}}}, false)
+	toHolder := newLogFilter([][][]common.Hash{{{transfer}, nil, {holder}}}, false)
+
+	keys := []string{everything.key, transfers.key, fromHolder.key, toHolder.key}
+	for a := range keys {
+		for b := a + 1; b < len(keys); b++ {
+			if keys[a] == keys[b] {
+				t.Errorf("filters %d and %d share key %q", a, b, keys[a])
+			}
+		}
+	}
+	if again := newLogFilter([][][]common.Hash{{{transfer}, {holder}}}, false); again.key != fromHolder.key {
+		t.Errorf("equal filters got keys %q and %q", again.key, fromHolder.key)
+	}
+}
+
+func TestPlanFilteredLogRanges(t *testing.T) {
+	const (
+		a = "0x000000000000000000000000000000000000000a"
+		b = "0x000000000000000000000000000000000000000b"
+		c = "0x000000000000000000000000000000000000000c"
+		d = "0x000000000000000000000000000000000000000d"
+	)
+	transfers := newLogFilter([][][]common.Hash{{{common.HexToHash("0x01")}}}, false)
+	everything := newLogFilter(nil, false)
+
+	contracts := []*models.Contract{contractAt(a, 100), contractAt(b, 100), contractAt(c, 100), contractAt(d, 100)}
+	filters := map[models.Address]logFilter{
+		a: everything,
+		b: transfers,
+		c: everything,
+		// d's filter did not compile
+	}
+
+	ranges := planFilteredLogRanges(contracts, filters, 150, fixedSize(1000), maxAddressesPerLogQuery)
+	if len(ranges) != 2 {
+		t.Fatalf("got %d ranges, want 2", len(ranges))
+	}
+	if got := rangeAddresses(ranges[0]); !reflect.DeepEqual(got, []models.Address{a, c}) {
+		t.Errorf("first range covers %v, want [a c]", got)
+	}
+	if ranges[0].topics != nil {
+		t.Errorf("first range has topics %v, want none", ranges[0].topics)
+	}
+	if got := rangeAddresses(ranges[1]); !reflect.DeepEqual(got, []models.Address{b}) {
+		t.Errorf("second range covers %v, want [b]", got)
+	}
+	if !reflect.DeepEqual(ranges[1].topics, transfers.queries) {
+		t.Errorf("second range has topics %v, want %v", ranges[1].topics, transfers.queries)
+	}
+}
+
+func TestNewLogFilter_WatchUpgrades(t *testing.T) {
+	transfer := common.HexToHash("0x01")
+	implementation := common.HexToHash("0x0b")
+
+	// Every log is fetched anyway
+	if all := newLogFilter(nil, true); all.queries != nil {
+		t.Errorf("unfiltered contract queries %v, want every log", all.queries)
+	}
+
+	filter := newLogFilter([][][]common.Hash{{{transfer}}}, true)
+	if len(filter.queries) != 2 || filter.queries[1][0][0] != parser.UpgradedEventID {
+		t.Fatalf("queries = %v, want the filter plus Upgraded", filter.queries)
+	}
+	if filter.key == newLogFilter(filter.topics, false).key {
+		t.Error("watching and non-watching filters share a key")
+	}
+
+	logs := []types.Log{
+		{BlockNumber: 1, Topics: []common.Hash{transfer}},
+		{BlockNumber: 2, Topics: []common.Hash{parser.UpgradedEventID, implementation}},
+		{BlockNumber: 3, Topics: []common.Hash{transfer}},
+	}
+	allowed := filter.allowedLogs(logs)
+	if len(allowed) != 2 || allowed[0].BlockNumber != 1 || allowed[1].BlockNumber != 3 {
+		t.Errorf("allowed %v, want the Transfer logs only", allowed)
+	}
+}
@@ -0,0 +1,774 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/blockchain"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/reorg"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/storage"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/pubsub"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// chainReorgError is returned by processContract when the range it was about
+// to index no longer extends the chain indexed so far. The rollback is left to
+// processAllContracts, which runs it once every contract has stopped.
+type chainReorgError struct {
+	forkPoint  int64
+	detectedAt int64
+}
+
+func (e *chainReorgError) Error() string {
+	return fmt.Sprintf("chain reorganized at block %d (detected at %d)", e.forkPoint, e.detectedAt)
+}
+
+// Indexer is the main orchestrator for blockchain event indexing.
+// Each Indexer follows a single chain; run one per configured chain.
+type Indexer struct {
+	chainID         int64
+	client          blockchain.ChainClient
+	contractStorage *storage.ContractStorage
+	eventStorage    *storage.EventStorage
+	stateStorage    *storage.StateStorage
+	reorgDetector   *reorg.Detector
+	reorgHandler    *reorg.Handler
+	publisher       *pubsub.EventPublisher
+	webhooks        *WebhookDispatcher
+	headers         *blockchain.HeaderCache
+	confirmations   *ConfirmationChecker
+	pollInterval    time.Duration
+	batchSize       int
+	maxConcurrent   int
+	contractTimeout time.Duration
+	rangeSizes      *rangeSizes
+	logger          utils.Logger
+	
+	// Contract-specific parsers, and those of topic subscriptions by ID
+	parsersMu           sync.RWMutex
+	parsers             map[models.Address]*contractParser
+	subscriptionParsers map[int64]*subscriptionParser
+}
+
+// NewIndexer creates a new indexer
+func NewIndexer(
+	chainID int64,
+	client blockchain.ChainClient,
+	contractStorage *storage.ContractStorage,
+	eventStorage *storage.EventStorage,
+	stateStorage *storage.StateStorage,
+	reorgDetector *reorg.Detector,
+	reorgHandler *reorg.Handler,
+	publisher *pubsub.EventPublisher,
+	webhooks *WebhookDispatcher,
+	pollInterval time.Duration,
+	batchSize int,
+	maxConcurrent int,
+	contractTimeout time.Duration,
+	logger utils.Logger,
+) *Indexer {
+	if maxConcurrent < 1 {
+		maxConcurrent = DefaultMaxConcurrentContracts
+	}
+	if contractTimeout <= 0 {
+		contractTimeout = DefaultContractTimeout
+	}
+	return &Indexer{
+		chainID:             chainID,
+		client:              client,
+		contractStorage:     contractStorage,
+		eventStorage:        eventStorage,
+		stateStorage:        stateStorage,
+		reorgDetector:       reorgDetector,
+		reorgHandler:        reorgHandler,
+		publisher:           publisher,
+		webhooks:            webhooks,
+		headers:             blockchain.NewHeaderCache(client, blockchain.DefaultHeaderCacheSize),
+		confirmations:       NewConfirmationChecker(logger),
+		pollInterval:        pollInterval,
+		batchSize:           batchSize,
+		maxConcurrent:       maxConcurrent,
+		contractTimeout:     contractTimeout,
+		rangeSizes:          newRangeSizes(batchSize),
+		logger:              logger.WithField("chain_id", chainID),
+		parsers:             make(map[models.Address]*contractParser),
+		subscriptionParsers: make(map[int64]*subscriptionParser),
+	}
+}
+
+// Start begins the indexing process
+func (i *Indexer) Start(ctx context.Context) error {
+	i.logger.Info("Starting indexer")
+	
+	// Load all contracts to monitor on this chain
+	contracts, err := i.contractStorage.GetContractsByChain(ctx, i.chainID)
+	if err != nil {
+		return fmt.Errorf("failed to load contracts: %w", err)
+	}
+	
+	if len(contracts) == 0 {
+		i.logger.Warn("No contracts to monitor. Add contracts via the admin API.")
+	} else {
+		i.logger.WithField("contract_count", len(contracts)).Info("Loaded contracts to monitor")
+	}
+	
+	// Initialize parsers for all contracts
+	if err := i.initializeParsers(ctx, contracts); err != nil {
+		return fmt.Errorf("failed to initialize parsers: %w", err)
+	}
+	
+	// Start the main indexing loop
+	ticker := time.NewTicker(i.pollInterval)
+	defer ticker.Stop()
+	
+	i.logger.WithField("poll_interval", i.pollInterval).Info("Indexer main loop started")
+	
+	for {
+		select {
+		case <-ctx.Done():
+			i.logger.Info("Indexer stopping")
+			return ctx.Err()
+			
+		case <-ticker.C:
+			if err := i.processAllContracts(ctx); err != nil {
+				i.logger.WithError(err).Error("Error processing contracts")
+				// Continue despite errors
+			}
+		}
+	}
+}
+
+// initializeParsers creates event parsers for all contracts
+func (i *Indexer) initializeParsers(ctx context.Context, contracts []*models.Contract) error {
+	versions, err := i.contractStorage.GetABIVersionsByChain(ctx, i.chainID)
+	if err != nil {
+		return err
+	}
+	
+	for _, contract := range contracts {
+		if _, err := i.parserForContract(contract, versions[contract.Address]); err != nil {
+			i.logger.WithError(err).WithField("contract", contract.Address).Error("Failed to create parser")
+			continue
+		}
+	}
+	
+	return nil
+}
+
+// getParserForContract retrieves the parser for a contract
+func (i *Indexer) getParserForContract(address models.Address) *contractParser {
+	i.parsersMu.RLock()
+	defer i.parsersMu.RUnlock()
+	return i.parsers[address]
+}
+
+// compileLogFilters compiles the event filter of every contract against its
+// ABI versions, refreshing parsers whose versions changed. A contract whose
+// filter no longer fits its ABI is reported as failed and skipped until the
+// filter is fixed, rather than indexed with a wider filter than asked for.
+func (i *Indexer) compileLogFilters(
+	ctx context.Context,
+	contracts []*models.Contract,
+	versions map[models.Address][]*models.ABIVersion,
+) (map[models.Address]logFilter, map[models.Address]bool) {
+	filters := make(map[models.Address]logFilter, len(contracts))
+	failed := make(map[models.Address]bool)
+	for _, contract := range contracts {
+		cp, err := i.parserForContract(contract, versions[contract.Address])
+		if err != nil {
+			i.logger.WithError(err).WithField("contract", contract.Address).Error("Failed to create parser")
+			i.stateStorage.IncrementErrorCount(ctx, i.chainID, contract.Address, err.Error())
+			failed[contract.Address] = true
+			continue
+		}
+		
+		topics, err := cp.eventParser.FilterTopics(contract.Filter, cp.pendingParsers()...)
+		if err != nil {
+			i.logger.WithError(err).WithField("contract", contract.Address).Error("Invalid contract event filter")
+			i.stateStorage.IncrementErrorCount(ctx, i.chainID, contract.Address, fmt.Sprintf("invalid event filter: %v", err))
+			failed[contract.Address] = true
+			continue
+		}
+		filters[contract.Address] = newLogFilter(topics, cp.watchesUpgrades())
+	}
+	
+	return filters, failed
+}
+
+// processAllContracts processes all monitored contracts. Contracts whose
+// cursors sit in the same block window and whose event filters match share one
+// eth_getLogs call; windows are indexed concurrently, at most maxConcurrent at
+// a time, and a contract that fails or times out only records an error against
+// itself.
+func (i *Indexer) processAllContracts(ctx context.Context) error {
+	// Get latest block from blockchain
+	latestBlock, err := i.client.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	
+	// Get all contracts on this chain
+	contracts, err := i.contractStorage.GetContractsByChain(ctx, i.chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get contracts: %w", err)
+	}
+	
+	// Paused contracts pick up from current_block once resumed
+	active := make([]*models.Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		if contract.IsActive {
+			active = append(active, contract)
+		}
+	}
+	// Proxies may have new ABI versions since the last poll
+	versions, err := i.contractStorage.GetABIVersionsByChain(ctx, i.chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get ABI versions: %w", err)
+	}
+	// Factories register the contracts they create as their events are indexed
+	factories, err := i.contractStorage.GetFactoriesByChain(ctx, i.chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get contract factories: %w", err)
+	}
+	// Topic subscriptions follow their event across every emitter
+	subscriptions, err := i.stateStorage.GetSubscriptionsByChain(ctx, i.chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get topic subscriptions: %w", err)
+	}
+	filters, failed := i.compileLogFilters(ctx, active, versions)
+	ranges := planFilteredLogRanges(active, filters, latestBlock, i.rangeSizes.size, maxAddressesPerLogQuery)
+	
+	// A reorg or deep reorg seen in one range stops the rest of the poll
+	poolCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	
+	var (
+		mu                  sync.Mutex
+		reorged             *chainReorgError
+		deepErr             error
+		failedSubscriptions = make(map[int64]bool)
+	)
+	// stopPoll records a reorg or deep reorg seen by any worker and cancels
+	// the rest of the poll, reporting whether err was one
+	stopPoll := func(err error) bool {
+		var reorgErr *chainReorgError
+		switch {
+		case errors.As(err, &reorgErr):
+			mu.Lock()
+			if reorged == nil || reorgErr.forkPoint < reorged.forkPoint {
+				reorged = reorgErr
+			}
+			mu.Unlock()
+			cancel()
+			return true
+		case errors.Is(err, reorg.ErrDeepReorg):
+			// The fork point is unknown, so no contract on this chain can be
+			// indexed safely until an operator resolves the reorg
+			mu.Lock()
+			deepErr = err
+			mu.Unlock()
+			cancel()
+			return true
+		}
+		return false
+	}
+	recordFailure := func(contract *models.Contract, err error) {
+		mu.Lock()
+		failed[contract.Address] = true
+		mu.Unlock()
+		
+		if poolCtx.Err() != nil {
+			// Interrupted by shutdown or by a reorg found in another range;
+			// the range is retried on the next poll
+			return
+		}
+		
+		i.logger.WithError(err).WithFields(map[string]interface{}{
+			"contract": contract.Address,
+			"name":     contract.Name,
+		}).Error("Failed to process contract")
+		
+		// Record error but continue with other contracts. The range's own
+		// context may have timed out, so record it under the poll's context.
+		i.stateStorage.IncrementErrorCount(ctx, i.chainID, contract.Address, err.Error())
+	}
+	
+	runWorkers(poolCtx, ranges, i.maxConcurrent, i.contractTimeout, func(rangeCtx context.Context, r *logRange) {
+		contractErrs, err := i.processLogRange(rangeCtx, r, latestBlock, factories)
+		if stopPoll(err) {
+			return
+		}
+		if err != nil {
+			// Nothing in the range was indexed
+			for _, contract := range r.contracts {
+				recordFailure(contract, err)
+			}
+			return
+		}
+		
+		for _, contract := range r.contracts {
+			if err, ok := contractErrs[contract.Address]; ok {
+				recordFailure(contract, err)
+			}
+		}
+	})
+	
+	// Subscriptions check the same chain for reorgs, so one they find rolls
+	// back the contracts as well
+	runWorkers(poolCtx, subscriptions, i.maxConcurrent, i.contractTimeout, func(subscriptionCtx context.Context, subscription *models.TopicSubscription) {
+		err := i.processSubscription(subscriptionCtx, subscription, latestBlock)
+		if err == nil || stopPoll(err) {
+			return
+		}
+		
+		mu.Lock()
+		failedSubscriptions[subscription.ID] = true
+		mu.Unlock()
+		if poolCtx.Err() != nil {
+			return
+		}
+		
+		i.logger.WithError(err).WithField("subscription", subscription.Name).Error("Failed to process topic subscription")
+		i.stateStorage.IncrementSubscriptionErrorCount(ctx, subscription.ID, err.Error())
+	})
+	
+	if deepErr != nil {
+		return deepErr
+	}
+	if reorged != nil {
+		// Every worker has stopped, so no contract can write a checkpoint on
+		// the abandoned branch after the rollback. Contracts resume from their
+		// rewound blocks on the next poll.
+		if err := i.reorgHandler.HandleReorgForAllContracts(ctx, i.chainID, reorged.forkPoint, reorged.detectedAt); err != nil {
+			return fmt.Errorf("failed to handle reorg: %w", err)
+		}
+		return nil
+	}
+	
+	// Promote pending events of every contract that is up to date, including
+	// those with no new blocks this poll
+	confirmable := promotableContracts(contracts, failed)
+	runWorkers(poolCtx, confirmable, i.maxConcurrent, i.contractTimeout, func(contractCtx context.Context, contract *models.Contract) {
+		if err := i.promoteConfirmedEvents(contractCtx, contract, latestBlock); err != nil {
+			i.logger.WithError(err).WithField("contract", contract.Address).Warn("Failed to promote pending events")
+		}
+	})
+	for _, subscription := range subscriptions {
+		if failedSubscriptions[subscription.ID] {
+			continue
+		}
+		if err := i.promoteSubscriptionEvents(ctx, subscription, latestBlock); err != nil {
+			i.logger.WithError(err).WithField("subscription", subscription.Name).Warn("Failed to promote pending events")
+		}
+	}
+	
+	// Decode the unknown logs an operator asked to retry with the ABIs the
+	// parsers were just rebuilt from
+	if err := i.retryUnknownLogs(ctx, confirmable, latestBlock); err != nil {
+		i.logger.WithError(err).Warn("Failed to retry unknown logs")
+	}
+	
+	return nil
+}
+
+// processLogRange indexes one planned range. The range is checked for a reorg
+// and the logs of all its contracts are fetched with a single query, then each
+// contract indexes its own logs and advances its own cursor. A failure before
+// the logs are fetched is returned as the error; failures after that are
+// returned per contract and leave the other contracts' progress untouched.
+func (i *Indexer) processLogRange(
+	ctx context.Context,
+	r *logRange,
+	latestBlock int64,
+	factories map[models.Address][]*models.ContractFactory,
+) (map[models.Address]error, error) {
+	i.logger.WithFields(map[string]interface{}{
+		"contracts":  len(r.contracts),
+		"from_block": r.fromBlock,
+		"to_block":   r.toBlock,
+		"latest":     latestBlock,
+	}).Debug("Processing block range")
+	
+	// Make sure the range still extends the chain we indexed before
+	forkPoint, detectedAt, err := i.checkForReorg(ctx, r.fromBlock, r.toBlock, latestBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for reorg: %w", err)
+	}
+	if forkPoint > 0 {
+		return nil, &chainReorgError{forkPoint: forkPoint, detectedAt: detectedAt}
+	}
+	
+	// Fetch the logs of every contract in the range at once, splitting the
+	// range if the provider rejects it as too large
+	logs, accepted, err := blockchain.GetFilteredLogs(ctx, i.client, r.addresses(), r.topics, r.fromBlock, r.toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs: %w", err)
+	}
+	requested := r.toBlock - r.fromBlock + 1
+	if accepted < requested {
+		i.logger.WithFields(map[string]interface{}{
+			"from_block": r.fromBlock,
+			"to_block":   r.toBlock,
+			"accepted":   accepted,
+		}).Debug("Provider limited getLogs range, split the range")
+	}
+	routed := routeLogs(logs)
+	logCounts := make(map[models.Address]int, len(r.contracts))
+	for _, contract := range r.contracts {
+		logCounts[contract.Address] = len(routed[contract.Address.ToCommonAddress()])
+	}
+	i.rangeSizes.recordShared(r.contracts, requested, accepted, logCounts)
+	
+	var (
+		blockTimestamps map[common.Hash]time.Time
+		lastHash        models.Hash
+	)
+	if len(logs) > 0 {
+		// Resolve the timestamp of every block that emitted a log
+		blockTimestamps, err = i.headers.TimestampsForLogs(ctx, logs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block timestamps: %w", err)
+		}
+		
+		// Record the hash of the last block in the range
+		lastHeader, err := i.client.GetHeaderByNumber(ctx, r.toBlock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block header: %w", err)
+		}
+		lastHash = models.Hash(lastHeader.Hash().Hex())
+	}
+	
+	contractErrs := make(map[models.Address]error)
+	for _, contract := range r.contracts {
+		// Blocks up to the contract's cursor were indexed in an earlier poll
+		contractLogs := logsAfter(routed[contract.Address.ToCommonAddress()], contract.CurrentBlock)
+		if err := i.indexContractLogs(ctx, contract, r.filters[contract.Address], factories[contract.Address], r.toBlock, latestBlock, contractLogs, blockTimestamps, lastHash); err != nil {
+			contractErrs[contract.Address] = err
+		}
+	}
+	
+	return contractErrs, nil
+}
+
+// indexContractLogs stores the events in one contract's logs for the blocks
+// after its cursor up to toBlock and advances the cursor to toBlock. Blocks up
+// to the chain head are indexed; events in blocks without enough
+// confirmations are stored as pending and promoted later. Proxy upgrades among
+// the logs switch the contract's ABI version before the logs are decoded, and
+// contracts created by a factory's events are registered before the cursor
+// moves past them.
+func (i *Indexer) indexContractLogs(
+	ctx context.Context,
+	contract *models.Contract,
+	filter logFilter,
+	factories []*models.ContractFactory,
+	toBlock int64,
+	latestBlock int64,
+	logs []types.Log,
+	blockTimestamps map[common.Hash]time.Time,
+	lastHash models.Hash,
+) error {
+	fromBlock := contract.CurrentBlock + 1
+	
+	// Get the parser for this contract, built when its filter was compiled
+	cp := i.getParserForContract(contract.Address)
+	if cp == nil {
+		return fmt.Errorf("no parser for contract %s", contract.Address)
+	}
+	eventParser, err := i.applyUpgrades(ctx, contract, cp, logs)
+	if err != nil {
+		return fmt.Errorf("failed to apply proxy upgrades: %w", err)
+	}
+	
+	// Drop the logs fetched only to watch for upgrades
+	logs = filter.allowedLogs(logs)
+	
+	if len(logs) == 0 {
+		i.logger.WithFields(map[string]interface{}{
+			"contract":   contract.Address,
+			"from_block": fromBlock,
+			"to_block":   toBlock,
+		}).Debug("No logs found in block range")
+		
+		// Update current block even if no logs
+		if err := i.contractStorage.UpdateContractBlock(ctx, i.chainID, contract.Address, toBlock); err != nil {
+			return fmt.Errorf("failed to update contract block: %w", err)
+		}
+		
+		return nil
+	}
+	
+	// Parse logs into events
+	events, unknown, err := eventParser.ParseLogs(logs, blockTimestamps)
+	if err != nil {
+		return fmt.Errorf("failed to parse logs: %w", err)
+	}
+	
+	// Keep the logs that could not be decoded before the cursor moves past
+	// them, so they can be decoded once the ABI is fixed
+	for _, log := range unknown {
+		log.ChainID = i.chainID
+	}
+	if err := i.eventStorage.InsertUnknownLogs(ctx, unknown); err != nil {
+		return fmt.Errorf("failed to store unknown logs: %w", err)
+	}
+	for _, event := range events {
+		event.ChainID = i.chainID
+		event.Finality = models.FinalityConfirmed
+		if !i.confirmations.IsBlockConfirmed(event.BlockNumber, latestBlock, contract.ConfirmBlocks) {
+			event.Finality = models.FinalityPending
+		}
+	}
+	
+	if len(events) == 0 {
+		i.logger.WithField("contract", contract.Address).Debug("No events parsed from logs")
+		
+		// Update current block
+		if err := i.contractStorage.UpdateContractBlock(ctx, i.chainID, contract.Address, toBlock); err != nil {
+			return fmt.Errorf("failed to update contract block: %w", err)
+		}
+		
+		return nil
+	}
+	
+	// Insert events into database
+	inserted, err := i.insertEvents(ctx, events)
+	if err != nil {
+		return fmt.Errorf("failed to insert events: %w", err)
+	}
+	
+	i.announceEvents(ctx, i.logger.WithField("contract", contract.Address), inserted)
+	
+	// Children are registered from every decoded event, not only new ones, so
+	// a range retried after a failed registration finds them again
+	if _, err := registerChildren(ctx, i.contractStorage, factories, events, i.logger); err != nil {
+		return fmt.Errorf("failed to register factory children: %w", err)
+	}
+	
+	// Update contract's current block
+	if err := i.contractStorage.UpdateContractBlock(ctx, i.chainID, contract.Address, toBlock); err != nil {
+		return fmt.Errorf("failed to update contract block: %w", err)
+	}
+	
+	// Update indexer state
+	if err := i.stateStorage.UpdateLastIndexedBlock(
+		ctx,
+		i.chainID,
+		contract.Address,
+		toBlock,
+		lastHash,
+	); err != nil {
+		return fmt.Errorf("failed to update indexer state: %w", err)
+	}
+	
+	// Reset error count on success
+	if err := i.stateStorage.ResetErrorCount(ctx, i.chainID, contract.Address); err != nil {
+		i.logger.WithError(err).Warn("Failed to reset error count")
+	}
+	
+	i.logger.WithFields(map[string]interface{}{
+		"contract":     contract.Address,
+		"from_block":   fromBlock,
+		"to_block":     toBlock,
+		"events_found": len(events),
+		"unknown_logs": len(unknown),
+		"logs_found":   len(logs),
+	}).Info("Successfully processed contract")
+	
+	return nil
+}
+
+// insertEvents stores events and, when webhooks are configured, queues the
+// deliveries of the newly stored ones in the same transaction
+func (i *Indexer) insertEvents(ctx context.Context, events []*models.Event) ([]*models.Event, error) {
+	if i.webhooks == nil {
+		return i.eventStorage.InsertEvents(ctx, events)
+	}
+	return i.eventStorage.InsertEventsWithDeliveries(ctx, events, i.webhooks.Match)
+}
+
+// announceEvents hands newly stored events to live subscribers, logging
+// failures with logger. Their webhook deliveries were queued by insertEvents.
+func (i *Indexer) announceEvents(ctx context.Context, logger utils.Logger, inserted []*models.Event) {
+	if len(inserted) == 0 || i.publisher == nil {
+		return
+	}
+	
+	// Delivery to live subscribers is best effort: they can always catch up
+	// through the query API
+	if err := i.publisher.PublishEvents(ctx, inserted); err != nil {
+		logger.WithError(err).Warn("Failed to publish new events")
+	}
+}
+
+// announcePromotions publishes events that were just promoted to confirmed,
+// so live subscribers filtering on confirmed events receive them
+func (i *Indexer) announcePromotions(ctx context.Context, logger utils.Logger, promoted []*models.Event) {
+	if len(promoted) == 0 || i.publisher == nil {
+		return
+	}
+	if err := i.publisher.PublishEvents(ctx, promoted); err != nil {
+		logger.WithError(err).Warn("Failed to publish confirmed events")
+	}
+}
+
+// promotableContracts returns the contracts whose pending events are promoted
+// after a poll: the active contracts indexed without error, and every paused
+// contract. A paused contract's events keep gaining confirmations while it is
+// paused, and reorgs still roll them back.
+func promotableContracts(contracts []*models.Contract, failed map[models.Address]bool) []*models.Contract {
+	promotable := make([]*models.Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		if !contract.IsActive || !failed[contract.Address] {
+			promotable = append(promotable, contract)
+		}
+	}
+	return promotable
+}
+
+// promoteConfirmedEvents marks the contract's pending events as confirmed once
+// their blocks have the required confirmations and announces them. Pending
+// events that are reorged out before then are deleted by the reorg handler.
+func (i *Indexer) promoteConfirmedEvents(ctx context.Context, contract *models.Contract, latestBlock int64) error {
+	confirmedBlock := i.confirmations.GetConfirmedBlock(latestBlock, contract.ConfirmBlocks)
+	if confirmedBlock < contract.StartBlock {
+		return nil
+	}
+	
+	promoted, err := i.eventStorage.ConfirmEvents(ctx, i.chainID, contract.Address, confirmedBlock)
+	if err != nil {
+		return err
+	}
+	i.announcePromotions(ctx, i.logger.WithField("contract", contract.Address), promoted)
+	return nil
+}
+
+// checkForReorg compares each block in the range with the hashes cached for
+// its height and its parent's, so blocks already checked by another range are
+// checked again. Blocks older than the cache window behind the chain
+// head are not checked. Returns the fork point and the block where the
+// divergence was seen, or zeros when the range is consistent.
+func (i *Indexer) checkForReorg(ctx context.Context, fromBlock, toBlock, latestBlock int64) (int64, int64, error) {
+	start := fromBlock
+	if windowStart := latestBlock - reorg.BlockCacheSize + 1; windowStart > start {
+		start = windowStart
+	}
+	
+	for blockNumber := start; blockNumber <= toBlock; blockNumber++ {
+		header, err := i.client.GetHeaderByNumber(ctx, blockNumber)
+		if err != nil {
+			return 0, 0, err
+		}
+		i.headers.Add(header)
+		
+		reorged, forkPoint, err := i.reorgDetector.DetectReorg(ctx, &reorg.BlockInfo{
+			Number:     blockNumber,
+			Hash:       models.Hash(header.Hash().Hex()),
+			ParentHash: models.Hash(header.ParentHash.Hex()),
+			Timestamp:  time.Unix(int64(header.Time), 0).UTC(),
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		if reorged {
+			return forkPoint, blockNumber, nil
+		}
+	}
+	
+	return 0, 0, nil
+}
+
+// AddContract adds a new contract to monitor
+func (i *Indexer) AddContract(ctx context.Context, contract *models.Contract) error {
+	if contract.ChainID == 0 {
+		contract.ChainID = i.chainID
+	}
+	if contract.ChainID != i.chainID {
+		return fmt.Errorf("contract is on chain %d but this indexer follows chain %d", contract.ChainID, i.chainID)
+	}
+	
+	// Validate the contract
+	if err := contract.Validate(); err != nil {
+		return fmt.Errorf("invalid contract: %w", err)
+	}
+	
+	// Create parser to validate ABI
+	if _, err := i.parserForContract(contract, nil); err != nil {
+		return fmt.Errorf("failed to create parser: %w", err)
+	}
+	
+	// Save contract to database
+	if err := i.contractStorage.UpsertContract(ctx, contract); err != nil {
+		return fmt.Errorf("failed to save contract: %w", err)
+	}
+	
+	// Initialize indexer state
+	if err := i.stateStorage.InitializeState(ctx, i.chainID, contract.Address, contract.StartBlock); err != nil {
+		return fmt.Errorf("failed to initialize state: %w", err)
+	}
+	
+	i.logger.WithFields(map[string]interface{}{
+		"contract": contract.Address,
+		"name":     contract.Name,
+	}).Info("Contract added to indexer")
+	
+	return nil
+}
+
+// RemoveContract removes a contract from monitoring
+func (i *Indexer) RemoveContract(ctx context.Context, address models.Address) error {
+	// Remove parser
+	i.parsersMu.Lock()
+	delete(i.parsers, address)
+	i.parsersMu.Unlock()
+	
+	// Delete contract from database
+	if err := i.contractStorage.DeleteContract(ctx, i.chainID, address); err != nil {
+		return fmt.Errorf("failed to delete contract: %w", err)
+	}
+	
+	// Delete indexer state
+	if err := i.stateStorage.DeleteIndexerState(ctx, i.chainID, address); err != nil {
+		i.logger.WithError(err).Warn("Failed to delete indexer state")
+	}
+	
+	i.logger.WithField("contract", address).Info("Contract removed from indexer")
+	
+	return nil
+}
+
+// GetStats returns indexing statistics
+func (i *Indexer) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	contractCount, err := i.contractStorage.GetContractCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract count: %w", err)
+	}
+	
+	eventCount, err := i.eventStorage.GetEventCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event count: %w", err)
+	}
+	
+	latestBlock, err := i.client.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	
+	stats := map[string]interface{}{
+		"chain_id":            i.chainID,
+		"contracts_monitored": contractCount,
+		"events_indexed":      eventCount,
+		"latest_block":        latestBlock,
+		"poll_interval":       i.pollInterval.String(),
+		"batch_size":          i.batchSize,
+	}
+	
+	return stats, nil
+}
+
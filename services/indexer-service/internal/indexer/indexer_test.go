@@ -0,0 +1,30 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+func TestPromotableContracts(t *testing.T) {
+	contracts := testContracts(4)
+	contracts[1].IsActive = false // paused
+	contracts[3].IsActive = false // paused; failures only hold back active contracts
+
+	failed := map[models.Address]bool{
+		contracts[2].Address: true,
+		contracts[3].Address: true,
+	}
+
+	got := promotableContracts(contracts, failed)
+
+	want := []models.Address{contracts[0].Address, contracts[1].Address, contracts[3].Address}
+	if len(got) != len(want) {
+		t.Fatalf("promotable = %d contracts, want %d", len(got), len(want))
+	}
+	for k, contract := range got {
+		if contract.Address != want[k] {
+			t.Errorf("promotable[%d] = %s, want %s", k, contract.Address, want[k])
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package indexer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxConcurrentContracts is used when no concurrency limit is set
+	DefaultMaxConcurrentContracts = 5
+	// DefaultContractTimeout bounds how long one contract may take per poll
+	DefaultContractTimeout = 2 * time.Minute
+)
+
+// runWorkers calls fn for every item, running at most limit calls at a time.
+// Each item is handed to exactly one call, so the work for one item stays
+// sequential while different items proceed independently. Every call gets its
+// own context that expires after timeout (no deadline when timeout is zero),
+// so a hung call only holds up its own worker. Items not yet started when ctx
+// is cancelled are skipped. runWorkers returns once every started call has
+// returned.
+func runWorkers[T any](
+	ctx context.Context,
+	items []T,
+	limit int,
+	timeout time.Duration,
+	fn func(ctx context.Context, item T),
+) {
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > len(items) {
+		limit = len(items)
+	}
+
+	work := make(chan T)
+	var wg sync.WaitGroup
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				runWorker(ctx, item, timeout, fn)
+			}
+		}()
+	}
+
+dispatch:
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case work <- item:
+		}
+	}
+	close(work)
+	wg.Wait()
+}
+
+// runWorker runs fn for one item under its own deadline
+func runWorker[T any](
+	ctx context.Context,
+	item T,
+	timeout time.Duration,
+	fn func(ctx context.Context, item T),
+) {
+	if ctx.Err() != nil {
+		return
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	fn(ctx, item)
+}
@@ -0,0 +1,191 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+func testContracts(n int) []*models.Contract {
+	contracts := make([]*models.Contract, n)
+	for k := range contracts {
+		contracts[k] = &models.Contract{
+			Address:    models.Address(fmt.Sprintf("0x%040x", k+1)),
+			StartBlock: int64(100 * k),
+			IsActive:   true,
+		}
+	}
+	return contracts
+}
+
+func TestRunWorkers_BoundsConcurrency(t *testing.T) {
+	contracts := testContracts(12)
+
+	var (
+		running, peak atomic.Int32
+		mu            sync.Mutex
+		calls         = make(map[models.Address]int)
+	)
+	runWorkers(context.Background(), contracts, 3, time.Second, func(ctx context.Context, contract *models.Contract) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		calls[contract.Address]++
+		mu.Unlock()
+	})
+
+	if p := peak.Load(); p > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", p)
+	}
+	for _, contract := range contracts {
+		if calls[contract.Address] != 1 {
+			t.Errorf("contract %s processed %d times, want 1", contract.Address, calls[contract.Address])
+		}
+	}
+}
+
+func TestRunWorkers_SlowContractTimesOutAlone(t *testing.T) {
+	contracts := testContracts(6)
+	slow := contracts[0].Address
+
+	var (
+		done    atomic.Int32
+		slowErr error
+	)
+	start := time.Now()
+	runWorkers(context.Background(), contracts, 2, 50*time.Millisecond, func(ctx context.Context, contract *models.Contract) {
+		if contract.Address == slow {
+			// Stand-in for a GetLogs call that never returns
+			<-ctx.Done()
+			slowErr = ctx.Err()
+			return
+		}
+		done.Add(1)
+	})
+
+	if !errors.Is(slowErr, context.DeadlineExceeded) {
+		t.Errorf("slow contract error = %v, want deadline exceeded", slowErr)
+	}
+	if n := done.Load(); n != 5 {
+		t.Errorf("%d other contracts processed, want 5", n)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("poll took %s; the slow contract held up the others", elapsed)
+	}
+}
+
+func TestRunWorkers_StopsDispatchOnCancel(t *testing.T) {
+	contracts := testContracts(20)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	runWorkers(ctx, contracts, 1, time.Second, func(ctx context.Context, contract *models.Contract) {
+		// The first contract sees a reorg and stops the poll
+		if calls.Add(1) == 1 {
+			cancel()
+		}
+	})
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("%d contracts processed after cancellation, want 1", n)
+	}
+}
+
+// checkpointStore mimics the checkpoint updates indexContractLogs makes: each
+// poll indexes the range after a contract's current block and advances it only
+// on success
+type checkpointStore struct {
+	mu       sync.Mutex
+	current  map[models.Address]int64
+	ranges   map[models.Address][][2]int64
+	inFlight map[models.Address]bool
+}
+
+func (s *checkpointStore) process(t *testing.T, contract *models.Contract, latestBlock, batch int64, fail bool) {
+	s.mu.Lock()
+	if s.inFlight[contract.Address] {
+		t.Errorf("contract %s processed concurrently with itself", contract.Address)
+	}
+	s.inFlight[contract.Address] = true
+	fromBlock := s.current[contract.Address] + 1
+	s.mu.Unlock()
+
+	time.Sleep(time.Duration(fromBlock%3) * time.Millisecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight[contract.Address] = false
+
+	toBlock := fromBlock + batch - 1
+	if toBlock > latestBlock {
+		toBlock = latestBlock
+	}
+	if fail || fromBlock > toBlock {
+		return
+	}
+	s.ranges[contract.Address] = append(s.ranges[contract.Address], [2]int64{fromBlock, toBlock})
+	s.current[contract.Address] = toBlock
+}
+
+func TestRunWorkers_CheckpointsStayInOrder(t *testing.T) {
+	contracts := testContracts(8)
+	store := &checkpointStore{
+		current:  make(map[models.Address]int64),
+		ranges:   make(map[models.Address][][2]int64),
+		inFlight: make(map[models.Address]bool),
+	}
+	for _, contract := range contracts {
+		store.current[contract.Address] = contract.StartBlock
+	}
+
+	const (
+		batch       = 10
+		latestBlock = 1000
+		polls       = 12
+	)
+	for poll := 0; poll < polls; poll++ {
+		runWorkers(context.Background(), contracts, 3, time.Second, func(ctx context.Context, contract *models.Contract) {
+			// The first contract fails every other poll
+			fail := contract == contracts[0] && poll%2 == 0
+			store.process(t, contract, latestBlock, batch, fail)
+		})
+	}
+
+	for k, contract := range contracts {
+		ranges := store.ranges[contract.Address]
+		next := contract.StartBlock + 1
+		for _, r := range ranges {
+			if r[0] != next {
+				t.Fatalf("contract %s indexed %d-%d, want a range starting at %d", contract.Address, r[0], r[1], next)
+			}
+			next = r[1] + 1
+		}
+
+		successes := int64(polls)
+		if k == 0 {
+			successes = polls / 2
+		}
+		want := contract.StartBlock + successes*batch
+		if want > latestBlock {
+			want = latestBlock
+		}
+		if got := store.current[contract.Address]; got != want {
+			t.Errorf("contract %s checkpoint = %d, want %d", contract.Address, got, want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package indexer
+
+import (
+	"sync"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// rangeGrowAfter is how many consecutive full-size fetches a contract needs
+// without being split before its range size doubles
+const rangeGrowAfter = 3
+
+// rangeSizes learns how many blocks each contract can fetch with one
+// eth_getLogs call. Sizes start at the batch size, which is also the ceiling.
+// When the provider rejects a range and it has to be split, the contract's
+// size drops to the widest span that was accepted, or in a window shared with
+// other contracts to the span its own logs would fit in; after rangeGrowAfter
+// full-size fetches in a row it doubles again. Dense contracts settle on small
+// ranges while sparse ones keep fetching whole batches. Topic subscriptions
+// learn their own sizes under their own keys.
+type rangeSizes struct {
+	mu      sync.Mutex
+	max     int64
+	sizes   map[string]int64
+	streaks map[string]int
+}
+
+// newRangeSizes creates a tracker whose sizes never exceed max blocks
+func newRangeSizes(max int) *rangeSizes {
+	if max < 1 {
+		max = 1
+	}
+	return &rangeSizes{
+		max:     int64(max),
+		sizes:   make(map[string]int64),
+		streaks: make(map[string]int),
+	}
+}
+
+// size returns the number of blocks to fetch for a contract in one call
+func (s *rangeSizes) size(contract *models.Contract) int64 {
+	return s.sizeFor(string(contract.Address))
+}
+
+// record updates a contract's size after fetching a range of requested
+// blocks, of which at most accepted were fetched in a single call
+func (s *rangeSizes) record(contract *models.Contract, requested, accepted int64) {
+	s.recordFor(string(contract.Address), requested, accepted)
+}
+
+// recordShared updates the sizes of contracts that fetched a range of
+// requested blocks with one eth_getLogs call, of which at most accepted were
+// fetched at a time, given how many logs each contract had in the range. A
+// split is attributed to the contracts by their share of the logs: the
+// accepted calls held about accepted*total/requested logs, so a contract with
+// own of the total logs would fit about accepted*total/own blocks by itself.
+// Contracts without logs in the range keep their size, unless no contract had
+// any and the provider limited the block span alone.
+func (s *rangeSizes) recordShared(contracts []*models.Contract, requested, accepted int64, logCounts map[models.Address]int) {
+	var total int64
+	for _, contract := range contracts {
+		total += int64(logCounts[contract.Address])
+	}
+
+	for _, contract := range contracts {
+		own := int64(logCounts[contract.Address])
+		span := accepted
+		switch {
+		case accepted >= requested || total == 0:
+		case own == 0:
+			span = requested
+		default:
+			if span = accepted * total / own; span > requested {
+				span = requested
+			}
+		}
+		s.record(contract, requested, span)
+	}
+}
+
+// sizeFor returns the number of blocks to fetch in one call for key
+func (s *rangeSizes) sizeFor(key string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if size, ok := s.sizes[key]; ok {
+		return size
+	}
+	return s.max
+}
+
+// recordFor updates the size of key; see record
+func (s *rangeSizes) recordFor(key string, requested, accepted int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.sizes[key]
+	if !ok {
+		current = s.max
+	}
+
+	if accepted < requested {
+		if accepted < 1 {
+			accepted = 1
+		}
+		s.sizes[key] = accepted
+		s.streaks[key] = 0
+		return
+	}
+
+	// Ranges cut short by the chain head say nothing about the provider limit
+	if requested < current || current >= s.max {
+		return
+	}
+
+	s.streaks[key]++
+	if s.streaks[key] < rangeGrowAfter {
+		return
+	}
+	grown := current * 2
+	if grown > s.max {
+		grown = s.max
+	}
+	s.sizes[key] = grown
+	s.streaks[key] = 0
+}
@@ -0,0 +1,104 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+func TestRangeSizes_ShrinksAndGrows(t *testing.T) {
+	sizes := newRangeSizes(1000)
+	dense := &models.Contract{Address: "0x000000000000000000000000000000000000000a"}
+	sparse := &models.Contract{Address: "0x000000000000000000000000000000000000000b"}
+
+	if got := sizes.size(dense); got != 1000 {
+		t.Fatalf("initial size = %d, want the batch size 1000", got)
+	}
+
+	// The provider only accepted 125 blocks at a time
+	sizes.record(dense, 1000, 125)
+	if got := sizes.size(dense); got != 125 {
+		t.Fatalf("size after split = %d, want 125", got)
+	}
+	if got := sizes.size(sparse); got != 1000 {
+		t.Errorf("sparse contract size = %d, want it unaffected at 1000", got)
+	}
+
+	// Short ranges at the chain head are not evidence the size can grow
+	for k := 0; k < 2*rangeGrowAfter; k++ {
+		sizes.record(dense, 3, 3)
+	}
+	if got := sizes.size(dense); got != 125 {
+		t.Errorf("size after short ranges = %d, want 125", got)
+	}
+
+	// Full-size fetches double the size, up to the batch size
+	want := []int64{250, 500, 1000, 1000}
+	for _, w := range want {
+		for k := 0; k < rangeGrowAfter; k++ {
+			sizes.record(dense, sizes.size(dense), sizes.size(dense))
+		}
+		if got := sizes.size(dense); got != w {
+			t.Fatalf("size = %d, want %d", got, w)
+		}
+	}
+
+	// A split down to a single block never drops the size below one
+	sizes.record(dense, 1000, 0)
+	if got := sizes.size(dense); got != 1 {
+		t.Errorf("size after single-block split = %d, want 1", got)
+	}
+}
+
+func TestRangeSizes_RecordShared(t *testing.T) {
+	dense := &models.Contract{Address: "0x000000000000000000000000000000000000000a"}
+	sparse := &models.Contract{Address: "0x000000000000000000000000000000000000000b"}
+	quiet := &models.Contract{Address: "0x000000000000000000000000000000000000000c"}
+	contracts := []*models.Contract{dense, sparse, quiet}
+
+	tests := []struct {
+		name      string
+		accepted  int64
+		logCounts map[models.Address]int
+		want      map[models.Address]int64
+	}{
+		{
+			name:      "dense contract caused the split",
+			accepted:  125,
+			logCounts: map[models.Address]int{dense.Address: 9000, sparse.Address: 1000},
+			// Alone, the dense contract fits 125*10000/9000 blocks and the
+			// sparse one the whole window
+			want: map[models.Address]int64{dense.Address: 138, sparse.Address: 1000, quiet.Address: 1000},
+		},
+		{
+			name:      "contracts share the split",
+			accepted:  100,
+			logCounts: map[models.Address]int{dense.Address: 5000, sparse.Address: 5000},
+			want:      map[models.Address]int64{dense.Address: 200, sparse.Address: 200, quiet.Address: 1000},
+		},
+		{
+			name:      "span limited without logs",
+			accepted:  500,
+			logCounts: map[models.Address]int{},
+			want:      map[models.Address]int64{dense.Address: 500, sparse.Address: 500, quiet.Address: 500},
+		},
+		{
+			name:      "window not split",
+			accepted:  1000,
+			logCounts: map[models.Address]int{dense.Address: 9000, sparse.Address: 1000},
+			want:      map[models.Address]int64{dense.Address: 1000, sparse.Address: 1000, quiet.Address: 1000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sizes := newRangeSizes(1000)
+			sizes.recordShared(contracts, 1000, tt.accepted, tt.logCounts)
+			for _, contract := range contracts {
+				if got := sizes.size(contract); got != tt.want[contract.Address] {
+					t.Errorf("size of %s = %d, want %d", contract.Address, got, tt.want[contract.Address])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/smart-contract-event-indexer/indexer-service/internal/parser"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// executeRedecodeJob walks the job's block range in chunks like a backfill,
+// but decodes the contract's stored raw logs again with its current ABIs
+// instead of fetching logs from the chain. Each chunk's events are rewritten
+// in one transaction. Events without a raw log, or that no longer decode, are
+// kept as they are and counted as skipped.
+func (w *BackfillWorker) executeRedecodeJob(ctx context.Context, job *models.BackfillJob, contract *models.Contract, eventParser *parser.EventParser) error {
+	chunkSize := int64(job.ChunkSize)
+	if chunkSize <= 0 {
+		chunkSize = 1000
+	}
+
+	fromBlock := resumeBlock(job)
+
+	w.logger.WithFields(map[string]interface{}{
+		"job_id":     job.ID,
+		"chain_id":   job.ChainID,
+		"contract":   job.ContractAddress,
+		"from_block": fromBlock,
+		"to_block":   job.ToBlock,
+		"chunk_size": chunkSize,
+	}).Info("Executing redecode job")
+
+	for start := fromBlock; start <= job.ToBlock; start += chunkSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := start + chunkSize - 1
+		if end > job.ToBlock {
+			end = job.ToBlock
+		}
+
+		updated, skipped, err := w.redecodeChunk(ctx, contract, eventParser, start, end)
+		if err != nil {
+			return fmt.Errorf("blocks %d-%d: %w", start, end, err)
+		}
+
+		// A chunk re-run after a crash is rewritten to the same result, but
+		// its counts are added again
+		running, err := w.backfillStorage.UpdateRedecodeProgress(ctx, job.ID, end, updated, skipped)
+		if err != nil {
+			return err
+		}
+		if !running {
+			return errBackfillStopped
+		}
+	}
+
+	return nil
+}
+
+// redecodeChunk re-decodes the stored events of a single block range and
+// returns how many changed and how many were skipped
+func (w *BackfillWorker) redecodeChunk(
+	ctx context.Context,
+	contract *models.Contract,
+	eventParser *parser.EventParser,
+	fromBlock, toBlock int64,
+) (int64, int64, error) {
+	events, err := w.eventStorage.GetEventsForRedecode(ctx, contract.ChainID, contract.Address, fromBlock, toBlock)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		decoded = make([]*models.Event, 0, len(events))
+		skipped int64
+	)
+	for _, event := range events {
+		redecoded, err := redecodeEvent(eventParser, event)
+		if err == nil {
+			event.EventName = redecoded.EventName
+			event.EventSignature = redecoded.EventSignature
+			event.Args = redecoded.Args
+			event.ArgTypes = redecoded.ArgTypes
+			decoded = append(decoded, event)
+			continue
+		}
+
+		skipped++
+		if !errors.Is(err, parser.ErrNoRawLog) {
+			w.logger.WithError(err).WithFields(map[string]interface{}{
+				"event_id":  event.ID,
+				"block":     event.BlockNumber,
+				"log_index": event.LogIndex,
+			}).Warn("Failed to redecode event, keeping it as stored")
+		}
+	}
+
+	updated, err := w.eventStorage.UpdateDecodedEvents(ctx, decoded)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	w.logger.WithFields(map[string]interface{}{
+		"contract":   contract.Address,
+		"from_block": fromBlock,
+		"to_block":   toBlock,
+		"events":     len(events),
+		"updated":    updated,
+		"skipped":    skipped,
+	}).Debug("Redecode chunk processed")
+
+	return updated, skipped, nil
+}
+
+// redecodeEvent decodes a stored event again from its raw log
+func redecodeEvent(eventParser *parser.EventParser, event *models.Event) (*models.Event, error) {
+	log, err := parser.EventToLog(event)
+	if err != nil {
+		return nil, err
+	}
+	return eventParser.ParseLog(log, event.Timestamp)
+}
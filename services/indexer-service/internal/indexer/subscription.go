@@ -0,0 +1,187 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/blockchain"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/parser"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// subscriptionParser decodes the logs of one topic subscription
+type subscriptionParser struct {
+	eventParser *parser.EventParser
+	eventID     common.Hash
+	topicCount  int // topic0 plus one topic per indexed argument
+}
+
+// parserForSubscription returns the parser of a subscription, building it
+// from the subscription's ABI fragment the first time. Subscriptions cannot
+// change their ABI, so parsers are cached by ID.
+func (i *Indexer) parserForSubscription(subscription *models.TopicSubscription) (*subscriptionParser, error) {
+	i.parsersMu.RLock()
+	sp, ok := i.subscriptionParsers[subscription.ID]
+	i.parsersMu.RUnlock()
+	if ok {
+		return sp, nil
+	}
+
+	abiParser, err := parser.NewABIParser(subscription.ABI, i.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse subscription ABI: %w", err)
+	}
+	// The signature picks the subscribed overload when the name is overloaded
+	event, ok := abiParser.GetEventByName(subscription.EventSignature)
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in subscription ABI", subscription.EventSignature)
+	}
+
+	sp = &subscriptionParser{
+		eventParser: parser.NewEventParser(abiParser, i.logger),
+		eventID:     event.ID,
+		topicCount:  1,
+	}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			sp.topicCount++
+		}
+	}
+
+	i.parsersMu.Lock()
+	i.subscriptionParsers[subscription.ID] = sp
+	i.parsersMu.Unlock()
+
+	return sp, nil
+}
+
+// subscriptionLogs keeps the logs whose topics fit the subscribed event.
+// Events sharing a signature can differ in which arguments are indexed, like
+// ERC-20 and ERC-721 Transfer, and only the subscribed layout is decodable.
+func subscriptionLogs(logs []types.Log, topicCount int) []types.Log {
+	matched := make([]types.Log, 0, len(logs))
+	for _, log := range logs {
+		if len(log.Topics) == topicCount {
+			matched = append(matched, log)
+		}
+	}
+	return matched
+}
+
+// subscriptionRangeKey keys a subscription's learned range size apart from
+// the contracts'
+func subscriptionRangeKey(subscription *models.TopicSubscription) string {
+	return fmt.Sprintf("subscription:%d", subscription.ID)
+}
+
+// processSubscription indexes the next range of a topic subscription: the
+// subscribed event from every emitter on the chain, stored as events of the
+// emitting address. Logs of other layouts under the same signature are
+// skipped rather than kept as unknown logs, since no contract claims them.
+func (i *Indexer) processSubscription(ctx context.Context, subscription *models.TopicSubscription, latestBlock int64) error {
+	fromBlock := subscription.CurrentBlock + 1
+	if fromBlock > latestBlock {
+		return nil
+	}
+
+	sp, err := i.parserForSubscription(subscription)
+	if err != nil {
+		return err
+	}
+
+	key := subscriptionRangeKey(subscription)
+	toBlock := fromBlock + i.rangeSizes.sizeFor(key) - 1
+	if toBlock > latestBlock {
+		toBlock = latestBlock
+	}
+
+	// Make sure the range still extends the chain we indexed before
+	forkPoint, detectedAt, err := i.checkForReorg(ctx, fromBlock, toBlock, latestBlock)
+	if err != nil {
+		return fmt.Errorf("failed to check for reorg: %w", err)
+	}
+	if forkPoint > 0 {
+		return &chainReorgError{forkPoint: forkPoint, detectedAt: detectedAt}
+	}
+
+	// No addresses: the subscription matches its event from any emitter
+	topics := [][][]common.Hash{{{sp.eventID}}}
+	logs, accepted, err := blockchain.GetFilteredLogs(ctx, i.client, nil, topics, fromBlock, toBlock)
+	if err != nil {
+		return fmt.Errorf("failed to get logs: %w", err)
+	}
+	i.rangeSizes.recordFor(key, toBlock-fromBlock+1, accepted)
+	logs = subscriptionLogs(logs, sp.topicCount)
+
+	var (
+		blockTimestamps map[common.Hash]time.Time
+		lastHash        models.Hash
+	)
+	if len(logs) > 0 {
+		blockTimestamps, err = i.headers.TimestampsForLogs(ctx, logs)
+		if err != nil {
+			return fmt.Errorf("failed to get block timestamps: %w", err)
+		}
+
+		lastHeader, err := i.client.GetHeaderByNumber(ctx, toBlock)
+		if err != nil {
+			return fmt.Errorf("failed to get block header: %w", err)
+		}
+		lastHash = models.Hash(lastHeader.Hash().Hex())
+	}
+
+	events, unknown, err := sp.eventParser.ParseLogs(logs, blockTimestamps)
+	if err != nil {
+		return fmt.Errorf("failed to parse logs: %w", err)
+	}
+	for _, event := range events {
+		event.ChainID = i.chainID
+		event.SubscriptionID = &subscription.ID
+		event.Finality = models.FinalityConfirmed
+		if !i.confirmations.IsBlockConfirmed(event.BlockNumber, latestBlock, subscription.ConfirmBlocks) {
+			event.Finality = models.FinalityPending
+		}
+	}
+
+	logger := i.logger.WithField("subscription", subscription.Name)
+	if len(events) > 0 {
+		inserted, err := i.insertEvents(ctx, events)
+		if err != nil {
+			return fmt.Errorf("failed to insert events: %w", err)
+		}
+		i.announceEvents(ctx, logger, inserted)
+	}
+
+	if err := i.stateStorage.UpdateSubscriptionBlock(ctx, subscription, toBlock, lastHash); err != nil {
+		return fmt.Errorf("failed to update subscription block: %w", err)
+	}
+
+	logger.WithFields(map[string]interface{}{
+		"from_block":   fromBlock,
+		"to_block":     toBlock,
+		"events_found": len(events),
+		"skipped_logs": len(unknown),
+	}).Debug("Processed topic subscription")
+
+	return nil
+}
+
+// promoteSubscriptionEvents marks a subscription's pending events as
+// confirmed once their blocks have the required confirmations and announces
+// them
+func (i *Indexer) promoteSubscriptionEvents(ctx context.Context, subscription *models.TopicSubscription, latestBlock int64) error {
+	confirmedBlock := i.confirmations.GetConfirmedBlock(latestBlock, subscription.ConfirmBlocks)
+	if confirmedBlock < subscription.StartBlock {
+		return nil
+	}
+
+	promoted, err := i.eventStorage.ConfirmSubscriptionEvents(ctx, subscription.ID, confirmedBlock)
+	if err != nil {
+		return err
+	}
+	i.announcePromotions(ctx, i.logger.WithField("subscription", subscription.Name), promoted)
+	return nil
+}
@@ -0,0 +1,49 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+func TestSubscriptionLogs(t *testing.T) {
+	transfer := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	from := common.HexToHash("0x01")
+	to := common.HexToHash("0x02")
+	tokenID := common.HexToHash("0x03")
+
+	logs := []types.Log{
+		// ERC-20 Transfer: amount in the data
+		{Topics: []common.Hash{transfer, from, to}, Index: 0},
+		// ERC-721 Transfer: token ID indexed as a fourth topic
+		{Topics: []common.Hash{transfer, from, to, tokenID}, Index: 1},
+		{Topics: []common.Hash{transfer, from, to}, Index: 2},
+	}
+
+	matched := subscriptionLogs(logs, 3)
+	if len(matched) != 2 {
+		t.Fatalf("expected the 2 ERC-20 layout logs, got %d", len(matched))
+	}
+	if matched[0].Index != 0 || matched[1].Index != 2 {
+		t.Errorf("unexpected logs kept: %d, %d", matched[0].Index, matched[1].Index)
+	}
+	if len(logs) != 3 || logs[1].Index != 1 {
+		t.Error("expected the input logs to be left untouched")
+	}
+}
+
+func TestRangeSizes_SubscriptionKeys(t *testing.T) {
+	sizes := newRangeSizes(1000)
+	contract := &models.Contract{Address: "0x000000000000000000000000000000000000000a"}
+	subscription := &models.TopicSubscription{ID: 1}
+
+	sizes.recordFor(subscriptionRangeKey(subscription), 1000, 10)
+	if got := sizes.sizeFor(subscriptionRangeKey(subscription)); got != 10 {
+		t.Errorf("subscription size = %d, want 10", got)
+	}
+	if got := sizes.size(contract); got != 1000 {
+		t.Errorf("contract size = %d, want it unaffected at 1000", got)
+	}
+}
@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smart-contract-event-indexer/indexer-service/internal/parser"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// unknownLogRetryBatch caps how many unknown logs one poll decodes again
+const unknownLogRetryBatch = 500
+
+// retryUnknownLogs decodes again the unknown logs an operator asked to retry,
+// with the ABI versions now attached to their contracts. Logs that decode are
+// stored as events and leave the unknown store; the others record the new
+// failure reason and wait for another retry request.
+func (i *Indexer) retryUnknownLogs(ctx context.Context, contracts []*models.Contract, latestBlock int64) error {
+	unknown, err := i.eventStorage.GetUnknownLogsForRetry(ctx, i.chainID, unknownLogRetryBatch)
+	if err != nil {
+		return err
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	// Parsers are only rebuilt for active contracts, so the unknown logs of
+	// paused ones wait until they are resumed
+	byAddress := make(map[models.Address]*models.Contract, len(contracts))
+	for _, contract := range contracts {
+		if contract.IsActive {
+			byAddress[contract.Address] = contract
+		}
+	}
+
+	var (
+		events []*models.Event
+		failed int
+	)
+	for _, u := range unknown {
+		contract, cp := byAddress[u.ContractAddress], i.getParserForContract(u.ContractAddress)
+		if contract == nil || cp == nil {
+			continue
+		}
+
+		event, err := decodeUnknownLog(cp.eventParser, u)
+		if err != nil {
+			failed++
+			if err := i.eventStorage.FinishUnknownLogRetry(ctx, u.ID, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		event.ChainID = i.chainID
+		event.Finality = models.FinalityConfirmed
+		if !i.confirmations.IsBlockConfirmed(event.BlockNumber, latestBlock, contract.ConfirmBlocks) {
+			event.Finality = models.FinalityPending
+		}
+		events = append(events, event)
+	}
+
+	if len(events) > 0 {
+		inserted, err := i.insertEvents(ctx, events)
+		if err != nil {
+			return fmt.Errorf("failed to insert events: %w", err)
+		}
+		if _, err := i.eventStorage.DeleteDecodedUnknownLogs(ctx, events); err != nil {
+			return fmt.Errorf("failed to delete decoded unknown logs: %w", err)
+		}
+		for address, contractEvents := range eventsByContract(inserted) {
+			i.announceEvents(ctx, i.logger.WithField("contract", address), contractEvents)
+		}
+	}
+
+	i.logger.WithFields(map[string]interface{}{
+		"decoded": len(events),
+		"failed":  failed,
+	}).Info("Retried unknown logs")
+
+	return nil
+}
+
+// eventsByContract groups events by emitting contract, keeping their order
+func eventsByContract(events []*models.Event) map[models.Address][]*models.Event {
+	grouped := make(map[models.Address][]*models.Event)
+	for _, event := range events {
+		grouped[event.ContractAddress] = append(grouped[event.ContractAddress], event)
+	}
+	return grouped
+}
+
+// decodeUnknownLog decodes the raw log kept by an unknown log
+func decodeUnknownLog(eventParser *parser.EventParser, unknown *models.UnknownLog) (*models.Event, error) {
+	log, err := parser.UnknownLogToLog(unknown)
+	if err != nil {
+		return nil, err
+	}
+	return eventParser.ParseLog(log, unknown.BlockTimestamp)
+}
@@ -0,0 +1,321 @@
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/smart-contract-event-indexer/indexer-service/internal/storage"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature" // "sha256=" + hex HMAC of "<timestamp>.<body>"
+	WebhookTimestampHeader = "X-Webhook-Timestamp" // unix seconds the payload was signed at
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"  // delivery ID, stable across retries
+)
+
+const (
+	// webhookRefreshInterval is how long the set of active webhooks is cached
+	webhookRefreshInterval = 30 * time.Second
+	// webhookClaimBatch is how many due deliveries are claimed at a time
+	webhookClaimBatch = 50
+	// webhookErrorBodyLimit caps how much of a failed response is logged
+	webhookErrorBodyLimit = 512
+)
+
+// DefaultWebhookRetryPolicy returns the retry policy for webhook deliveries.
+// Receivers may be down for a while, so it retries longer than RPC calls.
+func DefaultWebhookRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 8
+	policy.InitialDelay = 5 * time.Second
+	policy.MaxDelay = time.Hour
+	return policy
+}
+
+// WebhookPayload is the JSON body POSTed to webhook receivers
+type WebhookPayload struct {
+	DeliveryID int64        `json:"deliveryId"`
+	WebhookID  string       `json:"webhookId"`
+	Attempt    int          `json:"attempt"`
+	Event      models.JSONB `json:"event"`
+}
+
+// webhookResponseError is returned for a non-2xx response. Deliveries failing
+// with it are classified by status code: 408, 429 and 5xx are retried and
+// other statuses are permanent.
+type webhookResponseError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *webhookResponseError) Error() string {
+	return fmt.Sprintf("receiver responded with status %d: %s", e.StatusCode, e.Body)
+}
+
+// SignWebhookPayload computes the signature header value for a payload signed
+// at the given unix timestamp
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks a signature produced by SignWebhookPayload.
+// Receivers should also reject timestamps that are too old to prevent replays.
+func VerifyWebhookSignature(secret string, timestamp int64, body []byte, signature string) bool {
+	expected := SignWebhookPayload(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// WebhookDispatcher matches newly indexed events against webhook subscriptions
+// and delivers them. Deliveries are queued in the database, so retries survive
+// restarts; deliveries that fail for good are kept as dead letters.
+type WebhookDispatcher struct {
+	storage      *storage.WebhookStorage
+	httpClient   *http.Client
+	classifier   *ErrorClassifier
+	policy       *RetryPolicy
+	pollInterval time.Duration
+	logger       utils.Logger
+
+	mu       sync.Mutex
+	webhooks map[int64][]*models.Webhook // active webhooks by chain
+	loadedAt map[int64]time.Time
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(
+	webhookStorage *storage.WebhookStorage,
+	policy *RetryPolicy,
+	timeout time.Duration,
+	pollInterval time.Duration,
+	logger utils.Logger,
+) *WebhookDispatcher {
+	if policy == nil {
+		policy = DefaultWebhookRetryPolicy()
+	}
+	return &WebhookDispatcher{
+		storage:      webhookStorage,
+		httpClient:   &http.Client{Timeout: timeout},
+		classifier:   NewErrorClassifier(policy, logger),
+		policy:       policy,
+		pollInterval: pollInterval,
+		logger:       logger,
+		webhooks:     make(map[int64][]*models.Webhook),
+		loadedAt:     make(map[int64]time.Time),
+	}
+}
+
+// Match returns a delivery for every active webhook matching each event. It
+// is a storage.DeliveryMatcher: events must already be stored (have an ID),
+// and the deliveries are queued in the transaction that stores them.
+func (d *WebhookDispatcher) Match(ctx context.Context, events []*models.Event) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	for _, event := range events {
+		webhooks, err := d.activeWebhooks(ctx, event.ChainID)
+		if err != nil {
+			return nil, err
+		}
+		for _, webhook := range webhooks {
+			if !webhook.Matches(event) {
+				continue
+			}
+			payload, err := eventSnapshot(event)
+			if err != nil {
+				return nil, err
+			}
+			deliveries = append(deliveries, &models.WebhookDelivery{
+				WebhookID: webhook.ID,
+				EventID:   event.ID,
+				Payload:   payload,
+			})
+		}
+	}
+
+	if len(deliveries) > 0 {
+		d.logger.WithField("count", len(deliveries)).Debug("Webhook deliveries matched")
+	}
+	return deliveries, nil
+}
+
+// activeWebhooks returns the cached active webhooks on a chain, reloading them
+// once the cache is older than webhookRefreshInterval
+func (d *WebhookDispatcher) activeWebhooks(ctx context.Context, chainID int64) ([]*models.Webhook, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if loaded, ok := d.loadedAt[chainID]; ok && time.Since(loaded) < webhookRefreshInterval {
+		return d.webhooks[chainID], nil
+	}
+
+	webhooks, err := d.storage.GetActiveWebhooks(ctx, []int64{chainID})
+	if err != nil {
+		return nil, err
+	}
+	d.webhooks[chainID] = webhooks
+	d.loadedAt[chainID] = time.Now()
+	return webhooks, nil
+}
+
+// eventSnapshot converts an event to the JSON object stored with its deliveries
+func eventSnapshot(event *models.Event) (models.JSONB, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	var snapshot models.JSONB
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Start delivers due webhook deliveries until ctx is cancelled
+func (d *WebhookDispatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	d.logger.WithField("poll_interval", d.pollInterval).Info("Webhook dispatcher started")
+
+	for {
+		// Drain due deliveries before waiting for the next tick
+		for {
+			n, err := d.deliverDue(ctx)
+			if err != nil {
+				d.logger.WithError(err).Error("Error delivering webhooks")
+			}
+			if n < webhookClaimBatch || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Webhook dispatcher stopping")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverDue claims one batch of due deliveries and attempts each of them.
+// Returns the number of deliveries claimed.
+func (d *WebhookDispatcher) deliverDue(ctx context.Context) (int, error) {
+	// Lease claims for longer than a single attempt can take
+	deliveries, err := d.storage.ClaimDueDeliveries(ctx, webhookClaimBatch, d.httpClient.Timeout+time.Minute)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := d.attempt(ctx, delivery); err != nil {
+			d.logger.WithError(err).WithField("delivery_id", delivery.ID).Error("Failed to record webhook delivery outcome")
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// attempt sends one delivery and records the outcome: delivered, scheduled for
+// a retry, or moved to the dead-letter store
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *storage.ClaimedDelivery) error {
+	fields := map[string]interface{}{
+		"delivery_id": delivery.ID,
+		"webhook_id":  delivery.WebhookID,
+		"event_id":    delivery.EventID,
+		"attempt":     delivery.Attempts,
+	}
+
+	statusCode, err := d.Send(ctx, delivery.URL, delivery.Secret, &WebhookPayload{
+		DeliveryID: delivery.ID,
+		WebhookID:  delivery.WebhookID,
+		Attempt:    delivery.Attempts,
+		Event:      delivery.Payload,
+	})
+	if err == nil {
+		d.logger.WithFields(fields).Debug("Webhook delivered")
+		return d.storage.MarkDelivered(ctx, delivery.ID, statusCode)
+	}
+
+	errType := d.classify(err)
+	if errType == ErrorTypePermanent || delivery.Attempts >= d.policy.MaxAttempts {
+		d.logger.WithFields(fields).WithError(err).Warn("Webhook delivery moved to dead letters")
+		return d.storage.MarkDeadLetter(ctx, delivery.ID, statusCode, err.Error())
+	}
+
+	delay := d.classifier.GetRetryDelay(delivery.Attempts, errType)
+	d.logger.WithFields(fields).WithError(err).WithField("retry_in", delay.String()).Info("Webhook delivery failed, will retry")
+	return d.storage.ScheduleRetry(ctx, delivery.ID, time.Now().Add(delay), statusCode, err.Error())
+}
+
+// classify returns the error type of a failed delivery. Responses are
+// classified by status code, so a rejection whose body mentions a timeout or
+// a connection is not retried; other errors go to the ErrorClassifier.
+func (d *WebhookDispatcher) classify(err error) ErrorType {
+	var respErr *webhookResponseError
+	if !errors.As(err, &respErr) {
+		return d.classifier.ClassifyError(err)
+	}
+	switch {
+	case respErr.StatusCode == http.StatusTooManyRequests:
+		return ErrorTypeRateLimit
+	case respErr.StatusCode == http.StatusRequestTimeout, respErr.StatusCode >= 500:
+		return ErrorTypeTransient
+	default:
+		return ErrorTypePermanent
+	}
+}
+
+// Send POSTs a signed payload to a webhook receiver. It returns the response
+// status code (0 if no response was received) and an error unless the
+// receiver answered with a 2xx status.
+func (d *WebhookDispatcher) Send(ctx context.Context, url, secret string, payload *WebhookPayload) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, timestamp, body))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(payload.DeliveryID, 10))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+	return resp.StatusCode, &webhookResponseError{StatusCode: resp.StatusCode, Body: string(snippet)}
+}
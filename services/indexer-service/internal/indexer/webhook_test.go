@@ -0,0 +1,135 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/smart-contract-event-indexer/indexer-service/internal/testutil"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+func newTestDispatcher() *WebhookDispatcher {
+	return NewWebhookDispatcher(nil, nil, 5*time.Second, time.Second, testutil.NewTestLogger())
+}
+
+func TestWebhookSignature(t *testing.T) {
+	body := []byte(`{"deliveryId":1}`)
+	signature := SignWebhookPayload("secret", 1700000000, body)
+
+	if !VerifyWebhookSignature("secret", 1700000000, body, signature) {
+		t.Fatal("expected signature to verify")
+	}
+	if VerifyWebhookSignature("other", 1700000000, body, signature) {
+		t.Error("signature verified with the wrong secret")
+	}
+	if VerifyWebhookSignature("secret", 1700000001, body, signature) {
+		t.Error("signature verified with a different timestamp")
+	}
+	if VerifyWebhookSignature("secret", 1700000000, []byte(`{"deliveryId":2}`), signature) {
+		t.Error("signature verified for a tampered body")
+	}
+}
+
+func TestWebhookDispatcher_SendSignsPayload(t *testing.T) {
+	var received WebhookPayload
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(WebhookTimestampHeader), 10, 64)
+		if err != nil {
+			t.Errorf("bad timestamp header: %v", err)
+		}
+		if !VerifyWebhookSignature("secret", timestamp, body, r.Header.Get(WebhookSignatureHeader)) {
+			t.Error("receiver could not verify the signature")
+		}
+		if got := r.Header.Get(WebhookDeliveryHeader); got != "42" {
+			t.Errorf("delivery header = %q, want 42", got)
+		}
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("bad payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	payload := &WebhookPayload{
+		DeliveryID: 42,
+		WebhookID:  "hook",
+		Attempt:    1,
+		Event:      models.JSONB{"eventName": "Transfer"},
+	}
+	status, err := newTestDispatcher().Send(context.Background(), receiver.URL, "secret", payload)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if status != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", status, http.StatusNoContent)
+	}
+	if received.DeliveryID != 42 || received.Event["eventName"] != "Transfer" {
+		t.Errorf("unexpected payload received: %+v", received)
+	}
+}
+
+func TestWebhookDispatcher_SendClassifiesFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		retriable bool
+	}{
+		{"server error", http.StatusInternalServerError, "", true},
+		{"unavailable", http.StatusServiceUnavailable, "", true},
+		{"rate limited", http.StatusTooManyRequests, "", true},
+		{"request timeout", http.StatusRequestTimeout, "", true},
+		{"bad request", http.StatusBadRequest, "", false},
+		{"gone", http.StatusGone, "", false},
+		{"rejection mentioning a timeout", http.StatusBadRequest, "field timeout is required", false},
+		{"rejection mentioning a connection", http.StatusUnprocessableEntity, "unknown connection id", false},
+		{"server error with a plain body", http.StatusBadGateway, "invalid payload", true},
+	}
+
+	dispatcher := newTestDispatcher()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer receiver.Close()
+
+			status, err := dispatcher.Send(context.Background(), receiver.URL, "secret", &WebhookPayload{DeliveryID: 1})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+			if got := dispatcher.classify(err) != ErrorTypePermanent; got != tt.retriable {
+				t.Errorf("retriable(%q) = %v, want %v", err, got, tt.retriable)
+			}
+		})
+	}
+}
+
+func TestWebhookDispatcher_SendUnreachableIsRetriable(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	url := receiver.URL
+	receiver.Close()
+
+	dispatcher := newTestDispatcher()
+	status, err := dispatcher.Send(context.Background(), url, "secret", &WebhookPayload{DeliveryID: 1})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if status != 0 {
+		t.Errorf("status = %d, want 0", status)
+	}
+	if dispatcher.classify(err) == ErrorTypePermanent {
+		t.Errorf("expected %q to be retriable", err)
+	}
+}
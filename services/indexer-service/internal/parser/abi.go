@@ -3,6 +3,7 @@ package parser
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -10,12 +11,18 @@ import (
 	"github.com/smart-contract-event-indexer/shared/utils"
 )
 
-// ABIParser handles ABI parsing and event definition extraction
+// ABIParser handles ABI parsing and event definition extraction. Overloaded
+// events share a name but not a signature, so events are looked up by full
+// signature, e.g. Transfer(address,address,uint256), and a bare name only
+// resolves when a single event carries it. Anonymous events have no topic0
+// and are kept apart for matching by layout.
 type ABIParser struct {
-	contractABI abi.ABI
-	eventsByID  map[string]abi.Event // Map of event ID (topic0) to event
-	eventsByName map[string]abi.Event // Map of event name to event
-	logger      utils.Logger
+	contractABI       abi.ABI
+	eventsByID        map[string]abi.Event   // Map of event ID (topic0) to event, anonymous events excluded
+	eventsBySignature map[string]abi.Event   // Map of full signature to event
+	eventsByName      map[string][]abi.Event // Map of event name to its overloads, ordered by signature
+	anonymousEvents   []abi.Event            // ordered by signature
+	logger            utils.Logger
 }
 
 // NewABIParser creates a new ABI parser
@@ -27,21 +34,36 @@ func NewABIParser(abiJSON string, logger utils.Logger) (*ABIParser, error) {
 	}
 	
 	parser := &ABIParser{
-		contractABI:  contractABI,
-		eventsByID:   make(map[string]abi.Event),
-		eventsByName: make(map[string]abi.Event),
-		logger:       logger,
+		contractABI:       contractABI,
+		eventsByID:        make(map[string]abi.Event),
+		eventsBySignature: make(map[string]abi.Event),
+		eventsByName:      make(map[string][]abi.Event),
+		logger:            logger,
 	}
 	
-	// Build event maps
+	// Build event maps. go-ethereum renames overloads (Transfer0, Transfer1);
+	// RawName is the name declared in the ABI.
+	events := make([]abi.Event, 0, len(contractABI.Events))
 	for _, event := range contractABI.Events {
-		eventID := event.ID.Hex()
-		parser.eventsByID[eventID] = event
-		parser.eventsByName[event.Name] = event
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Sig < events[j].Sig
+	})
+	for _, event := range events {
+		parser.eventsBySignature[event.Sig] = event
+		parser.eventsByName[event.RawName] = append(parser.eventsByName[event.RawName], event)
+		if event.Anonymous {
+			parser.anonymousEvents = append(parser.anonymousEvents, event)
+		} else {
+			parser.eventsByID[event.ID.Hex()] = event
+		}
 		
 		logger.WithFields(map[string]interface{}{
-			"event_name": event.Name,
-			"event_id":   eventID,
+			"event_name": event.RawName,
+			"signature":  event.Sig,
+			"event_id":   event.ID.Hex(),
+			"anonymous":  event.Anonymous,
 		}).Debug("Registered event")
 	}
 	
@@ -56,28 +78,67 @@ func (p *ABIParser) GetEventByID(eventID string) (abi.Event, bool) {
 	return event, exists
 }
 
-// GetEventByName returns an event by its name
+// GetEventByName returns an event by its full signature, or by its name when
+// the name is not overloaded
 func (p *ABIParser) GetEventByName(name string) (abi.Event, bool) {
-	event, exists := p.eventsByName[name]
-	return event, exists
+	if event, exists := p.eventsBySignature[name]; exists {
+		return event, true
+	}
+	overloads := p.eventsByName[name]
+	if len(overloads) != 1 {
+		return abi.Event{}, false
+	}
+	return overloads[0], true
+}
+
+// GetEventsByName returns the events matching a full signature, or every
+// overload of a name, ordered by signature
+func (p *ABIParser) GetEventsByName(name string) []abi.Event {
+	if event, exists := p.eventsBySignature[name]; exists {
+		return []abi.Event{event}
+	}
+	return p.eventsByName[name]
 }
 
-// GetAllEvents returns all events in the ABI
+// GetAllEvents returns all events in the ABI, overloads included, ordered by
+// signature
 func (p *ABIParser) GetAllEvents() []abi.Event {
-	events := make([]abi.Event, 0, len(p.eventsByName))
-	for _, event := range p.eventsByName {
-		events = append(events, event)
+	events := make([]abi.Event, 0, len(p.eventsBySignature))
+	for _, overloads := range p.eventsByName {
+		events = append(events, overloads...)
 	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Sig < events[j].Sig
+	})
 	return events
 }
 
-// GetEventSignature returns the signature hash for an event
+// GetAnonymousEvents returns the anonymous events of the ABI, ordered by
+// signature
+func (p *ABIParser) GetAnonymousEvents() []abi.Event {
+	return p.anonymousEvents
+}
+
+// GetEventSignature returns the signature hash for an event, named by its
+// full signature or by a name that is not overloaded
 func (p *ABIParser) GetEventSignature(eventName string) (string, error) {
-	event, exists := p.eventsByName[eventName]
-	if !exists {
-		return "", fmt.Errorf("event %s not found in ABI", eventName)
+	event, exists := p.GetEventByName(eventName)
+	if exists {
+		if event.Anonymous {
+			return "", fmt.Errorf("event %s is anonymous and has no signature topic", eventName)
+		}
+		return event.ID.Hex(), nil
+	}
+	
+	overloads := p.eventsByName[eventName]
+	if len(overloads) > 1 {
+		signatures := make([]string, len(overloads))
+		for k, event := range overloads {
+			signatures[k] = event.Sig
+		}
+		return "", fmt.Errorf("event %s is overloaded, use one of its signatures: %s", eventName, strings.Join(signatures, ", "))
 	}
-	return event.ID.Hex(), nil
+	return "", fmt.Errorf("event %s not found in ABI", eventName)
 }
 
 // ValidateABI checks if the ABI is valid and contains events
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/smart-contract-event-indexer/indexer-service/internal/testutil"
@@ -191,3 +192,58 @@ func TestABIParser_EventInputTypes(t *testing.T) {
 	}
 }
 
+func TestABIParser_OverloadedEvents(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	parser, err := NewABIParser(testutil.OverloadedABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	
+	// Both overloads are kept under the declared name
+	if overloads := parser.GetEventsByName("Transfer"); len(overloads) != 2 {
+		t.Fatalf("Expected 2 Transfer overloads, got: %d", len(overloads))
+	}
+	if len(parser.eventsByID) != 2 {
+		t.Errorf("Expected the 2 overloads in eventsByID and no anonymous events, got: %d", len(parser.eventsByID))
+	}
+	
+	// A bare overloaded name is ambiguous; the full signature is not
+	if _, found := parser.GetEventByName("Transfer"); found {
+		t.Error("Expected an overloaded name not to resolve to a single event")
+	}
+	signature := "Transfer(address,address,uint256,bytes)"
+	event, found := parser.GetEventByName(signature)
+	if !found {
+		t.Fatalf("Expected to find %s", signature)
+	}
+	if event.RawName != "Transfer" || event.Sig != signature {
+		t.Errorf("Expected Transfer declared as %s, got %s declared as %s", signature, event.RawName, event.Sig)
+	}
+	
+	if _, err := parser.GetEventSignature("Transfer"); err == nil || !strings.Contains(err.Error(), "overloaded") {
+		t.Errorf("Expected an overloaded event error, got: %v", err)
+	}
+	eventID, err := parser.GetEventSignature(signature)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if eventID != EventSignatureToID(signature) {
+		t.Errorf("Expected event ID %s, got: %s", EventSignatureToID(signature), eventID)
+	}
+}
+
+func TestABIParser_AnonymousEvents(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	parser, err := NewABIParser(testutil.OverloadedABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	
+	anonymous := parser.GetAnonymousEvents()
+	if len(anonymous) != 2 || anonymous[0].RawName != "Deposit" || anonymous[1].RawName != "Settled" {
+		t.Fatalf("Expected Deposit and Settled as anonymous events, got: %v", anonymous)
+	}
+	if _, err := parser.GetEventSignature("Deposit"); err == nil {
+		t.Error("Expected an error for the signature topic of an anonymous event")
+	}
+}
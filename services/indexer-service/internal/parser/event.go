@@ -1,9 +1,13 @@
 package parser
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
 	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -13,10 +17,21 @@ import (
 	"github.com/smart-contract-event-indexer/shared/utils"
 )
 
-// EventParser parses blockchain logs into structured events
+// EventParser parses blockchain logs into structured events. The ABI of an
+// upgradeable proxy changes with its implementation, so besides the ABI it
+// starts with an EventParser holds later ABI versions, and each log is
+// decoded with the version in effect at its block.
 type EventParser struct {
+	abiParser       *ABIParser
+	versions        []abiVersion // ascending fromBlock
+	decodeAnonymous bool
+	logger          utils.Logger
+}
+
+// abiVersion is an ABI in effect from fromBlock until the next version
+type abiVersion struct {
+	fromBlock uint64
 	abiParser *ABIParser
-	logger    utils.Logger
 }
 
 // NewEventParser creates a new event parser
@@ -27,52 +42,80 @@ func NewEventParser(abiParser *ABIParser, logger utils.Logger) *EventParser {
 	}
 }
 
+// AddVersion decodes the logs from fromBlock onwards with abiParser, until a
+// later version takes over
+func (p *EventParser) AddVersion(fromBlock uint64, abiParser *ABIParser) {
+	k := sort.Search(len(p.versions), func(k int) bool {
+		return p.versions[k].fromBlock > fromBlock
+	})
+	p.versions = append(p.versions, abiVersion{})
+	copy(p.versions[k+1:], p.versions[k:])
+	p.versions[k] = abiVersion{fromBlock: fromBlock, abiParser: abiParser}
+}
+
+// DecodeAnonymousEvents makes the parser decode logs whose topic0 is not in
+// the ABI by matching them against the ABI's anonymous events. A match is
+// inferred from the log's layout, so contracts opt in.
+func (p *EventParser) DecodeAnonymousEvents() {
+	p.decodeAnonymous = true
+}
+
+// parserAt returns the ABI parser in effect at a block
+func (p *EventParser) parserAt(block uint64) *ABIParser {
+	abiParser := p.abiParser
+	for _, version := range p.versions {
+		if version.fromBlock > block {
+			break
+		}
+		abiParser = version.abiParser
+	}
+	return abiParser
+}
+
+// abiParsers returns the parsers of every ABI version, oldest first
+func (p *EventParser) abiParsers() []*ABIParser {
+	parsers := make([]*ABIParser, 0, len(p.versions)+1)
+	parsers = append(parsers, p.abiParser)
+	for _, version := range p.versions {
+		parsers = append(parsers, version.abiParser)
+	}
+	return parsers
+}
+
 // ParseLog converts a blockchain log into a structured Event
 func (p *EventParser) ParseLog(log types.Log, blockTimestamp time.Time) (*models.Event, error) {
-	if len(log.Topics) == 0 {
-		return nil, fmt.Errorf("log has no topics")
-	}
-	
-	// Get the event definition from the ABI
-	eventID := log.Topics[0].Hex()
-	event, exists := p.abiParser.GetEventByID(eventID)
-	if !exists {
-		return nil, fmt.Errorf("event with ID %s not found in ABI", eventID)
+	// Get the event definition from the ABI in effect at the log's block
+	event, err := p.eventForLog(log)
+	if err != nil {
+		return nil, err
 	}
 	
 	// Parse the event arguments
-	args, err := p.parseEventArgs(event, log)
+	args, argTypes, err := p.parseEventArgs(event, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse event args: %w", err)
 	}
 	
-	// Convert args to JSONB format
-	argsJSON, err := json.Marshal(args)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal args to JSON: %w", err)
-	}
-	
-	// Unmarshal into JSONB map
-	var argsMap models.JSONB
-	if err := json.Unmarshal(argsJSON, &argsMap); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal args to JSONB: %w", err)
-	}
-	
 	// Create the Event model
 	parsedEvent := &models.Event{
 		ContractAddress:  models.Address(log.Address.Hex()),
-		EventName:        event.Name,
+		EventName:        event.RawName,
+		EventSignature:   event.Sig,
 		BlockNumber:      int64(log.BlockNumber),
 		BlockHash:        models.Hash(log.BlockHash.Hex()),
 		TransactionHash:  models.Hash(log.TxHash.Hex()),
 		TransactionIndex: int(log.TxIndex),
 		LogIndex:         int(log.Index),
-		Args:             argsMap,
+		Args:             args,
+		ArgTypes:         argTypes,
 		Timestamp:        blockTimestamp,
 	}
+	rawLog := EncodeRawLog(log)
+	parsedEvent.RawLog = &rawLog
 	
 	p.logger.WithFields(map[string]interface{}{
-		"event_name":  event.Name,
+		"event_name":  event.RawName,
+		"signature":   event.Sig,
 		"block":       log.BlockNumber,
 		"tx":          log.TxHash.Hex(),
 		"log_index":   log.Index,
@@ -81,51 +124,162 @@ func (p *EventParser) ParseLog(log types.Log, blockTimestamp time.Time) (*models
 	return parsedEvent, nil
 }
 
-// ParseLogs parses multiple logs
-func (p *EventParser) ParseLogs(logs []types.Log, blockTimestamp time.Time) ([]*models.Event, error) {
+// eventForLog finds the ABI event of a log in the ABI in effect at its block:
+// by topic0, or, when the parser decodes anonymous events, by matching the
+// ABI's anonymous events against the log
+func (p *EventParser) eventForLog(log types.Log) (abi.Event, error) {
+	abiParser := p.parserAt(log.BlockNumber)
+	if len(log.Topics) > 0 {
+		if event, exists := abiParser.GetEventByID(log.Topics[0].Hex()); exists {
+			return event, nil
+		}
+	}
+	
+	if p.decodeAnonymous {
+		return matchAnonymousEvent(abiParser.GetAnonymousEvents(), log)
+	}
+	if len(log.Topics) == 0 {
+		return abi.Event{}, fmt.Errorf("log has no topics")
+	}
+	return abi.Event{}, fmt.Errorf("event with ID %s not found in ABI", log.Topics[0].Hex())
+}
+
+// matchAnonymousEvent finds the anonymous event whose layout fits a log: one
+// topic per indexed argument, each a valid encoding of its type, and data
+// that decodes and encodes back to exactly the log's data. A log that fits
+// several events is ambiguous and left undecoded.
+func matchAnonymousEvent(candidates []abi.Event, log types.Log) (abi.Event, error) {
+	var matches []abi.Event
+	for _, event := range candidates {
+		if fitsLayout(event, log) {
+			matches = append(matches, event)
+		}
+	}
+	
+	switch len(matches) {
+	case 0:
+		return abi.Event{}, fmt.Errorf("no event in ABI matches the log's topic0, and no anonymous event fits its %d topics and %d bytes of data", len(log.Topics), len(log.Data))
+	case 1:
+		return matches[0], nil
+	}
+	signatures := make([]string, len(matches))
+	for k, event := range matches {
+		signatures[k] = event.Sig
+	}
+	return abi.Event{}, fmt.Errorf("log fits several anonymous events: %s", strings.Join(signatures, ", "))
+}
+
+// fitsLayout reports whether a log can be an emission of an anonymous event
+func fitsLayout(event abi.Event, log types.Log) bool {
+	topic := 0
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		if topic >= len(log.Topics) || !topicFits(input.Type, log.Topics[topic]) {
+			return false
+		}
+		topic++
+	}
+	if topic != len(log.Topics) {
+		return false
+	}
+	
+	nonIndexed := event.Inputs.NonIndexed()
+	values, err := nonIndexed.Unpack(log.Data)
+	if err != nil {
+		return false
+	}
+	packed, err := nonIndexed.Pack(values...)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(packed, log.Data)
+}
+
+// topicFits reports whether a topic is a valid encoding of an indexed
+// argument. Dynamic types are hashed, so any topic fits them.
+func topicFits(typ abi.Type, topic common.Hash) bool {
+	switch typ.T {
+	case abi.AddressTy:
+		return topic.Big().BitLen() <= 160
+	case abi.BoolTy:
+		return topic.Big().BitLen() <= 1
+	case abi.UintTy:
+		return topic.Big().BitLen() <= typ.Size
+	default:
+		return true
+	}
+}
+
+// ParseLogs parses multiple logs. blockTimestamps maps each log's block hash to
+// the timestamp of that block; every log's block must be present. Logs that
+// cannot be decoded are returned as unknown logs, with the reason, instead of
+// events.
+func (p *EventParser) ParseLogs(logs []types.Log, blockTimestamps map[common.Hash]time.Time) ([]*models.Event, []*models.UnknownLog, error) {
 	events := make([]*models.Event, 0, len(logs))
+	var unknown []*models.UnknownLog
 	
 	for _, log := range logs {
+		blockTimestamp, ok := blockTimestamps[log.BlockHash]
+		if !ok {
+			return nil, nil, fmt.Errorf("no timestamp for block %d (%s)", log.BlockNumber, log.BlockHash.Hex())
+		}
+		
 		event, err := p.ParseLog(log, blockTimestamp)
 		if err != nil {
 			p.logger.WithError(err).WithFields(map[string]interface{}{
 				"block":     log.BlockNumber,
 				"tx":        log.TxHash.Hex(),
 				"log_index": log.Index,
-			}).Warn("Failed to parse log, skipping")
+			}).Warn("Failed to parse log, keeping it as unknown")
+			unknown = append(unknown, NewUnknownLog(log, blockTimestamp, err))
 			continue
 		}
 		events = append(events, event)
 	}
 	
-	return events, nil
+	return events, unknown, nil
 }
 
-// parseEventArgs extracts and parses event arguments from a log
-func (p *EventParser) parseEventArgs(event abi.Event, log types.Log) (map[string]interface{}, error) {
+// parseEventArgs extracts and parses event arguments from a log, along with
+// their ABI declarations in ABI order
+func (p *EventParser) parseEventArgs(event abi.Event, log types.Log) (models.JSONB, models.ArgTypes, error) {
 	// Unpack the event data
 	eventData := make(map[string]interface{})
 	
 	// Parse indexed and non-indexed arguments
 	if len(log.Data) > 0 {
 		if err := event.Inputs.UnpackIntoMap(eventData, log.Data); err != nil {
-			return nil, fmt.Errorf("failed to unpack event data: %w", err)
+			return nil, nil, fmt.Errorf("failed to unpack event data: %w", err)
 		}
 	}
 	
-	// Parse indexed arguments from topics (skip topic[0] which is the event signature)
+	// Parse indexed arguments from topics (skip topic[0] which is the event
+	// signature, except for anonymous events, which have none)
+	argTypes := make(models.ArgTypes, len(event.Inputs))
+	firstTopic := 1
+	if event.Anonymous {
+		firstTopic = 0
+	}
 	indexedIndex := 0
 	for i, input := range event.Inputs {
+		argTypes[i] = models.ArgType{
+			Name:    input.Name,
+			Type:    input.Type.String(),
+			Indexed: input.Indexed,
+		}
+		
 		if input.Indexed {
-			topicIndex := indexedIndex + 1 // +1 to skip event signature
+			topicIndex := indexedIndex + firstTopic
 			if topicIndex >= len(log.Topics) {
-				return nil, fmt.Errorf("not enough topics for indexed parameter %s", input.Name)
+				return nil, nil, fmt.Errorf("not enough topics for indexed parameter %s", input.Name)
 			}
 			
 			// Parse the indexed value
 			value, err := p.parseIndexedValue(input, log.Topics[topicIndex])
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse indexed parameter %s: %w", input.Name, err)
+				return nil, nil, fmt.Errorf("failed to parse indexed parameter %s: %w", input.Name, err)
 			}
 			
 			eventData[input.Name] = value
@@ -138,16 +292,17 @@ func (p *EventParser) parseEventArgs(event abi.Event, log types.Log) (map[string
 				delete(eventData, input.Name)
 				eventData[fmt.Sprintf("arg%d", i)] = val
 			}
+			argTypes[i].Name = fmt.Sprintf("arg%d", i)
 		}
 	}
 	
 	// Convert values to JSON-serializable format
-	serializable := make(map[string]interface{})
+	serializable := make(models.JSONB, len(eventData))
 	for key, value := range eventData {
 		serializable[key] = p.convertToSerializable(value)
 	}
 	
-	return serializable, nil
+	return serializable, argTypes, nil
 }
 
 // parseIndexedValue parses an indexed parameter from a topic
@@ -179,9 +334,15 @@ func (p *EventParser) parseIndexedValue(input abi.Argument, topic common.Hash) (
 	}
 }
 
-// convertToSerializable converts ABI values to JSON-serializable types
+// convertToSerializable converts ABI values to JSON-serializable types.
+// Integers of every size become decimal strings so no value is rounded
+// through a float, byte arrays become hex, and tuples become maps keyed by
+// component name.
 func (p *EventParser) convertToSerializable(value interface{}) interface{} {
 	switch v := value.(type) {
+	case nil:
+		return nil
+		
 	case *big.Int:
 		// Convert big integers to strings to preserve precision
 		return v.String()
@@ -197,8 +358,8 @@ func (p *EventParser) convertToSerializable(value interface{}) interface{} {
 		// Convert bytes to hex string
 		return common.Bytes2Hex(v)
 		
-	case [32]byte:
-		return common.Bytes2Hex(v[:])
+	case string, bool:
+		return v
 		
 	case []interface{}:
 		// Recursively convert arrays
@@ -215,42 +376,83 @@ func (p *EventParser) convertToSerializable(value interface{}) interface{} {
 			result[key] = p.convertToSerializable(val)
 		}
 		return result
+	}
+	
+	// The ABI decoder builds fixed bytes, small integers, arrays and tuples
+	// as typed Go values, so the remaining cases go by kind
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+		
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+		
+	case reflect.Array, reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			// bytes1 to bytes32
+			data := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(data), rv)
+			return common.Bytes2Hex(data)
+		}
+		result := make([]interface{}, rv.Len())
+		for i := range result {
+			result[i] = p.convertToSerializable(rv.Index(i).Interface())
+		}
+		return result
+		
+	case reflect.Struct:
+		// Tuple components carry their ABI name in the json tag
+		result := make(map[string]interface{}, rv.NumField())
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			name := field.Tag.Get("json")
+			if name == "" {
+				name = field.Name
+			}
+			result[name] = p.convertToSerializable(rv.Field(i).Interface())
+		}
+		return result
+		
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+		return p.convertToSerializable(rv.Elem().Interface())
 		
 	default:
-		// Return as-is for basic types (string, int, bool, etc.)
-		return v
+		return value
 	}
 }
 
 // GetEventName returns the event name from a log
 func (p *EventParser) GetEventName(log types.Log) (string, error) {
-	if len(log.Topics) == 0 {
-		return "", fmt.Errorf("log has no topics")
-	}
-	
-	eventID := log.Topics[0].Hex()
-	event, exists := p.abiParser.GetEventByID(eventID)
-	if !exists {
-		return "", fmt.Errorf("event with ID %s not found in ABI", eventID)
+	event, err := p.eventForLog(log)
+	if err != nil {
+		return "", err
 	}
 	
-	return event.Name, nil
+	return event.RawName, nil
 }
 
 // IsEventInABI checks if a log's event is defined in the ABI
 func (p *EventParser) IsEventInABI(log types.Log) bool {
-	if len(log.Topics) == 0 {
-		return false
-	}
-	
-	eventID := log.Topics[0].Hex()
-	_, exists := p.abiParser.GetEventByID(eventID)
-	return exists
+	_, err := p.eventForLog(log)
+	return err == nil
 }
 
-// GetEventInputNames returns the input names for an event
+// GetEventInputNames returns the input names for an event, named by its full
+// signature or by a name that is not overloaded, as declared in the latest ABI
+// version that has it
 func (p *EventParser) GetEventInputNames(eventName string) ([]string, error) {
-	event, exists := p.abiParser.GetEventByName(eventName)
+	parsers := p.abiParsers()
+	var (
+		event  abi.Event
+		exists bool
+	)
+	for k := len(parsers) - 1; k >= 0 && !exists; k-- {
+		event, exists = parsers[k].GetEventByName(eventName)
+	}
 	if !exists {
 		return nil, fmt.Errorf("event %s not found in ABI", eventName)
 	}
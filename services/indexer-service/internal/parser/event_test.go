@@ -1,11 +1,16 @@
 package parser
 
 import (
+	"bytes"
+	"errors"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/smart-contract-event-indexer/indexer-service/internal/testutil"
 	"github.com/smart-contract-event-indexer/shared/models"
 )
@@ -138,6 +143,184 @@ func TestEventParser_ParseLog_TransferArgs(t *testing.T) {
 	}
 }
 
+func TestEventParser_ParseLog_ArgTypes(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	abiParser, err := NewABIParser(testutil.ERC20ABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	eventParser := NewEventParser(abiParser, logger)
+	parsedEvent, err := eventParser.ParseLog(testutil.CreateMockTransferLog(), time.Now())
+	if err != nil {
+		t.Fatalf("Failed to parse log: %v", err)
+	}
+	
+	// Types are kept in ABI order, with the indexed flag of each argument
+	expected := models.ArgTypes{
+		{Name: "from", Type: "address", Indexed: true},
+		{Name: "to", Type: "address", Indexed: true},
+		{Name: "value", Type: "uint256", Indexed: false},
+	}
+	if len(parsedEvent.ArgTypes) != len(expected) {
+		t.Fatalf("Expected %d arg types, got: %+v", len(expected), parsedEvent.ArgTypes)
+	}
+	for k, argType := range expected {
+		if parsedEvent.ArgTypes[k] != argType {
+			t.Errorf("Arg type %d: expected %+v, got %+v", k, argType, parsedEvent.ArgTypes[k])
+		}
+	}
+	
+	ordered := parsedEvent.OrderedArgs()
+	if len(ordered) != 3 || ordered[0].Name != "from" || ordered[2].Type != "uint256" {
+		t.Errorf("Expected args in ABI order, got: %+v", ordered)
+	}
+}
+
+func TestEventParser_ParseLog_SmallIntegersAndFixedBytes(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	abiJSON := `[{"type":"event","name":"Tagged","anonymous":false,"inputs":[
+		{"name":"tag","type":"bytes4","indexed":false},
+		{"name":"decimals","type":"uint8","indexed":false},
+		{"name":"","type":"int64","indexed":false}
+	]}]`
+	abiParser, err := NewABIParser(abiJSON, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	var data []byte
+	data = append(data, common.RightPadBytes([]byte{0xde, 0xad, 0xbe, 0xef}, 32)...)
+	data = append(data, common.LeftPadBytes([]byte{18}, 32)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(9007199254740993).Bytes(), 32)...)
+	log := testutil.CreateMockTransferLog()
+	log.Topics = []common.Hash{crypto.Keccak256Hash([]byte("Tagged(bytes4,uint8,int64)"))}
+	log.Data = data
+	
+	eventParser := NewEventParser(abiParser, logger)
+	parsedEvent, err := eventParser.ParseLog(log, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to parse log: %v", err)
+	}
+	
+	// Integers of every size are decimal strings, so none is rounded through a float
+	if parsedEvent.Args["decimals"] != "18" {
+		t.Errorf("Expected decimals \"18\", got: %#v", parsedEvent.Args["decimals"])
+	}
+	if parsedEvent.Args["arg2"] != "9007199254740993" {
+		t.Errorf("Expected arg2 \"9007199254740993\", got: %#v", parsedEvent.Args["arg2"])
+	}
+	if parsedEvent.Args["tag"] != "deadbeef" {
+		t.Errorf("Expected tag \"deadbeef\", got: %#v", parsedEvent.Args["tag"])
+	}
+	if len(parsedEvent.ArgTypes) != 3 || parsedEvent.ArgTypes[2].Name != "arg2" || parsedEvent.ArgTypes[2].Type != "int64" {
+		t.Errorf("Expected the unnamed argument typed as arg2 int64, got: %+v", parsedEvent.ArgTypes)
+	}
+}
+
+func TestEventParser_ParseLog_Overload(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	abiParser, err := NewABIParser(testutil.OverloadedABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	signature := "Transfer(address,address,uint256,bytes)"
+	event, _ := abiParser.GetEventByName(signature)
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(5), []byte{0x01, 0x02})
+	if err != nil {
+		t.Fatalf("Failed to pack data: %v", err)
+	}
+	log := testutil.CreateMockTransferLog()
+	log.Topics[0] = crypto.Keccak256Hash([]byte(signature))
+	log.Data = data
+	
+	parsedEvent, err := NewEventParser(abiParser, logger).ParseLog(log, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to parse log: %v", err)
+	}
+	
+	// The overload is stored under its declared name and told apart by signature
+	if parsedEvent.EventName != "Transfer" || parsedEvent.EventSignature != signature {
+		t.Errorf("Expected Transfer with signature %s, got %s with signature %s",
+			signature, parsedEvent.EventName, parsedEvent.EventSignature)
+	}
+	if parsedEvent.Args["data"] != "0102" {
+		t.Errorf("Expected data \"0102\", got: %#v", parsedEvent.Args["data"])
+	}
+}
+
+func TestEventParser_ParseLog_Anonymous(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	abiParser, err := NewABIParser(testutil.OverloadedABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	// Deposit(address indexed owner, uint256 amount): the owner is the only topic
+	owner := testutil.TestAddresses.Alice
+	log := testutil.CreateMockTransferLog()
+	log.Topics = []common.Hash{common.BytesToHash(owner.Bytes())}
+	log.Data = common.LeftPadBytes(big.NewInt(42).Bytes(), 32)
+	
+	eventParser := NewEventParser(abiParser, logger)
+	if _, err := eventParser.ParseLog(log, time.Now()); err == nil {
+		t.Fatal("Expected anonymous events not to be decoded without opting in")
+	}
+	
+	eventParser.DecodeAnonymousEvents()
+	parsedEvent, err := eventParser.ParseLog(log, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to parse log: %v", err)
+	}
+	if parsedEvent.EventName != "Deposit" || parsedEvent.EventSignature != "Deposit(address,uint256)" {
+		t.Errorf("Expected Deposit(address,uint256), got %s (%s)", parsedEvent.EventName, parsedEvent.EventSignature)
+	}
+	if !equalAddresses(parsedEvent.Args["owner"].(string), owner.Hex()) || parsedEvent.Args["amount"] != "42" {
+		t.Errorf("Expected owner %s and amount 42, got: %v", owner.Hex(), parsedEvent.Args)
+	}
+	
+	// Settled(uint256 amount, bool closed) has no topics and two data words
+	log.Topics = nil
+	log.Data = append(common.LeftPadBytes([]byte{7}, 32), common.LeftPadBytes([]byte{1}, 32)...)
+	parsedEvent, err = eventParser.ParseLog(log, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to parse log: %v", err)
+	}
+	if parsedEvent.EventName != "Settled" || parsedEvent.Args["closed"] != true {
+		t.Errorf("Expected a closed Settled event, got %s with %v", parsedEvent.EventName, parsedEvent.Args)
+	}
+	
+	// A bool word other than 0 or 1 is not an encoding of Settled
+	log.Data[63] = 2
+	if _, err := eventParser.ParseLog(log, time.Now()); err == nil {
+		t.Error("Expected a log that fits no anonymous event to stay undecoded")
+	}
+}
+
+func TestEventParser_ParseLog_AmbiguousAnonymous(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	abiJSON := `[
+		{"type":"event","name":"Minted","anonymous":true,"inputs":[{"name":"amount","type":"uint256","indexed":false}]},
+		{"type":"event","name":"Burned","anonymous":true,"inputs":[{"name":"amount","type":"uint256","indexed":false}]}
+	]`
+	abiParser, err := NewABIParser(abiJSON, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	log := testutil.CreateMockTransferLog()
+	log.Topics = nil
+	log.Data = common.LeftPadBytes([]byte{1}, 32)
+	
+	eventParser := NewEventParser(abiParser, logger)
+	eventParser.DecodeAnonymousEvents()
+	_, err = eventParser.ParseLog(log, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "several anonymous events") {
+		t.Errorf("Expected a log fitting two events to stay undecoded, got: %v", err)
+	}
+}
+
 func TestEventParser_ParseLog_Approval(t *testing.T) {
 	logger := testutil.NewTestLogger()
 	abiParser, err := NewABIParser(testutil.ERC20ABI, logger)
@@ -254,6 +437,163 @@ func TestEventParser_ParseLog_Timestamp(t *testing.T) {
 	}
 }
 
+func TestEventParser_ParseLogs_PerBlockTimestamps(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	abiParser, err := NewABIParser(testutil.ERC20ABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	eventParser := NewEventParser(abiParser, logger)
+	transferLog := testutil.CreateMockTransferLog()
+	approvalLog := testutil.CreateMockApprovalLog()
+	
+	transferTime := time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC)
+	approvalTime := time.Date(2024, 1, 15, 12, 30, 57, 0, time.UTC)
+	timestamps := map[common.Hash]time.Time{
+		transferLog.BlockHash: transferTime,
+		approvalLog.BlockHash: approvalTime,
+	}
+	
+	events, unknown, err := eventParser.ParseLogs([]types.Log{transferLog, approvalLog}, timestamps)
+	if err != nil {
+		t.Fatalf("Failed to parse logs: %v", err)
+	}
+	
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got: %d", len(events))
+	}
+	if len(unknown) != 0 {
+		t.Errorf("Expected no unknown logs, got: %d", len(unknown))
+	}
+	if !events[0].Timestamp.Equal(transferTime) {
+		t.Errorf("Expected transfer timestamp %v, got: %v", transferTime, events[0].Timestamp)
+	}
+	if !events[1].Timestamp.Equal(approvalTime) {
+		t.Errorf("Expected approval timestamp %v, got: %v", approvalTime, events[1].Timestamp)
+	}
+}
+
+func TestEventParser_ParseLogs_MissingTimestamp(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	abiParser, err := NewABIParser(testutil.ERC20ABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	eventParser := NewEventParser(abiParser, logger)
+	log := testutil.CreateMockTransferLog()
+	
+	_, _, err = eventParser.ParseLogs([]types.Log{log}, map[common.Hash]time.Time{})
+	if err == nil {
+		t.Error("Expected error when the block timestamp is missing")
+	}
+}
+
+func TestEventParser_ParseLogs_KeepsUnknownLogs(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	abiParser, err := NewABIParser(testutil.ERC20ABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	eventParser := NewEventParser(abiParser, logger)
+	transferLog := testutil.CreateMockTransferLog()
+	unknownLog := testutil.CreateMockTransferLog()
+	unknownLog.Topics = append([]common.Hash{crypto.Keccak256Hash([]byte("Swap(address,uint256)"))}, unknownLog.Topics[1:]...)
+	unknownLog.Index = transferLog.Index + 1
+	
+	blockTime := time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC)
+	timestamps := map[common.Hash]time.Time{transferLog.BlockHash: blockTime}
+	
+	events, unknown, err := eventParser.ParseLogs([]types.Log{transferLog, unknownLog}, timestamps)
+	if err != nil {
+		t.Fatalf("Failed to parse logs: %v", err)
+	}
+	if len(events) != 1 || events[0].EventName != "Transfer" {
+		t.Fatalf("Expected only the Transfer event, got: %v", events)
+	}
+	if len(unknown) != 1 {
+		t.Fatalf("Expected 1 unknown log, got: %d", len(unknown))
+	}
+	if unknown[0].Reason == "" {
+		t.Error("Expected the unknown log to record why it failed")
+	}
+	if !unknown[0].BlockTimestamp.Equal(blockTime) {
+		t.Errorf("Expected block timestamp %v, got: %v", blockTime, unknown[0].BlockTimestamp)
+	}
+	
+	// The kept log must rebuild the original one
+	rebuilt, err := UnknownLogToLog(unknown[0])
+	if err != nil {
+		t.Fatalf("Failed to rebuild log: %v", err)
+	}
+	if rebuilt.Address != unknownLog.Address || rebuilt.BlockHash != unknownLog.BlockHash ||
+		rebuilt.TxHash != unknownLog.TxHash || rebuilt.Index != unknownLog.Index ||
+		rebuilt.BlockNumber != unknownLog.BlockNumber || rebuilt.TxIndex != unknownLog.TxIndex {
+		t.Errorf("Rebuilt log position differs: got %+v, want %+v", rebuilt, unknownLog)
+	}
+	if len(rebuilt.Topics) != len(unknownLog.Topics) {
+		t.Fatalf("Expected %d topics, got: %d", len(unknownLog.Topics), len(rebuilt.Topics))
+	}
+	for k := range rebuilt.Topics {
+		if rebuilt.Topics[k] != unknownLog.Topics[k] {
+			t.Errorf("Topic %d: expected %s, got %s", k, unknownLog.Topics[k].Hex(), rebuilt.Topics[k].Hex())
+		}
+	}
+	if !bytes.Equal(rebuilt.Data, unknownLog.Data) {
+		t.Errorf("Expected data %x, got %x", unknownLog.Data, rebuilt.Data)
+	}
+}
+
+func TestEventParser_ParseLog_RawLogRoundTrip(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	abiParser, err := NewABIParser(testutil.ERC20ABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	eventParser := NewEventParser(abiParser, logger)
+	log := testutil.CreateMockTransferLog()
+	blockTimestamp := time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC)
+	
+	event, err := eventParser.ParseLog(log, blockTimestamp)
+	if err != nil {
+		t.Fatalf("Failed to parse log: %v", err)
+	}
+	if event.RawLog == nil {
+		t.Fatal("Expected the event to keep its raw log")
+	}
+	
+	// Decoding the rebuilt log must give the same event
+	rebuilt, err := EventToLog(event)
+	if err != nil {
+		t.Fatalf("Failed to rebuild log: %v", err)
+	}
+	if !bytes.Equal(rebuilt.Data, log.Data) || len(rebuilt.Topics) != len(log.Topics) {
+		t.Fatalf("Rebuilt log differs: got %+v, want %+v", rebuilt, log)
+	}
+	redecoded, err := eventParser.ParseLog(rebuilt, blockTimestamp)
+	if err != nil {
+		t.Fatalf("Failed to parse rebuilt log: %v", err)
+	}
+	if redecoded.EventName != event.EventName || redecoded.LogIndex != event.LogIndex || redecoded.TransactionHash != event.TransactionHash {
+		t.Errorf("Expected %s at log %d of %s, got %s at log %d of %s",
+			event.EventName, event.LogIndex, event.TransactionHash,
+			redecoded.EventName, redecoded.LogIndex, redecoded.TransactionHash)
+	}
+	for name, value := range event.Args {
+		if redecoded.Args[name] != value {
+			t.Errorf("Arg %s: expected %v, got %v", name, value, redecoded.Args[name])
+		}
+	}
+	
+	event.RawLog = nil
+	if _, err := EventToLog(event); !errors.Is(err, ErrNoRawLog) {
+		t.Errorf("Expected ErrNoRawLog for an event without a raw log, got: %v", err)
+	}
+}
+
 func TestEventParser_ParseLog_BlockHash(t *testing.T) {
 	logger := testutil.NewTestLogger()
 	abiParser, err := NewABIParser(testutil.ERC20ABI, logger)
@@ -324,3 +664,79 @@ func TestEventParser_AddressFormatting(t *testing.T) {
 	}
 }
 
+
+// approvalOnlyABI is an earlier ABI version of the ERC20 fixture that lacks
+// the Transfer event
+const approvalOnlyABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "owner", "type": "address"},
+			{"indexed": true, "name": "spender", "type": "address"},
+			{"indexed": false, "name": "value", "type": "uint256"}
+		],
+		"name": "Approval",
+		"type": "event"
+	}
+]`
+
+func TestEventParser_ParseLog_ABIVersions(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	
+	v1, err := NewABIParser(approvalOnlyABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	v2, err := NewABIParser(testutil.ERC20ABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	
+	// The upgrade adding Transfer takes effect at the fixture's block
+	eventParser := NewEventParser(v1, logger)
+	eventParser.AddVersion(12345, v2)
+	
+	log := testutil.CreateMockTransferLog()
+	if _, err := eventParser.ParseLog(log, time.Now()); err != nil {
+		t.Fatalf("Expected Transfer to parse with the upgraded ABI, got: %v", err)
+	}
+	
+	log.BlockNumber = 12344
+	if _, err := eventParser.ParseLog(log, time.Now()); err == nil {
+		t.Error("Expected Transfer before the upgrade to be missing from the ABI")
+	}
+	
+	approval := testutil.CreateMockApprovalLog()
+	approval.BlockNumber = 100
+	parsedEvent, err := eventParser.ParseLog(approval, time.Now())
+	if err != nil {
+		t.Fatalf("Expected Approval to parse with the first ABI, got: %v", err)
+	}
+	if parsedEvent.EventName != "Approval" {
+		t.Errorf("Expected event name 'Approval', got: %s", parsedEvent.EventName)
+	}
+}
+
+func TestEventParser_FilterTopics_ABIVersions(t *testing.T) {
+	logger := testutil.NewTestLogger()
+	
+	v1, err := NewABIParser(approvalOnlyABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	v2, err := NewABIParser(testutil.ERC20ABI, logger)
+	if err != nil {
+		t.Fatalf("Failed to create ABI parser: %v", err)
+	}
+	eventParser := NewEventParser(v1, logger)
+	eventParser.AddVersion(12345, v2)
+	
+	// Transfer only exists in the later version, Approval in both
+	queries, err := eventParser.FilterTopics(models.ContractFilter{Events: []string{"Transfer", "Approval"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(queries) != 1 || len(queries[0][0]) != 2 {
+		t.Errorf("Expected one query accepting 2 event IDs, got: %v", queries)
+	}
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// UpgradedEventID is the topic0 of the EIP-1967 Upgraded(address) event that
+// proxies emit when they switch implementation
+var UpgradedEventID = crypto.Keccak256Hash([]byte("Upgraded(address)"))
+
+// FilterTopics compiles a contract filter into the topic conditions of the
+// eth_getLogs queries that fetch exactly the logs it allows. Event names
+// become the accepted topic0 values and each topic alternative becomes one
+// query; a log matching any query is allowed. An empty filter compiles to no
+// queries, meaning every log is fetched. A name accepts every overload of
+// the event and a full signature only that one. Anonymous events have no
+// topic0 to select, so a non-empty filter leaves them out.
+func (p *ABIParser) FilterTopics(filter models.ContractFilter) ([][][]common.Hash, error) {
+	return compileFilter(filter, []*ABIParser{p})
+}
+
+// FilterTopics compiles a contract filter against every ABI version of the
+// parser, plus the ABIs of pending versions not yet in effect, which the
+// filter may already name. An event name accepts the event's ID in each
+// version declaring it, so a filter keeps matching an event whose signature
+// changed in an upgrade.
+func (p *EventParser) FilterTopics(filter models.ContractFilter, pending ...*ABIParser) ([][][]common.Hash, error) {
+	return compileFilter(filter, append(p.abiParsers(), pending...))
+}
+
+// compileFilter compiles a contract filter against a set of ABIs; see
+// ABIParser.FilterTopics
+func compileFilter(filter models.ContractFilter, parsers []*ABIParser) ([][][]common.Hash, error) {
+	if filter.IsEmpty() {
+		return nil, nil
+	}
+
+	var eventIDs []common.Hash
+	for _, name := range filter.Events {
+		found, anonymous := false, false
+		for _, abiParser := range parsers {
+			for _, event := range abiParser.GetEventsByName(name) {
+				if event.Anonymous {
+					anonymous = true
+					continue
+				}
+				found = true
+				if !containsHash(eventIDs, event.ID) {
+					eventIDs = append(eventIDs, event.ID)
+				}
+			}
+		}
+		if !found && anonymous {
+			return nil, fmt.Errorf("event %s is anonymous and cannot be selected by topic", name)
+		}
+		if !found {
+			return nil, fmt.Errorf("event %s not found in ABI", name)
+		}
+	}
+
+	if len(filter.Topics) == 0 {
+		return [][][]common.Hash{{eventIDs}}, nil
+	}
+
+	queries := make([][][]common.Hash, 0, len(filter.Topics))
+	for _, alternative := range filter.Topics {
+		topics := [][]common.Hash{eventIDs}
+		for _, values := range alternative.Positions() {
+			var hashes []common.Hash
+			for _, value := range values {
+				topic, err := models.NormalizeTopic(value)
+				if err != nil {
+					return nil, err
+				}
+				hashes = append(hashes, common.HexToHash(topic))
+			}
+			topics = append(topics, hashes)
+		}
+		// Trailing wildcards would exclude logs with fewer topics
+		for len(topics) > 0 && len(topics[len(topics)-1]) == 0 {
+			topics = topics[:len(topics)-1]
+		}
+		queries = append(queries, topics)
+	}
+	return queries, nil
+}
+
+func containsHash(hashes []common.Hash, hash common.Hash) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/testutil"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+const (
+	filterTransferID = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	filterHolder     = "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"
+)
+
+func TestABIParser_FilterTopics_Empty(t *testing.T) {
+	parser, err := NewABIParser(testutil.ERC20ABI, testutil.NewTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	queries, err := parser.FilterTopics(models.ContractFilter{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if queries != nil {
+		t.Errorf("Expected no queries for an empty filter, got: %v", queries)
+	}
+}
+
+func TestABIParser_FilterTopics_Events(t *testing.T) {
+	parser, err := NewABIParser(testutil.ERC20ABI, testutil.NewTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	queries, err := parser.FilterTopics(models.ContractFilter{Events: []string{"Transfer"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(queries) != 1 || len(queries[0]) != 1 || len(queries[0][0]) != 1 {
+		t.Fatalf("Expected one query on topic0, got: %v", queries)
+	}
+	if queries[0][0][0] != common.HexToHash(filterTransferID) {
+		t.Errorf("Expected topic0 %s, got: %s", filterTransferID, queries[0][0][0].Hex())
+	}
+}
+
+func TestABIParser_FilterTopics_TopicAlternatives(t *testing.T) {
+	parser, err := NewABIParser(testutil.ERC20ABI, testutil.NewTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	// Transfers from or to the filterHolder
+	queries, err := parser.FilterTopics(models.ContractFilter{
+		Events: []string{"Transfer"},
+		Topics: []models.TopicFilter{
+			{Topic1: []string{filterHolder}},
+			{Topic2: []string{filterHolder}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("Expected 2 queries, got: %d", len(queries))
+	}
+
+	filterHolderTopic := common.BytesToHash(common.HexToAddress(filterHolder).Bytes())
+	if len(queries[0]) != 2 || queries[0][1][0] != filterHolderTopic {
+		t.Errorf("Expected the first query to match topic1, got: %v", queries[0])
+	}
+	if len(queries[1]) != 3 || len(queries[1][1]) != 0 || queries[1][2][0] != filterHolderTopic {
+		t.Errorf("Expected the second query to match topic2 only, got: %v", queries[1])
+	}
+}
+
+func TestABIParser_FilterTopics_UnknownEvent(t *testing.T) {
+	parser, err := NewABIParser(testutil.ERC20ABI, testutil.NewTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	if _, err := parser.FilterTopics(models.ContractFilter{Events: []string{"Mint"}}); err == nil {
+		t.Fatal("Expected error for an event missing from the ABI, got nil")
+	}
+}
+
+func TestABIParser_FilterTopics_Overloads(t *testing.T) {
+	parser, err := NewABIParser(testutil.OverloadedABI, testutil.NewTestLogger())
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	// A name selects every overload, a signature only that one
+	queries, err := parser.FilterTopics(models.ContractFilter{Events: []string{"Transfer"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(queries) != 1 || len(queries[0][0]) != 2 {
+		t.Fatalf("Expected both Transfer overloads on topic0, got: %v", queries)
+	}
+
+	queries, err = parser.FilterTopics(models.ContractFilter{Events: []string{"Transfer(address,address,uint256)"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(queries) != 1 || len(queries[0][0]) != 1 || queries[0][0][0] != common.HexToHash(filterTransferID) {
+		t.Errorf("Expected only topic0 %s, got: %v", filterTransferID, queries)
+	}
+
+	// Anonymous events have no topic0 to select
+	if _, err := parser.FilterTopics(models.ContractFilter{Events: []string{"Deposit"}}); err == nil {
+		t.Error("Expected an error for a filter on an anonymous event")
+	}
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// ErrNoRawLog is returned for events stored without their raw log
+var ErrNoRawLog = errors.New("event has no raw log")
+
+// rawLog is the stored form of a log's topics and data (events.raw_log)
+type rawLog struct {
+	Topics []string `json:"topics"`
+	Data   string   `json:"data"`
+}
+
+// EncodeRawLog returns the raw log kept with an event so it can be decoded
+// again after an ABI change
+func EncodeRawLog(log types.Log) string {
+	raw, _ := json.Marshal(rawLog{
+		Topics: encodeTopics(log.Topics),
+		Data:   hexutil.Encode(log.Data),
+	})
+	return string(raw)
+}
+
+// EventToLog rebuilds the log an event was decoded from out of its raw log
+func EventToLog(event *models.Event) (types.Log, error) {
+	if event.RawLog == nil || *event.RawLog == "" {
+		return types.Log{}, ErrNoRawLog
+	}
+	var raw rawLog
+	if err := json.Unmarshal([]byte(*event.RawLog), &raw); err != nil {
+		return types.Log{}, fmt.Errorf("invalid raw log: %w", err)
+	}
+	topics, err := decodeTopics(raw.Topics)
+	if err != nil {
+		return types.Log{}, err
+	}
+	data, err := hexutil.Decode(raw.Data)
+	if err != nil {
+		return types.Log{}, fmt.Errorf("invalid log data: %w", err)
+	}
+	return types.Log{
+		Address:     event.ContractAddress.ToCommonAddress(),
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: uint64(event.BlockNumber),
+		BlockHash:   common.HexToHash(string(event.BlockHash)),
+		TxHash:      common.HexToHash(string(event.TransactionHash)),
+		TxIndex:     uint(event.TransactionIndex),
+		Index:       uint(event.LogIndex),
+	}, nil
+}
+
+// encodeTopics returns topics as 0x-prefixed hex
+func encodeTopics(topics []common.Hash) []string {
+	encoded := make([]string, len(topics))
+	for k, topic := range topics {
+		encoded[k] = topic.Hex()
+	}
+	return encoded
+}
+
+// decodeTopics parses 0x-prefixed 32-byte hex topics
+func decodeTopics(topics []string) ([]common.Hash, error) {
+	decoded := make([]common.Hash, len(topics))
+	for k, topic := range topics {
+		if !strings.HasPrefix(topic, "0x") || len(topic) != 2+2*common.HashLength {
+			return nil, fmt.Errorf("invalid log topic %q", topic)
+		}
+		decoded[k] = common.HexToHash(topic)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// NewUnknownLog keeps a log that failed to decode, with the reason, so it can
+// be decoded again later
+func NewUnknownLog(log types.Log, blockTimestamp time.Time, reason error) *models.UnknownLog {
+	return &models.UnknownLog{
+		ContractAddress:  models.Address(log.Address.Hex()),
+		BlockNumber:      int64(log.BlockNumber),
+		BlockHash:        models.Hash(log.BlockHash.Hex()),
+		BlockTimestamp:   blockTimestamp,
+		TransactionHash:  models.Hash(log.TxHash.Hex()),
+		TransactionIndex: int(log.TxIndex),
+		LogIndex:         int(log.Index),
+		Topics:           encodeTopics(log.Topics),
+		Data:             hexutil.Encode(log.Data),
+		Reason:           reason.Error(),
+	}
+}
+
+// UnknownLogToLog rebuilds the raw log kept by an unknown log
+func UnknownLogToLog(unknown *models.UnknownLog) (types.Log, error) {
+	data, err := hexutil.Decode(unknown.Data)
+	if err != nil {
+		return types.Log{}, fmt.Errorf("invalid log data: %w", err)
+	}
+	topics, err := decodeTopics(unknown.Topics)
+	if err != nil {
+		return types.Log{}, err
+	}
+	return types.Log{
+		Address:     unknown.ContractAddress.ToCommonAddress(),
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: uint64(unknown.BlockNumber),
+		BlockHash:   common.HexToHash(string(unknown.BlockHash)),
+		TxHash:      common.HexToHash(string(unknown.TransactionHash)),
+		TxIndex:     uint(unknown.TransactionIndex),
+		Index:       uint(unknown.LogIndex),
+	}, nil
+}
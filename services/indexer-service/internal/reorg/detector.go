@@ -2,9 +2,11 @@ package reorg
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/redis/go-redis/v9"
 	"github.com/smart-contract-event-indexer/shared/models"
 	"github.com/smart-contract-event-indexer/shared/utils"
@@ -13,22 +15,54 @@ import (
 const (
 	// BlockCacheSize is the number of recent blocks to cache
 	BlockCacheSize = 50
-	// BlockCacheKeyPrefix is the Redis key prefix for block cache
+	// BlockCacheKeyPrefix is the Redis key prefix for block cache; keys are
+	// scoped by chain as block:hash:<chain_id>:<block_number>
 	BlockCacheKeyPrefix = "block:hash:"
 )
 
+// ErrDeepReorg is returned when the fork point of a reorganization lies beyond
+// the cached block history. The rollback target cannot be determined safely, so
+// indexing should stop until an operator intervenes.
+var ErrDeepReorg = errors.New("reorg deeper than block cache")
+
+// DeepReorgError describes a reorg whose fork point could not be found in the cache
+type DeepReorgError struct {
+	ChainID       int64
+	DetectedAt    int64 // block whose parent did not match the cache
+	OldestChecked int64 // oldest block compared before giving up
+}
+
+func (e *DeepReorgError) Error() string {
+	return fmt.Sprintf("chain %d: reorg detected at block %d extends past block %d: %v",
+		e.ChainID, e.DetectedAt, e.OldestChecked, ErrDeepReorg)
+}
+
+// Is reports ErrDeepReorg as a match so callers can use errors.Is
+func (e *DeepReorgError) Is(target error) bool {
+	return target == ErrDeepReorg
+}
+
+// HeaderSource provides canonical block headers for the detector's chain
+type HeaderSource interface {
+	GetHeaderByNumber(ctx context.Context, blockNumber int64) (*types.Header, error)
+}
+
 // Detector detects blockchain reorganizations
 type Detector struct {
-	redisClient *redis.Client
+	chainID     int64
+	chain       HeaderSource
+	redisClient redis.Cmdable
 	logger      utils.Logger
 	cacheSize   int
 }
 
-// NewDetector creates a new reorg detector
-func NewDetector(redisClient *redis.Client, logger utils.Logger) *Detector {
+// NewDetector creates a new reorg detector caching block hashes in Redis
+func NewDetector(chainID int64, chain HeaderSource, redisClient redis.Cmdable, logger utils.Logger) *Detector {
 	return &Detector{
+		chainID:     chainID,
+		chain:       chain,
 		redisClient: redisClient,
-		logger:      logger,
+		logger:      logger.WithField("chain_id", chainID),
 		cacheSize:   BlockCacheSize,
 	}
 }
@@ -43,7 +77,7 @@ type BlockInfo struct {
 
 // CacheBlock stores a block's hash in the cache
 func (d *Detector) CacheBlock(ctx context.Context, block *BlockInfo) error {
-	key := fmt.Sprintf("%s%d", BlockCacheKeyPrefix, block.Number)
+	key := d.cacheKey(block.Number)
 	
 	// Store block hash with expiration (keep for 7 days)
 	err := d.redisClient.Set(ctx, key, string(block.Hash), 7*24*time.Hour).Err()
@@ -59,9 +93,19 @@ func (d *Detector) CacheBlock(ctx context.Context, block *BlockInfo) error {
 	return nil
 }
 
+// cacheKey returns the Redis key for a block on the detector's chain
+func (d *Detector) cacheKey(blockNumber int64) string {
+	return fmt.Sprintf("%s%d:%d", BlockCacheKeyPrefix, d.chainID, blockNumber)
+}
+
+// cacheKeyPattern matches every cached block on the detector's chain
+func (d *Detector) cacheKeyPattern() string {
+	return fmt.Sprintf("%s%d:*", BlockCacheKeyPrefix, d.chainID)
+}
+
 // GetCachedBlockHash retrieves a block's hash from the cache
 func (d *Detector) GetCachedBlockHash(ctx context.Context, blockNumber int64) (models.Hash, error) {
-	key := fmt.Sprintf("%s%d", BlockCacheKeyPrefix, blockNumber)
+	key := d.cacheKey(blockNumber)
 	
 	hash, err := d.redisClient.Get(ctx, key).Result()
 	if err != nil {
@@ -74,8 +118,27 @@ func (d *Detector) GetCachedBlockHash(ctx context.Context, blockNumber int64) (m
 	return models.Hash(hash), nil
 }
 
-// DetectReorg checks if a reorganization has occurred
+// DetectReorg checks if a reorganization has occurred. A block is checked
+// against the hash cached for its own height, which ranges revisiting blocks
+// already indexed (a lagging contract, another coalesced range, a topic
+// subscription) find cached, and against the hash cached for its parent.
 func (d *Detector) DetectReorg(ctx context.Context, currentBlock *BlockInfo) (bool, int64, error) {
+	// A block seen before must still have the same hash; caching the new one
+	// over it would hide the replaced block from every later check
+	if cachedHash, err := d.GetCachedBlockHash(ctx, currentBlock.Number); err == nil {
+		if cachedHash == currentBlock.Hash {
+			return false, 0, nil
+		}
+		
+		d.logger.WithFields(map[string]interface{}{
+			"block_number":  currentBlock.Number,
+			"expected_hash": cachedHash,
+			"actual_hash":   currentBlock.Hash,
+		}).Warn("Blockchain reorganization detected")
+		
+		return d.reorgAt(ctx, currentBlock)
+	}
+	
 	// Get the cached hash for the parent block
 	cachedParentHash, err := d.GetCachedBlockHash(ctx, currentBlock.Number-1)
 	if err != nil {
@@ -99,14 +162,7 @@ func (d *Detector) DetectReorg(ctx context.Context, currentBlock *BlockInfo) (bo
 			"actual_parent":       currentBlock.ParentHash,
 		}).Warn("Blockchain reorganization detected")
 		
-		forkPoint, err := d.findForkPoint(ctx, currentBlock)
-		if err != nil {
-			return true, 0, fmt.Errorf("failed to find fork point: %w", err)
-		}
-		
-		d.logger.WithField("fork_point", forkPoint).Info("Fork point identified")
-		
-		return true, forkPoint, nil
+		return d.reorgAt(ctx, currentBlock)
 	}
 	
 	// No reorg, cache the current block
@@ -115,41 +171,98 @@ func (d *Detector) DetectReorg(ctx context.Context, currentBlock *BlockInfo) (bo
 	return false, 0, nil
 }
 
-// findForkPoint finds the block number where the chains diverged
+// reorgAt reports a reorg seen at currentBlock with its fork point
+func (d *Detector) reorgAt(ctx context.Context, currentBlock *BlockInfo) (bool, int64, error) {
+	forkPoint, err := d.findForkPoint(ctx, currentBlock)
+	if err != nil {
+		return true, 0, fmt.Errorf("failed to find fork point: %w", err)
+	}
+	
+	d.logger.WithField("fork_point", forkPoint).Info("Fork point identified")
+	
+	return true, forkPoint, nil
+}
+
+// findForkPoint walks back from the parent of currentBlock, comparing each
+// cached hash with the canonical hash from the RPC node. The fork point is the
+// first block above the newest block on which both agree. If no agreeing block
+// is found within the cache a *DeepReorgError is returned.
 func (d *Detector) findForkPoint(ctx context.Context, currentBlock *BlockInfo) (int64, error) {
-	// Start from the parent block and go backwards
 	blockNumber := currentBlock.Number - 1
 	
-	// We'll check up to cacheSize blocks back
 	for i := 0; i < d.cacheSize && blockNumber > 0; i++ {
 		cachedHash, err := d.GetCachedBlockHash(ctx, blockNumber)
 		if err != nil {
-			// If we can't find the cached block, assume this is the fork point
-			d.logger.WithField("block_number", blockNumber).Debug("Cached block not found, assuming fork point")
+			// The cache does not reach back far enough to locate the fork
+			break
+		}
+		
+		header, err := d.chain.GetHeaderByNumber(ctx, blockNumber)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch canonical block %d: %w", blockNumber, err)
+		}
+		
+		canonicalHash := models.Hash(header.Hash().Hex())
+		if canonicalHash == cachedHash {
+			d.logger.WithFields(map[string]interface{}{
+				"common_ancestor": blockNumber,
+				"depth":           currentBlock.Number - blockNumber - 1,
+			}).Debug("Common ancestor found")
 			return blockNumber + 1, nil
 		}
 		
-		// In a real implementation, we'd fetch the actual block from the chain
-		// and compare hashes. Since this is a simplified detector, we treat the
-		// first cached hash we encounter as the fork point so we at least have
-		// a deterministic rollback target.
 		d.logger.WithFields(map[string]interface{}{
-			"block_number": blockNumber,
-			"cached_hash":  cachedHash,
-		}).Debug("Using cached block as fork point")
-		return blockNumber, nil
+			"block_number":   blockNumber,
+			"cached_hash":    cachedHash,
+			"canonical_hash": canonicalHash,
+		}).Debug("Cached block is no longer canonical")
+		
+		blockNumber--
 	}
 	
-	// If we've gone back cacheSize blocks and haven't found a match,
-	// the reorg is deeper than our cache. Return the oldest cached block
-	d.logger.Warn("Reorg depth exceeds cache size")
-	return currentBlock.Number - int64(d.cacheSize), nil
+	deepErr := &DeepReorgError{
+		ChainID:       d.chainID,
+		DetectedAt:    currentBlock.Number,
+		OldestChecked: blockNumber + 1,
+	}
+	d.logger.WithFields(map[string]interface{}{
+		"detected_at":   currentBlock.Number,
+		"oldest_cached": blockNumber + 1,
+		"cache_size":    d.cacheSize,
+		"alert":         "deep_reorg",
+	}).Error("Reorg depth exceeds block cache; fork point unknown")
+	
+	return 0, deepErr
+}
+
+// InvalidateFrom removes cached hashes for blocks fromBlock..toBlock, which
+// belong to an abandoned branch after a reorg
+func (d *Detector) InvalidateFrom(ctx context.Context, fromBlock, toBlock int64) error {
+	if toBlock < fromBlock {
+		return nil
+	}
+	
+	keys := make([]string, 0, toBlock-fromBlock+1)
+	for blockNumber := fromBlock; blockNumber <= toBlock; blockNumber++ {
+		keys = append(keys, d.cacheKey(blockNumber))
+	}
+	
+	if err := d.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate cached blocks: %w", err)
+	}
+	
+	d.logger.WithFields(map[string]interface{}{
+		"from_block": fromBlock,
+		"to_block":   toBlock,
+	}).Debug("Cached blocks invalidated")
+	
+	return nil
 }
 
 // ClearCache clears all cached block hashes
 func (d *Detector) ClearCache(ctx context.Context) error {
 	// Find all block cache keys
-	iter := d.redisClient.Scan(ctx, 0, BlockCacheKeyPrefix+"*", 0).Iterator()
+	iter := d.redisClient.Scan(ctx, 0, d.cacheKeyPattern(), 0).Iterator()
 	
 	count := 0
 	for iter.Next(ctx) {
@@ -171,7 +284,7 @@ func (d *Detector) ClearCache(ctx context.Context) error {
 // GetCacheStats returns statistics about the block cache
 func (d *Detector) GetCacheStats(ctx context.Context) (map[string]interface{}, error) {
 	// Count cached blocks
-	iter := d.redisClient.Scan(ctx, 0, BlockCacheKeyPrefix+"*", 0).Iterator()
+	iter := d.redisClient.Scan(ctx, 0, d.cacheKeyPattern(), 0).Iterator()
 	
 	count := 0
 	for iter.Next(ctx) {
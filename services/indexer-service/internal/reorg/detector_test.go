@@ -0,0 +1,265 @@
+package reorg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/redis/go-redis/v9"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/testutil"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// fakeBlockCache keeps block hashes in memory. Only the commands the detector
+// uses are implemented; any other call panics on the nil Cmdable.
+type fakeBlockCache struct {
+	redis.Cmdable
+	hashes map[string]string
+}
+
+func newFakeBlockCache() *fakeBlockCache {
+	return &fakeBlockCache{hashes: make(map[string]string)}
+}
+
+func (c *fakeBlockCache) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx, "get", key)
+	if hash, ok := c.hashes[key]; ok {
+		cmd.SetVal(hash)
+	} else {
+		cmd.SetErr(redis.Nil)
+	}
+	return cmd
+}
+
+func (c *fakeBlockCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	c.hashes[key] = fmt.Sprint(value)
+	cmd := redis.NewStatusCmd(ctx, "set", key, value)
+	cmd.SetVal("OK")
+	return cmd
+}
+
+func (c *fakeBlockCache) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	var deleted int64
+	for _, key := range keys {
+		if _, ok := c.hashes[key]; ok {
+			delete(c.hashes, key)
+			deleted++
+		}
+	}
+	cmd := redis.NewIntCmd(ctx, "del")
+	cmd.SetVal(deleted)
+	return cmd
+}
+
+// fakeChain serves canonical headers by number
+type fakeChain struct {
+	headers map[int64]*types.Header
+	err     error
+}
+
+func (c *fakeChain) GetHeaderByNumber(ctx context.Context, blockNumber int64) (*types.Header, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	header, ok := c.headers[blockNumber]
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", blockNumber)
+	}
+	return header, nil
+}
+
+// buildBranch returns headers for blocks from..to chained onto parent; the
+// branch name makes the hashes of different branches differ
+func buildBranch(parent *types.Header, from, to int64, branch string) map[int64]*types.Header {
+	headers := make(map[int64]*types.Header)
+	for number := from; number <= to; number++ {
+		header := &types.Header{
+			Number: big.NewInt(number),
+			Extra:  []byte(branch),
+		}
+		if parent != nil {
+			header.ParentHash = parent.Hash()
+		}
+		headers[number] = header
+		parent = header
+	}
+	return headers
+}
+
+func blockInfo(header *types.Header) *BlockInfo {
+	return &BlockInfo{
+		Number:     header.Number.Int64(),
+		Hash:       models.Hash(header.Hash().Hex()),
+		ParentHash: models.Hash(header.ParentHash.Hex()),
+	}
+}
+
+func TestDetector_FindForkPoint(t *testing.T) {
+	const (
+		chainID   = 1
+		cacheSize = 10
+		head      = 100 // first block of the canonical chain whose parent is not cached
+	)
+
+	tests := []struct {
+		name string
+		// The indexer saw the abandoned branch from oldFork on; the cache holds
+		// the blocks from cachedFrom up to head-1 as the indexer saw them
+		oldFork    int64
+		cachedFrom int64
+		chainErr   error
+		wantFork   int64
+		wantDeep   int64 // OldestChecked of the expected *DeepReorgError, 0 if none
+	}{
+		{name: "only the parent replaced", oldFork: 99, cachedFrom: 90, wantFork: 99},
+		{name: "shallow fork", oldFork: 96, cachedFrom: 90, wantFork: 96},
+		{name: "ancestor is the oldest cached block", oldFork: 91, cachedFrom: 90, wantFork: 91},
+		{name: "fork at the cache edge", oldFork: 90, cachedFrom: 90, wantDeep: 90},
+		{name: "fork beyond the cache size", oldFork: 80, cachedFrom: 80, wantDeep: 90},
+		{name: "cache does not reach the ancestor", oldFork: 95, cachedFrom: 97, wantDeep: 97},
+		{name: "canonical header unavailable", oldFork: 96, cachedFrom: 90, chainErr: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			common := buildBranch(nil, 0, tt.oldFork-1, "canonical")
+			canonical := buildBranch(common[tt.oldFork-1], tt.oldFork, head, "canonical-new")
+			abandoned := buildBranch(common[tt.oldFork-1], tt.oldFork, head-1, "abandoned")
+			for number, header := range common {
+				canonical[number] = header
+			}
+
+			cache := newFakeBlockCache()
+			detector := NewDetector(chainID, &fakeChain{headers: canonical, err: tt.chainErr}, cache, testutil.NewTestLogger())
+			detector.cacheSize = cacheSize
+			for number := tt.cachedFrom; number < head; number++ {
+				header := common[number]
+				if number >= tt.oldFork {
+					header = abandoned[number]
+				}
+				if err := detector.CacheBlock(ctx, blockInfo(header)); err != nil {
+					t.Fatalf("CacheBlock(%d): %v", number, err)
+				}
+			}
+
+			forkPoint, err := detector.findForkPoint(ctx, blockInfo(canonical[head]))
+
+			switch {
+			case tt.chainErr != nil:
+				if err == nil || errors.Is(err, ErrDeepReorg) {
+					t.Fatalf("err = %v, want the header fetch error", err)
+				}
+			case tt.wantDeep != 0:
+				var deepErr *DeepReorgError
+				if !errors.As(err, &deepErr) || !errors.Is(err, ErrDeepReorg) {
+					t.Fatalf("err = %v, want a *DeepReorgError", err)
+				}
+				if deepErr.ChainID != chainID || deepErr.DetectedAt != head || deepErr.OldestChecked != tt.wantDeep {
+					t.Errorf("deep reorg = %+v, want detected at %d, oldest checked %d", deepErr, head, tt.wantDeep)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("findForkPoint: %v", err)
+				}
+				if forkPoint != tt.wantFork {
+					t.Errorf("fork point = %d, want %d", forkPoint, tt.wantFork)
+				}
+			}
+		})
+	}
+}
+
+func TestDetector_DetectReorg(t *testing.T) {
+	ctx := context.Background()
+	common := buildBranch(nil, 0, 97, "canonical")
+	canonical := buildBranch(common[97], 98, 101, "canonical-new")
+	abandoned := buildBranch(common[97], 98, 100, "abandoned")
+	for number, header := range common {
+		canonical[number] = header
+	}
+
+	detector := NewDetector(1, &fakeChain{headers: canonical}, newFakeBlockCache(), testutil.NewTestLogger())
+
+	// Nothing cached yet: no reorg, and the block is cached
+	for number := int64(95); number <= 97; number++ {
+		reorged, _, err := detector.DetectReorg(ctx, blockInfo(common[number]))
+		if err != nil || reorged {
+			t.Fatalf("DetectReorg(%d) = %v, %v; want no reorg", number, reorged, err)
+		}
+	}
+	for number := int64(98); number <= 100; number++ {
+		reorged, _, err := detector.DetectReorg(ctx, blockInfo(abandoned[number]))
+		if err != nil || reorged {
+			t.Fatalf("DetectReorg(%d) = %v, %v; want no reorg on the first branch seen", number, reorged, err)
+		}
+	}
+
+	// The canonical block 101 does not build on the cached block 100
+	reorged, forkPoint, err := detector.DetectReorg(ctx, blockInfo(canonical[101]))
+	if err != nil {
+		t.Fatalf("DetectReorg: %v", err)
+	}
+	if !reorged || forkPoint != 98 {
+		t.Fatalf("DetectReorg = %v at %d, want a reorg with fork point 98", reorged, forkPoint)
+	}
+
+	// After the rollback the abandoned hashes are dropped and the canonical
+	// branch is accepted
+	if err := detector.InvalidateFrom(ctx, forkPoint, 101); err != nil {
+		t.Fatalf("InvalidateFrom: %v", err)
+	}
+	for number := int64(98); number <= 101; number++ {
+		reorged, _, err := detector.DetectReorg(ctx, blockInfo(canonical[number]))
+		if err != nil || reorged {
+			t.Fatalf("DetectReorg(%d) after rollback = %v, %v; want no reorg", number, reorged, err)
+		}
+	}
+}
+
+func TestDetector_DetectReorgRevisitedBlock(t *testing.T) {
+	ctx := context.Background()
+	common := buildBranch(nil, 0, 98, "canonical")
+	canonical := buildBranch(common[98], 99, 101, "canonical-new")
+	abandoned := buildBranch(common[98], 99, 100, "abandoned")
+	for number, header := range common {
+		canonical[number] = header
+	}
+
+	cache := newFakeBlockCache()
+	detector := NewDetector(1, &fakeChain{headers: canonical}, cache, testutil.NewTestLogger())
+
+	// A first range indexes the abandoned branch
+	for number := int64(97); number <= 100; number++ {
+		header := common[number]
+		if number >= 99 {
+			header = abandoned[number]
+		}
+		if reorged, _, err := detector.DetectReorg(ctx, blockInfo(header)); err != nil || reorged {
+			t.Fatalf("DetectReorg(%d) = %v, %v; want no reorg on the first branch seen", number, reorged, err)
+		}
+	}
+
+	// A second range revisits block 98 unchanged, then finds 99 replaced by a
+	// block with the same parent
+	if reorged, _, err := detector.DetectReorg(ctx, blockInfo(common[98])); err != nil || reorged {
+		t.Fatalf("DetectReorg(98) = %v, %v; want no reorg for an unchanged block", reorged, err)
+	}
+	reorged, forkPoint, err := detector.DetectReorg(ctx, blockInfo(canonical[99]))
+	if err != nil {
+		t.Fatalf("DetectReorg(99): %v", err)
+	}
+	if !reorged || forkPoint != 99 {
+		t.Fatalf("DetectReorg(99) = %v at %d, want a reorg with fork point 99", reorged, forkPoint)
+	}
+
+	// The abandoned hash stays cached until the rollback invalidates it
+	if hash, err := detector.GetCachedBlockHash(ctx, 99); err != nil || hash != blockInfo(abandoned[99]).Hash {
+		t.Errorf("cached hash of 99 = %s, %v; want the abandoned block's", hash, err)
+	}
+}
@@ -16,6 +16,7 @@ type Handler struct {
 	contractStorage *storage.ContractStorage
 	eventStorage    *storage.EventStorage
 	stateStorage    *storage.StateStorage
+	reorgStorage    *storage.ReorgStorage
 	detector        *Detector
 	logger          utils.Logger
 }
@@ -26,6 +27,7 @@ func NewHandler(
 	contractStorage *storage.ContractStorage,
 	eventStorage *storage.EventStorage,
 	stateStorage *storage.StateStorage,
+	reorgStorage *storage.ReorgStorage,
 	detector *Detector,
 	logger utils.Logger,
 ) *Handler {
@@ -34,113 +36,226 @@ func NewHandler(
 		contractStorage: contractStorage,
 		eventStorage:    eventStorage,
 		stateStorage:    stateStorage,
+		reorgStorage:    reorgStorage,
 		detector:        detector,
 		logger:          logger,
 	}
 }
 
-// HandleReorg handles a blockchain reorganization
-func (h *Handler) HandleReorg(ctx context.Context, contractAddress models.Address, forkPoint int64) error {
+// HandleReorg rolls a single contract back to just before the fork point and
+// returns the number of events removed
+func (h *Handler) HandleReorg(ctx context.Context, chainID int64, contractAddress models.Address, forkPoint int64) (int64, error) {
 	h.logger.WithFields(map[string]interface{}{
+		"chain_id":   chainID,
 		"contract":   contractAddress,
 		"fork_point": forkPoint,
 	}).Warn("Handling blockchain reorganization")
 	
-	// Start a database transaction
+	// Every step runs in one transaction, so a failure leaves the contract's
+	// events, cursor and ABI versions as they were
 	tx, err := h.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 	
 	// Step 1: Delete events from fork point onwards
-	if err := h.rollbackEvents(ctx, contractAddress, forkPoint); err != nil {
-		return fmt.Errorf("failed to rollback events: %w", err)
+	deleted, err := h.rollbackEvents(ctx, tx, chainID, contractAddress, forkPoint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rollback events: %w", err)
+	}
+	// Undecodable logs of the abandoned branch go with its events
+	if _, err := h.eventStorage.DeleteUnknownLogsByBlock(ctx, tx, chainID, contractAddress, forkPoint); err != nil {
+		return 0, fmt.Errorf("failed to rollback unknown logs: %w", err)
 	}
 	
 	// Step 2: Update contract's current block to fork point
-	if err := h.contractStorage.UpdateContractBlock(ctx, contractAddress, forkPoint-1); err != nil {
-		return fmt.Errorf("failed to update contract block: %w", err)
+	if err := h.contractStorage.RewindContractBlock(ctx, tx, chainID, contractAddress, forkPoint-1); err != nil {
+		return 0, fmt.Errorf("failed to update contract block: %w", err)
 	}
 	
-	// Step 3: Update indexer state
-	if err := h.stateStorage.UpdateStatus(ctx, contractAddress, "reorg_recovery"); err != nil {
-		h.logger.WithError(err).Warn("Failed to update indexer status")
+	// Step 3: Upgrades seen on the abandoned branch must be seen again
+	reset, err := h.contractStorage.ResetABIVersionsFrom(ctx, tx, chainID, contractAddress, forkPoint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset ABI versions: %w", err)
 	}
 	
-	// Step 4: Clear block cache for affected blocks
-	// In a more sophisticated implementation, we'd only clear the affected blocks
-	// For simplicity, we're keeping the cache as-is since detector will handle it
+	// Step 4: Rewind and flag the indexer state
+	if err := h.stateStorage.RewindLastIndexedBlock(ctx, tx, chainID, contractAddress, forkPoint-1); err != nil {
+		return 0, fmt.Errorf("failed to rewind indexer state: %w", err)
+	}
 	
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	
+	if reset > 0 {
+		h.logger.WithFields(map[string]interface{}{
+			"contract": contractAddress,
+			"versions": reset,
+		}).Info("ABI versions returned to pending")
 	}
 	
 	h.logger.WithFields(map[string]interface{}{
+		"chain_id":   chainID,
 		"contract":   contractAddress,
 		"fork_point": forkPoint,
 	}).Info("Successfully handled blockchain reorganization")
 	
-	return nil
+	return deleted, nil
 }
 
 // rollbackEvents deletes events from a specific block onwards
-func (h *Handler) rollbackEvents(ctx context.Context, contractAddress models.Address, fromBlock int64) error {
-	// Get count of events to be deleted (for logging)
-	countQuery := `
-		SELECT COUNT(*) FROM events
-		WHERE contract_address = $1 AND block_number >= $2
-	`
-	
-	var count int64
-	if err := h.db.GetContext(ctx, &count, countQuery, contractAddress, fromBlock); err != nil {
-		h.logger.WithError(err).Warn("Failed to count events to rollback")
-	}
-	
-	// Delete events
-	if err := h.eventStorage.DeleteEventsByBlock(ctx, contractAddress, fromBlock); err != nil {
-		return fmt.Errorf("failed to delete events: %w", err)
+func (h *Handler) rollbackEvents(ctx context.Context, tx *sqlx.Tx, chainID int64, contractAddress models.Address, fromBlock int64) (int64, error) {
+	deleted, err := h.eventStorage.DeleteEventsByBlock(ctx, tx, chainID, contractAddress, fromBlock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete events: %w", err)
 	}
 	
 	h.logger.WithFields(map[string]interface{}{
+		"chain_id":   chainID,
 		"contract":   contractAddress,
 		"from_block": fromBlock,
-		"deleted":    count,
+		"deleted":    deleted,
 	}).Info("Events rolled back")
 	
-	return nil
+	return deleted, nil
 }
 
-// HandleReorgForAllContracts handles a reorg that affects all contracts
-func (h *Handler) HandleReorgForAllContracts(ctx context.Context, forkPoint int64) error {
-	h.logger.WithField("fork_point", forkPoint).Warn("Handling global blockchain reorganization")
+// HandleReorgForAllContracts handles a reorg that affects all contracts on a chain.
+// detectedAt is the block whose parent hash did not match the cache; every block
+// from forkPoint up to it is treated as replaced. Contracts that indexed past the
+// fork point are rolled back, each in its own transaction, stale cache entries
+// are dropped once every rollback succeeded and the reorg is recorded in the
+// audit log. Indexing resumes from the fork point on the next poll.
+func (h *Handler) HandleReorgForAllContracts(ctx context.Context, chainID int64, forkPoint int64, detectedAt int64) error {
+	h.logger.WithFields(map[string]interface{}{
+		"chain_id":    chainID,
+		"fork_point":  forkPoint,
+		"detected_at": detectedAt,
+	}).Warn("Handling global blockchain reorganization")
 	
-	// Get all contracts
-	contracts, err := h.contractStorage.GetAllContracts(ctx)
+	// Get all contracts on the chain
+	contracts, err := h.contractStorage.GetContractsByChain(ctx, chainID)
 	if err != nil {
 		return fmt.Errorf("failed to get contracts: %w", err)
 	}
 	
+	record := &models.ReorgRecord{
+		ChainID:         chainID,
+		ForkPoint:       forkPoint,
+		DetectedAtBlock: detectedAt,
+		Depth:           detectedAt - forkPoint,
+	}
+	if record.Depth < 1 {
+		record.Depth = 1
+	}
+	
 	// Handle reorg for each contract
+	var failed int
 	for _, contract := range contracts {
 		// Only rollback if the contract has indexed past the fork point
 		if contract.CurrentBlock >= forkPoint {
-			if err := h.HandleReorg(ctx, contract.Address, forkPoint); err != nil {
+			deleted, err := h.HandleReorg(ctx, chainID, contract.Address, forkPoint)
+			record.EventsRemoved += deleted
+			if err != nil {
 				h.logger.WithError(err).WithField("contract", contract.Address).Error("Failed to handle reorg for contract")
+				failed++
 				continue
 			}
+			record.ContractsAffected++
+			
+			if err := h.RecoverFromReorg(ctx, chainID, contract.Address); err != nil {
+				h.logger.WithError(err).WithField("contract", contract.Address).Warn("Failed to mark contract as recovered")
+			}
+		}
+	}
+	
+	// Topic subscriptions index the same chain from their own cursors. Their
+	// events and cursors move back together, so a failure leaves both as they
+	// were.
+	subscriptionErr := h.rollbackSubscriptions(ctx, chainID, forkPoint, record)
+	if subscriptionErr != nil {
+		h.logger.WithError(subscriptionErr).Error("Failed to roll back topic subscriptions")
+	}
+	
+	// Contracts created on the abandoned branch are discovered again if their
+	// factory's events are on the canonical chain. Their events were rolled
+	// back above, so they are only removed once every rollback succeeded.
+	if failed == 0 {
+		removed, err := h.contractStorage.DeleteDiscoveredContractsFrom(ctx, chainID, forkPoint)
+		if err != nil {
+			h.logger.WithError(err).Error("Failed to remove contracts discovered on the abandoned branch")
+		} else if removed > 0 {
+			h.logger.WithFields(map[string]interface{}{
+				"chain_id":  chainID,
+				"contracts": removed,
+			}).Info("Removed contracts discovered on the abandoned branch")
 		}
 	}
 	
-	h.logger.Info("Global reorganization handled for all contracts")
+	// Cached hashes from the fork point onwards belong to the abandoned branch.
+	// They are kept while a rollback failed, so that the next poll sees the
+	// reorg again and retries it.
+	if failed == 0 && subscriptionErr == nil {
+		if err := h.detector.InvalidateFrom(ctx, forkPoint, detectedAt); err != nil {
+			h.logger.WithError(err).Warn("Failed to invalidate block cache")
+		}
+	}
+	
+	if err := h.reorgStorage.RecordReorg(ctx, record); err != nil {
+		h.logger.WithError(err).Error("Failed to record reorg")
+	}
+	
+	if failed > 0 {
+		return fmt.Errorf("failed to roll back %d contract(s) on chain %d", failed, chainID)
+	}
+	if subscriptionErr != nil {
+		return fmt.Errorf("failed to roll back topic subscriptions on chain %d: %w", chainID, subscriptionErr)
+	}
+	
+	h.logger.WithFields(map[string]interface{}{
+		"chain_id":           chainID,
+		"fork_point":         forkPoint,
+		"depth":              record.Depth,
+		"contracts_affected": record.ContractsAffected,
+		"events_removed":     record.EventsRemoved,
+	}).Info("Global reorganization handled for all contracts")
+	
+	return nil
+}
+
+// rollbackSubscriptions removes the events topic subscriptions indexed from
+// the fork point onwards and moves their cursors back to just before it, in
+// one transaction
+func (h *Handler) rollbackSubscriptions(ctx context.Context, chainID int64, forkPoint int64, record *models.ReorgRecord) error {
+	tx, err := h.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	
+	deleted, err := h.eventStorage.DeleteSubscriptionEventsByBlock(ctx, tx, chainID, forkPoint)
+	if err != nil {
+		return err
+	}
+	
+	if _, err := h.stateStorage.RewindSubscriptions(ctx, tx, chainID, forkPoint-1); err != nil {
+		return err
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	record.EventsRemoved += deleted
 	
 	return nil
 }
 
 // RecoverFromReorg marks contracts as recovered from reorg
-func (h *Handler) RecoverFromReorg(ctx context.Context, contractAddress models.Address) error {
-	if err := h.stateStorage.UpdateStatus(ctx, contractAddress, "active"); err != nil {
+func (h *Handler) RecoverFromReorg(ctx context.Context, chainID int64, contractAddress models.Address) error {
+	if err := h.stateStorage.UpdateStatus(ctx, chainID, contractAddress, "active"); err != nil {
 		return fmt.Errorf("failed to update status: %w", err)
 	}
 	
@@ -178,9 +293,9 @@ func (h *Handler) GetReorgStats(ctx context.Context) (map[string]interface{}, er
 }
 
 // ValidateChainIntegrity validates the integrity of indexed data against the blockchain
-func (h *Handler) ValidateChainIntegrity(ctx context.Context, contractAddress models.Address) (bool, error) {
+func (h *Handler) ValidateChainIntegrity(ctx context.Context, chainID int64, contractAddress models.Address) (bool, error) {
 	// Get the latest indexed block for the contract
-	maxBlock, err := h.eventStorage.GetMaxBlockNumber(ctx, contractAddress)
+	maxBlock, err := h.eventStorage.GetMaxBlockNumber(ctx, chainID, contractAddress)
 	if err != nil {
 		return false, fmt.Errorf("failed to get max block: %w", err)
 	}
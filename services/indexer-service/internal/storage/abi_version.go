@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+const abiVersionColumns = `id, chain_id, contract_address, abi, from_block, implementation, created_at, updated_at`
+
+// GetABIVersions retrieves the later ABI versions of a contract, active ones in
+// block order followed by pending ones
+func (s *ContractStorage) GetABIVersions(ctx context.Context, chainID int64, address models.Address) ([]*models.ABIVersion, error) {
+	var versions []*models.ABIVersion
+
+	query := `
+		SELECT ` + abiVersionColumns + `
+		FROM contract_abi_versions
+		WHERE chain_id = $1 AND contract_address = $2
+		ORDER BY from_block ASC NULLS LAST, id ASC
+	`
+
+	if err := s.db.SelectContext(ctx, &versions, query, chainID, address); err != nil {
+		return nil, fmt.Errorf("failed to get ABI versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetABIVersionsByChain retrieves the later ABI versions of every contract on
+// a chain, keyed by contract address and ordered like GetABIVersions
+func (s *ContractStorage) GetABIVersionsByChain(ctx context.Context, chainID int64) (map[models.Address][]*models.ABIVersion, error) {
+	var versions []*models.ABIVersion
+
+	query := `
+		SELECT ` + abiVersionColumns + `
+		FROM contract_abi_versions
+		WHERE chain_id = $1
+		ORDER BY contract_address, from_block ASC NULLS LAST, id ASC
+	`
+
+	if err := s.db.SelectContext(ctx, &versions, query, chainID); err != nil {
+		return nil, fmt.Errorf("failed to get ABI versions for chain %d: %w", chainID, err)
+	}
+
+	byContract := make(map[models.Address][]*models.ABIVersion)
+	for _, version := range versions {
+		byContract[version.ContractAddress] = append(byContract[version.ContractAddress], version)
+	}
+
+	return byContract, nil
+}
+
+// ActivateABIVersion makes a pending ABI version take effect from fromBlock.
+// It reports false if the version was activated already.
+func (s *ContractStorage) ActivateABIVersion(ctx context.Context, id int64, fromBlock int64) (bool, error) {
+	query := `
+		UPDATE contract_abi_versions
+		SET from_block = $1
+		WHERE id = $2 AND from_block IS NULL
+	`
+
+	result, err := s.db.ExecContext(ctx, query, fromBlock, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to activate ABI version: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return false, nil
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"version_id": id,
+		"from_block": fromBlock,
+	}).Info("ABI version activated by upgrade")
+
+	return true, nil
+}
+
+// ResetABIVersionsFrom returns the versions a contract's Upgraded events
+// activated at or after fromBlock to pending, so the upgrades are detected
+// again on the canonical chain after a reorg. Versions attached at a fixed
+// block are left alone. exec is the reorg rollback's transaction.
+func (s *ContractStorage) ResetABIVersionsFrom(ctx context.Context, exec sqlx.ExtContext, chainID int64, address models.Address, fromBlock int64) (int64, error) {
+	query := `
+		UPDATE contract_abi_versions
+		SET from_block = NULL
+		WHERE chain_id = $1 AND contract_address = $2
+		  AND implementation IS NOT NULL AND from_block >= $3
+	`
+
+	result, err := exec.ExecContext(ctx, query, chainID, address, fromBlock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset ABI versions: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
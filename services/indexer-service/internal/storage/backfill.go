@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// BackfillStorage handles database operations for backfill jobs
+type BackfillStorage struct {
+	db     *sqlx.DB
+	logger utils.Logger
+}
+
+// NewBackfillStorage creates a new backfill storage
+func NewBackfillStorage(db *sqlx.DB, logger utils.Logger) *BackfillStorage {
+	return &BackfillStorage{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ClaimNextJob atomically claims the oldest pending backfill job on one of the
+// given chains and marks it running. Running jobs that have not reported progress
+// within staleAfter are treated as abandoned (e.g. the worker crashed) and may be
+// reclaimed. Returns nil when no job is available.
+func (s *BackfillStorage) ClaimNextJob(ctx context.Context, chainIDs []int64, staleAfter time.Duration) (*models.BackfillJob, error) {
+	var job models.BackfillJob
+
+	query := `
+		UPDATE backfill_jobs
+		SET status = 'running',
+		    error_message = NULL
+		WHERE id = (
+			SELECT id
+			FROM backfill_jobs
+			WHERE chain_id = ANY($1)
+			  AND (status = 'pending'
+			       OR (status = 'running' AND updated_at < NOW() - $2 * INTERVAL '1 second'))
+			ORDER BY created_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, chain_id, contract_address, from_block, to_block, current_block, chunk_size,
+		          kind, events_updated, events_skipped, status, error_message, created_at,
+		          updated_at, completed_at
+	`
+
+	err := s.db.GetContext(ctx, &job, query, pq.Array(chainIDs), int64(staleAfter.Seconds()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim backfill job: %w", err)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"job_id":   job.ID,
+		"kind":     job.Kind,
+		"chain_id": job.ChainID,
+		"contract": job.ContractAddress,
+		"from":     job.FromBlock,
+		"to":       job.ToBlock,
+	}).Info("Backfill job claimed")
+
+	return &job, nil
+}
+
+// GetJob retrieves a backfill job by ID
+func (s *BackfillStorage) GetJob(ctx context.Context, jobID string) (*models.BackfillJob, error) {
+	var job models.BackfillJob
+
+	query := `
+		SELECT id, chain_id, contract_address, from_block, to_block, current_block, chunk_size,
+		       kind, events_updated, events_skipped, status, error_message, created_at,
+		       updated_at, completed_at
+		FROM backfill_jobs
+		WHERE id = $1
+	`
+
+	err := s.db.GetContext(ctx, &job, query, jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("backfill job %s not found", jobID)
+		}
+		return nil, fmt.Errorf("failed to get backfill job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// UpdateProgress records the last fully processed block of a running job.
+// Returns false if the job is no longer running (for example it was cancelled),
+// in which case the worker should stop.
+func (s *BackfillStorage) UpdateProgress(ctx context.Context, jobID string, currentBlock int64) (bool, error) {
+	query := `
+		UPDATE backfill_jobs
+		SET current_block = $1
+		WHERE id = $2 AND status = 'running'
+	`
+
+	result, err := s.db.ExecContext(ctx, query, currentBlock, jobID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update backfill progress: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// UpdateRedecodeProgress records the last fully processed block of a running
+// redecode job and adds the chunk's counts to the job's totals. Returns false
+// if the job is no longer running.
+func (s *BackfillStorage) UpdateRedecodeProgress(ctx context.Context, jobID string, currentBlock, updated, skipped int64) (bool, error) {
+	query := `
+		UPDATE backfill_jobs
+		SET current_block = $1,
+		    events_updated = events_updated + $2,
+		    events_skipped = events_skipped + $3
+		WHERE id = $4 AND status = 'running'
+	`
+
+	result, err := s.db.ExecContext(ctx, query, currentBlock, updated, skipped, jobID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update redecode progress: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// CompleteJob marks a running job as completed
+func (s *BackfillStorage) CompleteJob(ctx context.Context, jobID string) error {
+	query := `
+		UPDATE backfill_jobs
+		SET status = 'completed',
+		    current_block = to_block,
+		    error_message = NULL,
+		    completed_at = NOW()
+		WHERE id = $1 AND status = 'running'
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, jobID); err != nil {
+		return fmt.Errorf("failed to complete backfill job: %w", err)
+	}
+
+	s.logger.WithField("job_id", jobID).Info("Backfill job completed")
+
+	return nil
+}
+
+// FailJob marks a running job as failed with the given error message
+func (s *BackfillStorage) FailJob(ctx context.Context, jobID string, errorMessage string) error {
+	query := `
+		UPDATE backfill_jobs
+		SET status = 'failed',
+		    error_message = $1,
+		    completed_at = NOW()
+		WHERE id = $2 AND status = 'running'
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, errorMessage, jobID); err != nil {
+		return fmt.Errorf("failed to mark backfill job as failed: %w", err)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"job_id": jobID,
+		"error":  errorMessage,
+	}).Warn("Backfill job failed")
+
+	return nil
+}
+
+// ReleaseJob returns a running job to the pending queue so another worker can
+// resume it from its last recorded block (used on graceful shutdown)
+func (s *BackfillStorage) ReleaseJob(ctx context.Context, jobID string) error {
+	query := `
+		UPDATE backfill_jobs
+		SET status = 'pending'
+		WHERE id = $1 AND status = 'running'
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, jobID); err != nil {
+		return fmt.Errorf("failed to release backfill job: %w", err)
+	}
+
+	return nil
+}
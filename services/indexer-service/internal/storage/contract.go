@@ -24,20 +24,21 @@ func NewContractStorage(db *sqlx.DB, logger utils.Logger) *ContractStorage {
 	}
 }
 
-// GetContract retrieves a contract by address
-func (s *ContractStorage) GetContract(ctx context.Context, address models.Address) (*models.Contract, error) {
+// GetContract retrieves a contract by chain and address
+func (s *ContractStorage) GetContract(ctx context.Context, chainID int64, address models.Address) (*models.Contract, error) {
 	var contract models.Contract
 	
 	query := `
-		SELECT id, address, abi, name, start_block, current_block, confirm_blocks, created_at, updated_at
+		SELECT id, chain_id, address, abi, name, start_block, current_block, confirm_blocks, is_active, event_filter,
+		       decode_anonymous_events, created_at, updated_at
 		FROM contracts
-		WHERE address = $1
+		WHERE chain_id = $1 AND address = $2
 	`
 	
-	err := s.db.GetContext(ctx, &contract, query, address)
+	err := s.db.GetContext(ctx, &contract, query, chainID, address)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("contract %s not found", address)
+			return nil, fmt.Errorf("contract %s on chain %d not found", address, chainID)
 		}
 		return nil, fmt.Errorf("failed to get contract: %w", err)
 	}
@@ -50,7 +51,8 @@ func (s *ContractStorage) GetAllContracts(ctx context.Context) ([]*models.Contra
 	var contracts []*models.Contract
 	
 	query := `
-		SELECT id, address, abi, name, start_block, current_block, confirm_blocks, created_at, updated_at
+		SELECT id, chain_id, address, abi, name, start_block, current_block, confirm_blocks, is_active, event_filter,
+		       decode_anonymous_events, created_at, updated_at
 		FROM contracts
 		ORDER BY created_at ASC
 	`
@@ -65,46 +67,103 @@ func (s *ContractStorage) GetAllContracts(ctx context.Context) ([]*models.Contra
 	return contracts, nil
 }
 
+// GetContractsByChain retrieves the monitored contracts on a single chain
+func (s *ContractStorage) GetContractsByChain(ctx context.Context, chainID int64) ([]*models.Contract, error) {
+	var contracts []*models.Contract
+	
+	query := `
+		SELECT id, chain_id, address, abi, name, start_block, current_block, confirm_blocks, is_active, event_filter,
+		       decode_anonymous_events, created_at, updated_at
+		FROM contracts
+		WHERE chain_id = $1
+		ORDER BY created_at ASC
+	`
+	
+	err := s.db.SelectContext(ctx, &contracts, query, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contracts for chain %d: %w", chainID, err)
+	}
+	
+	s.logger.WithFields(map[string]interface{}{
+		"chain_id": chainID,
+		"count":    len(contracts),
+	}).Debug("Retrieved chain contracts")
+	
+	return contracts, nil
+}
+
 // CreateContract inserts a new contract
 func (s *ContractStorage) CreateContract(ctx context.Context, contract *models.Contract) error {
 	query := `
-		INSERT INTO contracts (address, abi, name, start_block, current_block, confirm_blocks)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, created_at, updated_at
+		INSERT INTO contracts (chain_id, address, abi, name, start_block, current_block, confirm_blocks, event_filter,
+		                       decode_anonymous_events)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, is_active, created_at, updated_at
 	`
 	
 	err := s.db.QueryRowContext(
 		ctx,
 		query,
+		contract.ChainID,
 		contract.Address,
 		contract.ABI,
 		contract.Name,
 		contract.StartBlock,
 		contract.CurrentBlock,
 		contract.ConfirmBlocks,
-	).Scan(&contract.ID, &contract.CreatedAt, &contract.UpdatedAt)
+		contract.Filter,
+		contract.DecodeAnonymousEvents,
+	).Scan(&contract.ID, &contract.IsActive, &contract.CreatedAt, &contract.UpdatedAt)
 	
 	if err != nil {
 		return fmt.Errorf("failed to create contract: %w", err)
 	}
 	
 	s.logger.WithFields(map[string]interface{}{
-		"address": contract.Address,
-		"name":    contract.Name,
+		"chain_id": contract.ChainID,
+		"address":  contract.Address,
+		"name":     contract.Name,
 	}).Info("Contract created")
 	
 	return nil
 }
 
+// RewindContractBlock moves a contract's current block back to blockNumber
+// after a reorg; a contract already below it is left alone. exec is the
+// reorg rollback's transaction.
+func (s *ContractStorage) RewindContractBlock(ctx context.Context, exec sqlx.ExtContext, chainID int64, address models.Address, blockNumber int64) error {
+	query := `
+		UPDATE contracts
+		SET current_block = LEAST(current_block, $1), updated_at = NOW()
+		WHERE chain_id = $2 AND address = $3
+	`
+	
+	result, err := exec.ExecContext(ctx, query, blockNumber, chainID, address)
+	if err != nil {
+		return fmt.Errorf("failed to rewind contract block: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("contract %s on chain %d not found", address, chainID)
+	}
+	
+	return nil
+}
+
 // UpdateContractBlock updates the current block for a contract
-func (s *ContractStorage) UpdateContractBlock(ctx context.Context, address models.Address, blockNumber int64) error {
+func (s *ContractStorage) UpdateContractBlock(ctx context.Context, chainID int64, address models.Address, blockNumber int64) error {
 	query := `
 		UPDATE contracts
 		SET current_block = $1, updated_at = NOW()
-		WHERE address = $2
+		WHERE chain_id = $2 AND address = $3
 	`
 	
-	result, err := s.db.ExecContext(ctx, query, blockNumber, address)
+	result, err := s.db.ExecContext(ctx, query, blockNumber, chainID, address)
 	if err != nil {
 		return fmt.Errorf("failed to update contract block: %w", err)
 	}
@@ -115,22 +174,55 @@ func (s *ContractStorage) UpdateContractBlock(ctx context.Context, address model
 	}
 	
 	if rows == 0 {
-		return fmt.Errorf("contract %s not found", address)
+		return fmt.Errorf("contract %s on chain %d not found", address, chainID)
 	}
 	
 	s.logger.WithFields(map[string]interface{}{
-		"address": address,
-		"block":   blockNumber,
+		"chain_id": chainID,
+		"address":  address,
+		"block":    blockNumber,
 	}).Debug("Contract block updated")
 	
 	return nil
 }
 
+// SetContractActive pauses or resumes indexing for a contract. Pausing keeps
+// the contract's events, indexer state and backfill jobs.
+func (s *ContractStorage) SetContractActive(ctx context.Context, chainID int64, address models.Address, active bool) error {
+	query := `
+		UPDATE contracts
+		SET is_active = $1, updated_at = NOW()
+		WHERE chain_id = $2 AND address = $3
+	`
+	
+	result, err := s.db.ExecContext(ctx, query, active, chainID, address)
+	if err != nil {
+		return fmt.Errorf("failed to update contract active flag: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("contract %s on chain %d not found", address, chainID)
+	}
+	
+	s.logger.WithFields(map[string]interface{}{
+		"chain_id":  chainID,
+		"address":   address,
+		"is_active": active,
+	}).Info("Contract active flag updated")
+	
+	return nil
+}
+
 // DeleteContract removes a contract from monitoring
-func (s *ContractStorage) DeleteContract(ctx context.Context, address models.Address) error {
-	query := `DELETE FROM contracts WHERE address = $1`
+func (s *ContractStorage) DeleteContract(ctx context.Context, chainID int64, address models.Address) error {
+	query := `DELETE FROM contracts WHERE chain_id = $1 AND address = $2`
 	
-	result, err := s.db.ExecContext(ctx, query, address)
+	result, err := s.db.ExecContext(ctx, query, chainID, address)
 	if err != nil {
 		return fmt.Errorf("failed to delete contract: %w", err)
 	}
@@ -141,10 +233,13 @@ func (s *ContractStorage) DeleteContract(ctx context.Context, address models.Add
 	}
 	
 	if rows == 0 {
-		return fmt.Errorf("contract %s not found", address)
+		return fmt.Errorf("contract %s on chain %d not found", address, chainID)
 	}
 	
-	s.logger.WithField("address", address).Info("Contract deleted")
+	s.logger.WithFields(map[string]interface{}{
+		"chain_id": chainID,
+		"address":  address,
+	}).Info("Contract deleted")
 	
 	return nil
 }
@@ -152,47 +247,54 @@ func (s *ContractStorage) DeleteContract(ctx context.Context, address models.Add
 // UpsertContract inserts or updates a contract (idempotent)
 func (s *ContractStorage) UpsertContract(ctx context.Context, contract *models.Contract) error {
 	query := `
-		INSERT INTO contracts (address, abi, name, start_block, current_block, confirm_blocks)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (address) DO UPDATE
+		INSERT INTO contracts (chain_id, address, abi, name, start_block, current_block, confirm_blocks, event_filter,
+		                       decode_anonymous_events)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (chain_id, address) DO UPDATE
 		SET abi = EXCLUDED.abi,
 		    name = EXCLUDED.name,
 		    start_block = EXCLUDED.start_block,
 		    confirm_blocks = EXCLUDED.confirm_blocks,
+		    event_filter = EXCLUDED.event_filter,
+		    decode_anonymous_events = EXCLUDED.decode_anonymous_events,
 		    updated_at = NOW()
-		RETURNING id, created_at, updated_at
+		RETURNING id, is_active, created_at, updated_at
 	`
 	
 	err := s.db.QueryRowContext(
 		ctx,
 		query,
+		contract.ChainID,
 		contract.Address,
 		contract.ABI,
 		contract.Name,
 		contract.StartBlock,
 		contract.CurrentBlock,
 		contract.ConfirmBlocks,
-	).Scan(&contract.ID, &contract.CreatedAt, &contract.UpdatedAt)
+		contract.Filter,
+		contract.DecodeAnonymousEvents,
+	).Scan(&contract.ID, &contract.IsActive, &contract.CreatedAt, &contract.UpdatedAt)
 	
 	if err != nil {
 		return fmt.Errorf("failed to upsert contract: %w", err)
 	}
 	
 	s.logger.WithFields(map[string]interface{}{
-		"address": contract.Address,
-		"name":    contract.Name,
+		"chain_id": contract.ChainID,
+		"address":  contract.Address,
+		"name":     contract.Name,
 	}).Info("Contract upserted")
 	
 	return nil
 }
 
 // ContractExists checks if a contract exists
-func (s *ContractStorage) ContractExists(ctx context.Context, address models.Address) (bool, error) {
+func (s *ContractStorage) ContractExists(ctx context.Context, chainID int64, address models.Address) (bool, error) {
 	var exists bool
 	
-	query := `SELECT EXISTS(SELECT 1 FROM contracts WHERE address = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM contracts WHERE chain_id = $1 AND address = $2)`
 	
-	err := s.db.GetContext(ctx, &exists, query, address)
+	err := s.db.GetContext(ctx, &exists, query, chainID, address)
 	if err != nil {
 		return false, fmt.Errorf("failed to check contract existence: %w", err)
 	}
@@ -25,21 +25,33 @@ func NewEventStorage(db *sqlx.DB, logger utils.Logger) *EventStorage {
 	}
 }
 
-// InsertEvent inserts a single event
+// InsertEvent inserts a single event together with the addresses held by its
+// address-typed arguments
 func (s *EventStorage) InsertEvent(ctx context.Context, event *models.Event) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	
 	query := `
 		INSERT INTO events (
-			contract_address, event_name, block_number, block_hash,
-			transaction_hash, transaction_index, log_index, args, timestamp
+			chain_id, contract_address, event_name, block_number, block_hash,
+			transaction_hash, transaction_index, log_index, args, timestamp, finality, raw_log,
+			arg_types, event_signature
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		ON CONFLICT (transaction_hash, log_index) DO NOTHING
-		RETURNING id, created_at
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (chain_id, transaction_hash, log_index) DO UPDATE
+			SET raw_log = EXCLUDED.raw_log
+			WHERE events.raw_log IS NULL AND EXCLUDED.raw_log IS NOT NULL
+		RETURNING id, created_at, (xmax = 0) AS inserted
 	`
 	
-	err := s.db.QueryRowContext(
+	var isNew bool
+	err = tx.QueryRowContext(
 		ctx,
 		query,
+		event.ChainID,
 		event.ContractAddress,
 		event.EventName,
 		event.BlockNumber,
@@ -49,16 +61,30 @@ func (s *EventStorage) InsertEvent(ctx context.Context, event *models.Event) err
 		event.LogIndex,
 		event.Args,
 		event.Timestamp,
-	).Scan(&event.ID, &event.CreatedAt)
+		finalityOrDefault(event.Finality),
+		event.RawLog,
+		event.ArgTypes,
+		event.EventSignature,
+	).Scan(&event.ID, &event.CreatedAt, &isNew)
 	
-	if err != nil {
-		// If it's a "no rows" error, it means ON CONFLICT triggered
-		if strings.Contains(err.Error(), "no rows") {
-			s.logger.Debug("Event already exists, skipping")
-			return nil
-		}
+	if err != nil && !strings.Contains(err.Error(), "no rows") {
 		return fmt.Errorf("failed to insert event: %w", err)
 	}
+	// If it's a "no rows" error, it means ON CONFLICT triggered
+	if err == nil && isNew {
+		if err := insertEventAddresses(ctx, tx, event); err != nil {
+			return err
+		}
+	} else {
+		s.logger.Debug("Event already exists, skipping")
+	}
+	if err := linkEventSubscription(ctx, tx, event); err != nil {
+		return err
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	
 	s.logger.WithFields(map[string]interface{}{
 		"event_name": event.EventName,
@@ -69,40 +95,58 @@ func (s *EventStorage) InsertEvent(ctx context.Context, event *models.Event) err
 	return nil
 }
 
-// InsertEvents inserts multiple events in a batch
-func (s *EventStorage) InsertEvents(ctx context.Context, events []*models.Event) error {
+// InsertEvents inserts multiple events in a batch, with the addresses held by
+// their address-typed arguments, and returns the events that were newly stored
+// (with their IDs set). Events that already exist are skipped.
+func (s *EventStorage) InsertEvents(ctx context.Context, events []*models.Event) ([]*models.Event, error) {
+	return s.InsertEventsWithDeliveries(ctx, events, nil)
+}
+
+// InsertEventsWithDeliveries inserts events like InsertEvents and, in the same
+// transaction, queues the webhook deliveries match returns for the newly
+// stored ones, so no stored event misses its deliveries and no delivery
+// outlives a failed insert. A nil match queues nothing.
+func (s *EventStorage) InsertEventsWithDeliveries(ctx context.Context, events []*models.Event, match DeliveryMatcher) ([]*models.Event, error) {
 	if len(events) == 0 {
-		return nil
+		return nil, nil
 	}
 	
 	// Start a transaction
 	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 	
 	// Prepare the query
 	query := `
 		INSERT INTO events (
-			contract_address, event_name, block_number, block_hash,
-			transaction_hash, transaction_index, log_index, args, timestamp
+			chain_id, contract_address, event_name, block_number, block_hash,
+			transaction_hash, transaction_index, log_index, args, timestamp, finality, raw_log,
+			arg_types, event_signature
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		ON CONFLICT (transaction_hash, log_index) DO NOTHING
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (chain_id, transaction_hash, log_index) DO UPDATE
+			SET raw_log = EXCLUDED.raw_log
+			WHERE events.raw_log IS NULL AND EXCLUDED.raw_log IS NOT NULL
+		RETURNING id, created_at, (xmax = 0) AS inserted
 	`
 	
 	stmt, err := tx.PreparexContext(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to prepare statement: %w", err)
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 	
-	// Insert each event
-	insertedCount := 0
+	// Insert each event; rows skipped by the conflict clause return nothing,
+	// and existing events only gain the raw log they were stored without and
+	// the subscription now indexing them
+	inserted := make([]*models.Event, 0, len(events))
 	for _, event := range events {
-		result, err := stmt.ExecContext(
+		var isNew bool
+		err := stmt.QueryRowxContext(
 			ctx,
+			event.ChainID,
 			event.ContractAddress,
 			event.EventName,
 			event.BlockNumber,
@@ -112,45 +156,69 @@ func (s *EventStorage) InsertEvents(ctx context.Context, events []*models.Event)
 			event.LogIndex,
 			event.Args,
 			event.Timestamp,
-		)
+			finalityOrDefault(event.Finality),
+			event.RawLog,
+			event.ArgTypes,
+			event.EventSignature,
+		).Scan(&event.ID, &event.CreatedAt, &isNew)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to insert event: %w", err)
+		}
+		stored := err == nil && isNew
+		if err := linkEventSubscription(ctx, tx, event); err != nil {
+			return nil, err
+		}
+		if !stored {
+			continue
+		}
+		if err := insertEventAddresses(ctx, tx, event); err != nil {
+			return nil, err
+		}
+		event.Finality = finalityOrDefault(event.Finality)
+		inserted = append(inserted, event)
+	}
+	
+	if match != nil && len(inserted) > 0 {
+		deliveries, err := match(ctx, inserted)
 		if err != nil {
-			return fmt.Errorf("failed to insert event: %w", err)
+			return nil, fmt.Errorf("failed to match webhooks: %w", err)
+		}
+		if err := enqueueDeliveries(ctx, tx, deliveries); err != nil {
+			return nil, err
 		}
-		
-		rows, _ := result.RowsAffected()
-		insertedCount += int(rows)
 	}
 	
 	// Commit the transaction
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 	
 	s.logger.WithFields(map[string]interface{}{
 		"total":    len(events),
-		"inserted": insertedCount,
-		"skipped":  len(events) - insertedCount,
+		"inserted": len(inserted),
+		"skipped":  len(events) - len(inserted),
 	}).Info("Events batch inserted")
 	
-	return nil
+	return inserted, nil
 }
 
 // GetEventsByContract retrieves events for a contract within a block range
-func (s *EventStorage) GetEventsByContract(ctx context.Context, contractAddress models.Address, fromBlock, toBlock int64, limit int) ([]*models.Event, error) {
+func (s *EventStorage) GetEventsByContract(ctx context.Context, chainID int64, contractAddress models.Address, fromBlock, toBlock int64, limit int) ([]*models.Event, error) {
 	var events []*models.Event
 	
 	query := `
-		SELECT id, contract_address, event_name, block_number, block_hash,
-		       transaction_hash, transaction_index, log_index, args, timestamp, created_at
+		SELECT id, chain_id, contract_address, event_name, event_signature, block_number, block_hash,
+		       transaction_hash, transaction_index, log_index, args, arg_types, timestamp, finality, created_at
 		FROM events
-		WHERE contract_address = $1
-		  AND block_number >= $2
-		  AND block_number <= $3
+		WHERE chain_id = $1
+		  AND contract_address = $2
+		  AND block_number >= $3
+		  AND block_number <= $4
 		ORDER BY block_number DESC, log_index DESC
-		LIMIT $4
+		LIMIT $5
 	`
 	
-	err := s.db.SelectContext(ctx, &events, query, contractAddress, fromBlock, toBlock, limit)
+	err := s.db.SelectContext(ctx, &events, query, chainID, contractAddress, fromBlock, toBlock, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events by contract: %w", err)
 	}
@@ -163,8 +231,8 @@ func (s *EventStorage) GetEventsByTransaction(ctx context.Context, txHash models
 	var events []*models.Event
 	
 	query := `
-		SELECT id, contract_address, event_name, block_number, block_hash,
-		       transaction_hash, transaction_index, log_index, args, timestamp, created_at
+		SELECT id, chain_id, contract_address, event_name, event_signature, block_number, block_hash,
+		       transaction_hash, transaction_index, log_index, args, arg_types, timestamp, finality, created_at
 		FROM events
 		WHERE transaction_hash = $1
 		ORDER BY log_index ASC
@@ -183,8 +251,8 @@ func (s *EventStorage) GetLatestEvents(ctx context.Context, limit int) ([]*model
 	var events []*models.Event
 	
 	query := `
-		SELECT id, contract_address, event_name, block_number, block_hash,
-		       transaction_hash, transaction_index, log_index, args, timestamp, created_at
+		SELECT id, chain_id, contract_address, event_name, event_signature, block_number, block_hash,
+		       transaction_hash, transaction_index, log_index, args, arg_types, timestamp, finality, created_at
 		FROM events
 		ORDER BY block_number DESC, log_index DESC
 		LIMIT $1
@@ -213,12 +281,12 @@ func (s *EventStorage) GetEventCount(ctx context.Context) (int64, error) {
 }
 
 // GetEventCountByContract returns the number of events for a specific contract
-func (s *EventStorage) GetEventCountByContract(ctx context.Context, contractAddress models.Address) (int64, error) {
+func (s *EventStorage) GetEventCountByContract(ctx context.Context, chainID int64, contractAddress models.Address) (int64, error) {
 	var count int64
 	
-	query := `SELECT COUNT(*) FROM events WHERE contract_address = $1`
+	query := `SELECT COUNT(*) FROM events WHERE chain_id = $1 AND contract_address = $2`
 	
-	err := s.db.GetContext(ctx, &count, query, contractAddress)
+	err := s.db.GetContext(ctx, &count, query, chainID, contractAddress)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get event count by contract: %w", err)
 	}
@@ -226,43 +294,86 @@ func (s *EventStorage) GetEventCountByContract(ctx context.Context, contractAddr
 	return count, nil
 }
 
-// DeleteEventsByBlock deletes events from a specific block onwards (for reorg handling)
-func (s *EventStorage) DeleteEventsByBlock(ctx context.Context, contractAddress models.Address, fromBlock int64) error {
+// promotedEventColumns lists the columns returned for events promoted to
+// confirmed, so they can be announced like newly stored events
+const promotedEventColumns = `id, chain_id, contract_address, event_name, event_signature, block_number,
+	block_hash, transaction_hash, transaction_index, log_index, args, arg_types, timestamp, finality,
+	created_at`
+
+// ConfirmEvents promotes a contract's pending events up to and including
+// confirmedBlock to confirmed and returns the promoted events. Promoted events
+// move to the end of the event stream so confirmed-only streams pick them up.
+func (s *EventStorage) ConfirmEvents(ctx context.Context, chainID int64, contractAddress models.Address, confirmedBlock int64) ([]*models.Event, error) {
+	var promoted []*models.Event
+	
 	query := `
-		DELETE FROM events
-		WHERE contract_address = $1 AND block_number >= $2
-	`
+		UPDATE events
+		SET finality = 'confirmed', stream_xid = pg_current_xact_id()
+		WHERE chain_id = $1 AND contract_address = $2
+		  AND finality = 'pending' AND block_number <= $3
+		RETURNING ` + promotedEventColumns
+	
+	if err := s.db.SelectContext(ctx, &promoted, query, chainID, contractAddress, confirmedBlock); err != nil {
+		return nil, fmt.Errorf("failed to confirm events: %w", err)
+	}
 	
-	result, err := s.db.ExecContext(ctx, query, contractAddress, fromBlock)
-	if err != nil {
-		return fmt.Errorf("failed to delete events: %w", err)
+	if len(promoted) > 0 {
+		s.logger.WithFields(map[string]interface{}{
+			"chain_id":        chainID,
+			"contract":        contractAddress,
+			"confirmed_block": confirmedBlock,
+			"promoted":        len(promoted),
+		}).Debug("Pending events confirmed")
 	}
 	
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+	return promoted, nil
+}
+
+// DeleteEventsByBlock deletes events from a specific block onwards (for reorg handling)
+// and returns the number of events removed. exec is the reorg rollback's transaction.
+// Their undelivered webhook deliveries are cancelled.
+func (s *EventStorage) DeleteEventsByBlock(ctx context.Context, exec sqlx.ExtContext, chainID int64, contractAddress models.Address, fromBlock int64) (int64, error) {
+	// Webhook deliveries still pending for the removed events are cancelled
+	// in the same statement, since event_id has no foreign key
+	query := `
+		WITH removed AS (
+			DELETE FROM events
+			WHERE chain_id = $1 AND contract_address = $2 AND block_number >= $3
+			RETURNING id
+		), cancelled AS (
+			UPDATE webhook_deliveries
+			SET status = 'cancelled', last_error = 'event removed by chain reorganization'
+			WHERE status = 'pending' AND event_id IN (SELECT id FROM removed)
+		)
+		SELECT COUNT(*) FROM removed
+	`
+	
+	var rows int64
+	if err := exec.QueryRowxContext(ctx, query, chainID, contractAddress, fromBlock).Scan(&rows); err != nil {
+		return 0, fmt.Errorf("failed to delete events: %w", err)
 	}
 	
 	s.logger.WithFields(map[string]interface{}{
+		"chain_id":   chainID,
 		"contract":   contractAddress,
 		"from_block": fromBlock,
 		"deleted":    rows,
 	}).Info("Events deleted for reorg")
 	
-	return nil
+	return rows, nil
 }
 
 // GetMaxBlockNumber returns the highest block number for a contract
-func (s *EventStorage) GetMaxBlockNumber(ctx context.Context, contractAddress models.Address) (int64, error) {
+func (s *EventStorage) GetMaxBlockNumber(ctx context.Context, chainID int64, contractAddress models.Address) (int64, error) {
 	var maxBlock sql.NullInt64
 	
 	query := `
 		SELECT MAX(block_number)
 		FROM events
-		WHERE contract_address = $1
+		WHERE chain_id = $1 AND contract_address = $2
 	`
 	
-	err := s.db.GetContext(ctx, &maxBlock, query, contractAddress)
+	err := s.db.GetContext(ctx, &maxBlock, query, chainID, contractAddress)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get max block number: %w", err)
 	}
@@ -274,3 +385,10 @@ func (s *EventStorage) GetMaxBlockNumber(ctx context.Context, contractAddress mo
 	return maxBlock.Int64, nil
 }
 
+// finalityOrDefault treats events without an explicit finality as confirmed
+func finalityOrDefault(finality models.Finality) models.Finality {
+	if finality == "" {
+		return models.FinalityConfirmed
+	}
+	return finality
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// insertEventAddresses stores the addresses held by the address-typed
+// arguments of a stored event, so address queries can find it through the
+// event_addresses table
+func insertEventAddresses(ctx context.Context, exec sqlx.ExecerContext, event *models.Event) error {
+	addresses := event.AddressArgs()
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(addresses))
+	args := make([]interface{}, 0, len(addresses)*3)
+	for i, addr := range addresses {
+		values[i] = fmt.Sprintf("($%d, $%d, $%d)", i*3+1, i*3+2, i*3+3)
+		args = append(args, addr.EventID, addr.Address, addr.Role)
+	}
+
+	query := `
+		INSERT INTO event_addresses (event_id, address, role)
+		VALUES ` + strings.Join(values, ", ") + `
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := exec.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert addresses of event %d: %w", event.ID, err)
+	}
+
+	return nil
+}
+
+// replaceEventAddresses rewrites the stored addresses of an event whose args
+// have changed
+func replaceEventAddresses(ctx context.Context, exec sqlx.ExecerContext, event *models.Event) error {
+	if _, err := exec.ExecContext(ctx, `DELETE FROM event_addresses WHERE event_id = $1`, event.ID); err != nil {
+		return fmt.Errorf("failed to delete addresses of event %d: %w", event.ID, err)
+	}
+	return insertEventAddresses(ctx, exec, event)
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// GetFactories retrieves the factory rules of a contract
+func (s *ContractStorage) GetFactories(ctx context.Context, chainID int64, address models.Address) ([]*models.ContractFactory, error) {
+	var factories []*models.ContractFactory
+
+	query := `
+		SELECT id, chain_id, contract_address, event_name, address_arg, child_abi, child_name,
+		       from_block, created_at, updated_at
+		FROM contract_factories
+		WHERE chain_id = $1 AND contract_address = $2
+		ORDER BY id ASC
+	`
+
+	if err := s.db.SelectContext(ctx, &factories, query, chainID, address); err != nil {
+		return nil, fmt.Errorf("failed to get contract factories: %w", err)
+	}
+
+	return factories, nil
+}
+
+// GetFactoriesByChain retrieves the factory rules of every contract on a
+// chain, keyed by factory address
+func (s *ContractStorage) GetFactoriesByChain(ctx context.Context, chainID int64) (map[models.Address][]*models.ContractFactory, error) {
+	var factories []*models.ContractFactory
+
+	query := `
+		SELECT id, chain_id, contract_address, event_name, address_arg, child_abi, child_name,
+		       from_block, created_at, updated_at
+		FROM contract_factories
+		WHERE chain_id = $1
+		ORDER BY contract_address, id ASC
+	`
+
+	if err := s.db.SelectContext(ctx, &factories, query, chainID); err != nil {
+		return nil, fmt.Errorf("failed to get contract factories for chain %d: %w", chainID, err)
+	}
+
+	byContract := make(map[models.Address][]*models.ContractFactory)
+	for _, factory := range factories {
+		byContract[factory.ContractAddress] = append(byContract[factory.ContractAddress], factory)
+	}
+
+	return byContract, nil
+}
+
+// RegisterChildContract adds a contract created by a factory, indexed from
+// startBlock with the rule's child ABI and the factory's confirmations. It
+// reports false if the address is already monitored on the chain, whether it
+// was discovered before or added by hand.
+func (s *ContractStorage) RegisterChildContract(ctx context.Context, factory *models.ContractFactory, address models.Address, startBlock int64) (bool, error) {
+	// Addresses added through the admin API keep the caller's casing, so
+	// duplicates are looked for case-insensitively
+	query := `
+		INSERT INTO contracts (chain_id, address, abi, name, start_block, current_block, confirm_blocks, factory_id)
+		SELECT $1, $2, $3, $4, $5, $6, f.confirm_blocks, $7
+		FROM contracts f
+		WHERE f.chain_id = $1 AND f.address = $8
+		  AND NOT EXISTS (SELECT 1 FROM contracts WHERE chain_id = $1 AND LOWER(address) = LOWER($2))
+		ON CONFLICT (chain_id, address) DO NOTHING
+	`
+
+	// current_block is the last indexed block, so the creation block itself
+	// is indexed: children often emit events in the transaction creating them
+	result, err := s.db.ExecContext(ctx,
+		query,
+		factory.ChainID,
+		address,
+		factory.ChildABI,
+		factory.ChildName,
+		startBlock,
+		startBlock-1,
+		factory.ID,
+		factory.ContractAddress,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to register child contract: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return false, nil
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"chain_id":    factory.ChainID,
+		"factory":     factory.ContractAddress,
+		"address":     address,
+		"start_block": startBlock,
+	}).Info("Child contract registered by factory")
+
+	return true, nil
+}
+
+// DeleteDiscoveredContractsFrom removes the contracts factories registered
+// from creation events at or after fromBlock, so they are discovered again
+// from the canonical chain after a reorg. Their indexer state, jobs and
+// unknown logs go with them.
+func (s *ContractStorage) DeleteDiscoveredContractsFrom(ctx context.Context, chainID int64, fromBlock int64) (int64, error) {
+	query := `
+		DELETE FROM contracts
+		WHERE chain_id = $1 AND factory_id IS NOT NULL AND start_block >= $2
+	`
+
+	result, err := s.db.ExecContext(ctx, query, chainID, fromBlock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete discovered contracts: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// GetEventsForRedecode returns a contract's stored events in a block range in
+// log order, with their raw logs
+func (s *EventStorage) GetEventsForRedecode(ctx context.Context, chainID int64, contractAddress models.Address, fromBlock, toBlock int64) ([]*models.Event, error) {
+	query := `
+		SELECT id, chain_id, contract_address, event_name, event_signature, block_number, block_hash,
+		       transaction_hash, transaction_index, log_index, args, arg_types, raw_log,
+		       timestamp, finality, created_at
+		FROM events
+		WHERE chain_id = $1 AND contract_address = $2 AND block_number BETWEEN $3 AND $4
+		ORDER BY block_number ASC, log_index ASC
+	`
+
+	var events []*models.Event
+	if err := s.db.SelectContext(ctx, &events, query, chainID, contractAddress, fromBlock, toBlock); err != nil {
+		return nil, fmt.Errorf("failed to get events for redecode: %w", err)
+	}
+
+	return events, nil
+}
+
+// UpdateDecodedEvents rewrites the name, signature, args and argument types of
+// re-decoded events, and the addresses held by their args, in a single
+// transaction and returns the number of events that changed
+func (s *EventStorage) UpdateDecodedEvents(ctx context.Context, events []*models.Event) (int64, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE events
+		SET event_name = $1, args = $2, arg_types = $3, event_signature = $4
+		WHERE id = $5 AND (event_name <> $1 OR args <> $2 OR arg_types <> $3 OR event_signature <> $4)
+	`
+
+	stmt, err := tx.PreparexContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	var updated int64
+	for _, event := range events {
+		result, err := stmt.ExecContext(ctx, event.EventName, event.Args, event.ArgTypes, event.EventSignature, event.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update event %d: %w", event.ID, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			continue
+		}
+		if err := replaceEventAddresses(ctx, tx, event); err != nil {
+			return 0, err
+		}
+		updated += rows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// ReorgStorage handles database operations for the reorg audit log
+type ReorgStorage struct {
+	db     *sqlx.DB
+	logger utils.Logger
+}
+
+// NewReorgStorage creates a new reorg storage
+func NewReorgStorage(db *sqlx.DB, logger utils.Logger) *ReorgStorage {
+	return &ReorgStorage{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// RecordReorg appends a handled reorganization to the audit log
+func (s *ReorgStorage) RecordReorg(ctx context.Context, record *models.ReorgRecord) error {
+	query := `
+		INSERT INTO reorg_log (
+			chain_id, fork_point, detected_at_block, depth, contracts_affected, events_removed
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, detected_at
+	`
+	
+	err := s.db.QueryRowContext(
+		ctx,
+		query,
+		record.ChainID,
+		record.ForkPoint,
+		record.DetectedAtBlock,
+		record.Depth,
+		record.ContractsAffected,
+		record.EventsRemoved,
+	).Scan(&record.ID, &record.DetectedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record reorg: %w", err)
+	}
+	
+	s.logger.WithFields(map[string]interface{}{
+		"chain_id":   record.ChainID,
+		"fork_point": record.ForkPoint,
+		"depth":      record.Depth,
+	}).Info("Reorg recorded")
+	
+	return nil
+}
+
+// GetRecentReorgs retrieves the most recent reorganizations on a chain
+func (s *ReorgStorage) GetRecentReorgs(ctx context.Context, chainID int64, limit int) ([]*models.ReorgRecord, error) {
+	var records []*models.ReorgRecord
+	
+	query := `
+		SELECT id, chain_id, fork_point, detected_at_block, depth,
+		       contracts_affected, events_removed, detected_at
+		FROM reorg_log
+		WHERE chain_id = $1
+		ORDER BY detected_at DESC
+		LIMIT $2
+	`
+	
+	if err := s.db.SelectContext(ctx, &records, query, chainID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get reorgs: %w", err)
+	}
+	
+	return records, nil
+}
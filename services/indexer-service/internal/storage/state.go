@@ -25,20 +25,20 @@ func NewStateStorage(db *sqlx.DB, logger utils.Logger) *StateStorage {
 }
 
 // GetIndexerState retrieves the indexer state for a contract
-func (s *StateStorage) GetIndexerState(ctx context.Context, contractAddress models.Address) (*models.IndexerState, error) {
+func (s *StateStorage) GetIndexerState(ctx context.Context, chainID int64, contractAddress models.Address) (*models.IndexerState, error) {
 	var state models.IndexerState
 	
 	query := `
-		SELECT id, contract_address, last_indexed_block, last_block_hash,
+		SELECT id, chain_id, contract_address, last_indexed_block, last_block_hash,
 		       last_processed_at, status, error_count, last_error, created_at, updated_at
 		FROM indexer_state
-		WHERE contract_address = $1
+		WHERE chain_id = $1 AND contract_address = $2
 	`
 	
-	err := s.db.GetContext(ctx, &state, query, contractAddress)
+	err := s.db.GetContext(ctx, &state, query, chainID, contractAddress)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("indexer state for contract %s not found", contractAddress)
+			return nil, fmt.Errorf("indexer state for contract %s on chain %d not found", contractAddress, chainID)
 		}
 		return nil, fmt.Errorf("failed to get indexer state: %w", err)
 	}
@@ -50,11 +50,11 @@ func (s *StateStorage) GetIndexerState(ctx context.Context, contractAddress mode
 func (s *StateStorage) SaveIndexerState(ctx context.Context, state *models.IndexerState) error {
 	query := `
 		INSERT INTO indexer_state (
-			contract_address, last_indexed_block, last_block_hash,
+			chain_id, contract_address, last_indexed_block, last_block_hash,
 			last_processed_at, status, error_count, last_error
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		ON CONFLICT (contract_address) DO UPDATE
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (chain_id, contract_address) DO UPDATE
 		SET last_indexed_block = EXCLUDED.last_indexed_block,
 		    last_block_hash = EXCLUDED.last_block_hash,
 		    last_processed_at = EXCLUDED.last_processed_at,
@@ -68,6 +68,7 @@ func (s *StateStorage) SaveIndexerState(ctx context.Context, state *models.Index
 	err := s.db.QueryRowContext(
 		ctx,
 		query,
+		state.ChainID,
 		state.ContractAddress,
 		state.LastIndexedBlock,
 		state.LastBlockHash,
@@ -82,6 +83,7 @@ func (s *StateStorage) SaveIndexerState(ctx context.Context, state *models.Index
 	}
 	
 	s.logger.WithFields(map[string]interface{}{
+		"chain_id": state.ChainID,
 		"contract": state.ContractAddress,
 		"block":    state.LastIndexedBlock,
 		"status":   state.Status,
@@ -91,17 +93,17 @@ func (s *StateStorage) SaveIndexerState(ctx context.Context, state *models.Index
 }
 
 // UpdateLastIndexedBlock updates only the last indexed block
-func (s *StateStorage) UpdateLastIndexedBlock(ctx context.Context, contractAddress models.Address, blockNumber int64, blockHash models.Hash) error {
+func (s *StateStorage) UpdateLastIndexedBlock(ctx context.Context, chainID int64, contractAddress models.Address, blockNumber int64, blockHash models.Hash) error {
 	query := `
 		UPDATE indexer_state
 		SET last_indexed_block = $1,
 		    last_block_hash = $2,
 		    last_processed_at = NOW(),
 		    updated_at = NOW()
-		WHERE contract_address = $3
+		WHERE chain_id = $3 AND contract_address = $4
 	`
 	
-	result, err := s.db.ExecContext(ctx, query, blockNumber, blockHash, contractAddress)
+	result, err := s.db.ExecContext(ctx, query, blockNumber, blockHash, chainID, contractAddress)
 	if err != nil {
 		return fmt.Errorf("failed to update last indexed block: %w", err)
 	}
@@ -114,6 +116,7 @@ func (s *StateStorage) UpdateLastIndexedBlock(ctx context.Context, contractAddre
 	if rows == 0 {
 		// State doesn't exist, create it
 		state := &models.IndexerState{
+			ChainID:           chainID,
 			ContractAddress:   contractAddress,
 			LastIndexedBlock:  blockNumber,
 			LastBlockHash:     blockHash,
@@ -126,17 +129,38 @@ func (s *StateStorage) UpdateLastIndexedBlock(ctx context.Context, contractAddre
 	return nil
 }
 
+// RewindLastIndexedBlock moves the last indexed block back to blockNumber after a
+// reorg and flags the contract as recovering from it. The stored block hash is
+// cleared because it belonged to the abandoned branch. exec is the reorg
+// rollback's transaction.
+func (s *StateStorage) RewindLastIndexedBlock(ctx context.Context, exec sqlx.ExtContext, chainID int64, contractAddress models.Address, blockNumber int64) error {
+	query := `
+		UPDATE indexer_state
+		SET last_indexed_block = LEAST(last_indexed_block, $1),
+		    last_block_hash = '',
+		    status = 'reorg_recovery',
+		    updated_at = NOW()
+		WHERE chain_id = $2 AND contract_address = $3
+	`
+	
+	if _, err := exec.ExecContext(ctx, query, blockNumber, chainID, contractAddress); err != nil {
+		return fmt.Errorf("failed to rewind last indexed block: %w", err)
+	}
+	
+	return nil
+}
+
 // IncrementErrorCount increments the error count for a contract
-func (s *StateStorage) IncrementErrorCount(ctx context.Context, contractAddress models.Address, errorMessage string) error {
+func (s *StateStorage) IncrementErrorCount(ctx context.Context, chainID int64, contractAddress models.Address, errorMessage string) error {
 	query := `
 		UPDATE indexer_state
 		SET error_count = error_count + 1,
 		    last_error = $1,
 		    updated_at = NOW()
-		WHERE contract_address = $2
+		WHERE chain_id = $2 AND contract_address = $3
 	`
 	
-	_, err := s.db.ExecContext(ctx, query, errorMessage, contractAddress)
+	_, err := s.db.ExecContext(ctx, query, errorMessage, chainID, contractAddress)
 	if err != nil {
 		return fmt.Errorf("failed to increment error count: %w", err)
 	}
@@ -145,16 +169,16 @@ func (s *StateStorage) IncrementErrorCount(ctx context.Context, contractAddress
 }
 
 // ResetErrorCount resets the error count for a contract
-func (s *StateStorage) ResetErrorCount(ctx context.Context, contractAddress models.Address) error {
+func (s *StateStorage) ResetErrorCount(ctx context.Context, chainID int64, contractAddress models.Address) error {
 	query := `
 		UPDATE indexer_state
 		SET error_count = 0,
 		    last_error = NULL,
 		    updated_at = NOW()
-		WHERE contract_address = $1
+		WHERE chain_id = $1 AND contract_address = $2
 	`
 	
-	_, err := s.db.ExecContext(ctx, query, contractAddress)
+	_, err := s.db.ExecContext(ctx, query, chainID, contractAddress)
 	if err != nil {
 		return fmt.Errorf("failed to reset error count: %w", err)
 	}
@@ -163,20 +187,21 @@ func (s *StateStorage) ResetErrorCount(ctx context.Context, contractAddress mode
 }
 
 // UpdateStatus updates the indexer status for a contract
-func (s *StateStorage) UpdateStatus(ctx context.Context, contractAddress models.Address, status string) error {
+func (s *StateStorage) UpdateStatus(ctx context.Context, chainID int64, contractAddress models.Address, status string) error {
 	query := `
 		UPDATE indexer_state
 		SET status = $1,
 		    updated_at = NOW()
-		WHERE contract_address = $2
+		WHERE chain_id = $2 AND contract_address = $3
 	`
 	
-	_, err := s.db.ExecContext(ctx, query, status, contractAddress)
+	_, err := s.db.ExecContext(ctx, query, status, chainID, contractAddress)
 	if err != nil {
 		return fmt.Errorf("failed to update status: %w", err)
 	}
 	
 	s.logger.WithFields(map[string]interface{}{
+		"chain_id": chainID,
 		"contract": contractAddress,
 		"status":   status,
 	}).Info("Indexer status updated")
@@ -184,15 +209,17 @@ func (s *StateStorage) UpdateStatus(ctx context.Context, contractAddress models.
 	return nil
 }
 
-// GetAllIndexerStates retrieves all indexer states
+// GetAllIndexerStates retrieves all indexer states, of contracts and of topic
+// subscriptions
 func (s *StateStorage) GetAllIndexerStates(ctx context.Context) ([]*models.IndexerState, error) {
 	var states []*models.IndexerState
 	
 	query := `
-		SELECT id, contract_address, last_indexed_block, last_block_hash,
-		       last_processed_at, status, error_count, last_error, created_at, updated_at
+		SELECT id, chain_id, COALESCE(contract_address, '') AS contract_address, subscription_id,
+		       last_indexed_block, last_block_hash, last_processed_at, status, error_count,
+		       last_error, created_at, updated_at
 		FROM indexer_state
-		ORDER BY contract_address ASC
+		ORDER BY chain_id ASC, contract_address ASC, subscription_id ASC
 	`
 	
 	err := s.db.SelectContext(ctx, &states, query)
@@ -204,30 +231,33 @@ func (s *StateStorage) GetAllIndexerStates(ctx context.Context) ([]*models.Index
 }
 
 // DeleteIndexerState deletes the indexer state for a contract
-func (s *StateStorage) DeleteIndexerState(ctx context.Context, contractAddress models.Address) error {
-	query := `DELETE FROM indexer_state WHERE contract_address = $1`
+func (s *StateStorage) DeleteIndexerState(ctx context.Context, chainID int64, contractAddress models.Address) error {
+	query := `DELETE FROM indexer_state WHERE chain_id = $1 AND contract_address = $2`
 	
-	_, err := s.db.ExecContext(ctx, query, contractAddress)
+	_, err := s.db.ExecContext(ctx, query, chainID, contractAddress)
 	if err != nil {
 		return fmt.Errorf("failed to delete indexer state: %w", err)
 	}
 	
-	s.logger.WithField("contract", contractAddress).Info("Indexer state deleted")
+	s.logger.WithFields(map[string]interface{}{
+		"chain_id": chainID,
+		"contract": contractAddress,
+	}).Info("Indexer state deleted")
 	
 	return nil
 }
 
 // InitializeState creates initial state for a contract if it doesn't exist
-func (s *StateStorage) InitializeState(ctx context.Context, contractAddress models.Address, startBlock int64) error {
+func (s *StateStorage) InitializeState(ctx context.Context, chainID int64, contractAddress models.Address, startBlock int64) error {
 	query := `
 		INSERT INTO indexer_state (
-			contract_address, last_indexed_block, status, error_count
+			chain_id, contract_address, last_indexed_block, status, error_count
 		)
-		VALUES ($1, $2, 'active', 0)
-		ON CONFLICT (contract_address) DO NOTHING
+		VALUES ($1, $2, $3, 'active', 0)
+		ON CONFLICT (chain_id, contract_address) DO NOTHING
 	`
 	
-	_, err := s.db.ExecContext(ctx, query, contractAddress, startBlock-1)
+	_, err := s.db.ExecContext(ctx, query, chainID, contractAddress, startBlock-1)
 	if err != nil {
 		return fmt.Errorf("failed to initialize state: %w", err)
 	}
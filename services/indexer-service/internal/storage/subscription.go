@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// GetSubscriptionsByChain retrieves the active topic subscriptions of a chain
+// with their cursors. A subscription without a state row has indexed nothing
+// yet and starts at its start block.
+func (s *StateStorage) GetSubscriptionsByChain(ctx context.Context, chainID int64) ([]*models.TopicSubscription, error) {
+	var subscriptions []*models.TopicSubscription
+
+	query := `
+		SELECT t.id, t.chain_id, t.name, t.abi, t.event_name, t.event_signature, t.start_block,
+		       t.confirm_blocks, t.is_active,
+		       COALESCE(st.last_indexed_block, t.start_block - 1) AS current_block,
+		       t.created_at, t.updated_at
+		FROM topic_subscriptions t
+		LEFT JOIN indexer_state st ON st.subscription_id = t.id
+		WHERE t.chain_id = $1 AND t.is_active = TRUE
+		ORDER BY t.id ASC
+	`
+
+	if err := s.db.SelectContext(ctx, &subscriptions, query, chainID); err != nil {
+		return nil, fmt.Errorf("failed to get topic subscriptions for chain %d: %w", chainID, err)
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateSubscriptionBlock moves a subscription's cursor to blockNumber and
+// clears its error count, creating its state row if needed
+func (s *StateStorage) UpdateSubscriptionBlock(ctx context.Context, subscription *models.TopicSubscription, blockNumber int64, blockHash models.Hash) error {
+	query := `
+		INSERT INTO indexer_state (
+			chain_id, subscription_id, last_indexed_block, last_block_hash,
+			last_processed_at, status, error_count
+		)
+		VALUES ($1, $2, $3, $4, NOW(), 'active', 0)
+		ON CONFLICT (subscription_id) WHERE subscription_id IS NOT NULL DO UPDATE
+		SET last_indexed_block = EXCLUDED.last_indexed_block,
+		    last_block_hash = EXCLUDED.last_block_hash,
+		    last_processed_at = NOW(),
+		    error_count = 0,
+		    last_error = NULL,
+		    updated_at = NOW()
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, subscription.ChainID, subscription.ID, blockNumber, blockHash); err != nil {
+		return fmt.Errorf("failed to update subscription block: %w", err)
+	}
+
+	return nil
+}
+
+// IncrementSubscriptionErrorCount records a failure against a subscription
+func (s *StateStorage) IncrementSubscriptionErrorCount(ctx context.Context, subscriptionID int64, errorMessage string) error {
+	query := `
+		UPDATE indexer_state
+		SET error_count = error_count + 1,
+		    last_error = $1,
+		    updated_at = NOW()
+		WHERE subscription_id = $2
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, errorMessage, subscriptionID); err != nil {
+		return fmt.Errorf("failed to increment subscription error count: %w", err)
+	}
+
+	return nil
+}
+
+// RewindSubscriptions moves the cursor of every subscription on a chain back
+// to blockNumber after a reorg, returning the number of subscriptions rewound.
+// exec is the reorg rollback's transaction.
+func (s *StateStorage) RewindSubscriptions(ctx context.Context, exec sqlx.ExtContext, chainID int64, blockNumber int64) (int64, error) {
+	query := `
+		UPDATE indexer_state
+		SET last_indexed_block = $1,
+		    last_block_hash = '',
+		    updated_at = NOW()
+		WHERE chain_id = $2 AND subscription_id IS NOT NULL AND last_indexed_block > $1
+	`
+
+	result, err := exec.ExecContext(ctx, query, blockNumber, chainID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rewind subscriptions: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ConfirmSubscriptionEvents promotes a subscription's pending events up to
+// and including confirmedBlock to confirmed and returns the promoted events
+func (s *EventStorage) ConfirmSubscriptionEvents(ctx context.Context, subscriptionID int64, confirmedBlock int64) ([]*models.Event, error) {
+	var promoted []*models.Event
+
+	query := `
+		UPDATE events
+		SET finality = 'confirmed', stream_xid = pg_current_xact_id()
+		WHERE finality = 'pending' AND block_number <= $2
+		  AND id IN (SELECT event_id FROM event_subscriptions WHERE subscription_id = $1)
+		RETURNING ` + promotedEventColumns + `, $1::bigint AS subscription_id`
+
+	if err := s.db.SelectContext(ctx, &promoted, query, subscriptionID, confirmedBlock); err != nil {
+		return nil, fmt.Errorf("failed to confirm subscription events: %w", err)
+	}
+
+	return promoted, nil
+}
+
+// DeleteSubscriptionEventsByBlock deletes the events every subscription on a
+// chain indexed from fromBlock onwards, for reorg handling, and returns the
+// number of events removed. exec is the reorg rollback's transaction.
+func (s *EventStorage) DeleteSubscriptionEventsByBlock(ctx context.Context, exec sqlx.ExtContext, chainID int64, fromBlock int64) (int64, error) {
+	// Pending webhook deliveries of the removed events are cancelled as in
+	// DeleteEventsByBlock
+	query := `
+		WITH removed AS (
+			DELETE FROM events e
+			WHERE e.chain_id = $1 AND e.block_number >= $2
+			  AND EXISTS (SELECT 1 FROM event_subscriptions es WHERE es.event_id = e.id)
+			RETURNING e.id
+		), cancelled AS (
+			UPDATE webhook_deliveries
+			SET status = 'cancelled', last_error = 'event removed by chain reorganization'
+			WHERE status = 'pending' AND event_id IN (SELECT id FROM removed)
+		)
+		SELECT COUNT(*) FROM removed
+	`
+
+	var rows int64
+	if err := exec.QueryRowxContext(ctx, query, chainID, fromBlock).Scan(&rows); err != nil {
+		return 0, fmt.Errorf("failed to delete subscription events: %w", err)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"chain_id":   chainID,
+		"from_block": fromBlock,
+		"deleted":    rows,
+	}).Info("Subscription events deleted for reorg")
+
+	return rows, nil
+}
+
+// linkEventSubscription records that the event's subscription indexed it. The
+// event is looked up by its log, so a subscription is linked to events that a
+// monitored contract or another subscription stored first.
+func linkEventSubscription(ctx context.Context, exec sqlx.ExecerContext, event *models.Event) error {
+	if event.SubscriptionID == nil {
+		return nil
+	}
+
+	query := `
+		INSERT INTO event_subscriptions (event_id, subscription_id)
+		SELECT id, $4 FROM events
+		WHERE chain_id = $1 AND transaction_hash = $2 AND log_index = $3
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := exec.ExecContext(ctx, query, event.ChainID, event.TransactionHash, event.LogIndex, *event.SubscriptionID); err != nil {
+		return fmt.Errorf("failed to link event to subscription %d: %w", *event.SubscriptionID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// unknownLogColumns lists the unknown_logs columns in models.UnknownLog order
+const unknownLogColumns = `id, chain_id, contract_address, block_number, block_hash, block_timestamp,
+	transaction_hash, transaction_index, log_index, topics, data, reason,
+	retry_requested_at, created_at, updated_at`
+
+// InsertUnknownLogs stores logs that could not be decoded. A log already
+// stored keeps its row and only records the latest failure reason.
+func (s *EventStorage) InsertUnknownLogs(ctx context.Context, logs []*models.UnknownLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO unknown_logs (
+			chain_id, contract_address, block_number, block_hash, block_timestamp,
+			transaction_hash, transaction_index, log_index, topics, data, reason
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (chain_id, transaction_hash, log_index) DO UPDATE SET reason = EXCLUDED.reason
+	`
+
+	stmt, err := tx.PreparexContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, log := range logs {
+		if _, err := stmt.ExecContext(
+			ctx,
+			log.ChainID,
+			log.ContractAddress,
+			log.BlockNumber,
+			log.BlockHash,
+			log.BlockTimestamp,
+			log.TransactionHash,
+			log.TransactionIndex,
+			log.LogIndex,
+			log.Topics,
+			log.Data,
+			log.Reason,
+		); err != nil {
+			return fmt.Errorf("failed to insert unknown log: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.WithField("count", len(logs)).Warn("Stored logs that could not be decoded")
+
+	return nil
+}
+
+// GetUnknownLogsForRetry returns up to limit unknown logs of a chain's active
+// contracts that are waiting to be decoded again, oldest request first. Logs
+// of paused contracts wait until the contract is resumed.
+func (s *EventStorage) GetUnknownLogsForRetry(ctx context.Context, chainID int64, limit int) ([]*models.UnknownLog, error) {
+	query := `
+		SELECT ` + unknownLogColumns + `
+		FROM unknown_logs
+		WHERE chain_id = $1 AND retry_requested_at IS NOT NULL
+			AND contract_address IN (SELECT address FROM contracts WHERE chain_id = $1 AND is_active)
+		ORDER BY retry_requested_at ASC, id ASC
+		LIMIT $2
+	`
+
+	var logs []*models.UnknownLog
+	if err := s.db.SelectContext(ctx, &logs, query, chainID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get unknown logs for retry: %w", err)
+	}
+
+	return logs, nil
+}
+
+// FinishUnknownLogRetry records a failed retry: the log keeps waiting for a
+// fix with the new reason, and is not retried again until requested
+func (s *EventStorage) FinishUnknownLogRetry(ctx context.Context, id int64, reason string) error {
+	query := `
+		UPDATE unknown_logs
+		SET reason = $2, retry_requested_at = NULL
+		WHERE id = $1
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, id, reason); err != nil {
+		return fmt.Errorf("failed to update unknown log: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDecodedUnknownLogs removes the unknown logs that the given events were
+// decoded from and returns the number removed
+func (s *EventStorage) DeleteDecodedUnknownLogs(ctx context.Context, events []*models.Event) (int64, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var deleted int64
+	for _, event := range events {
+		result, err := tx.ExecContext(ctx,
+			"DELETE FROM unknown_logs WHERE chain_id = $1 AND transaction_hash = $2 AND log_index = $3",
+			event.ChainID, event.TransactionHash, event.LogIndex,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete unknown log: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		deleted += rows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// DeleteUnknownLogsByBlock deletes unknown logs from a specific block onwards
+// (for reorg handling) and returns the number removed. exec is the reorg
+// rollback's transaction.
+func (s *EventStorage) DeleteUnknownLogsByBlock(ctx context.Context, exec sqlx.ExtContext, chainID int64, contractAddress models.Address, fromBlock int64) (int64, error) {
+	query := `
+		DELETE FROM unknown_logs
+		WHERE chain_id = $1 AND contract_address = $2 AND block_number >= $3
+	`
+
+	result, err := exec.ExecContext(ctx, query, chainID, contractAddress, fromBlock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete unknown logs: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// ClaimedDelivery is a due webhook delivery together with its endpoint
+type ClaimedDelivery struct {
+	models.WebhookDelivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+// WebhookStorage handles database operations for webhooks and their deliveries
+type WebhookStorage struct {
+	db     *sqlx.DB
+	logger utils.Logger
+}
+
+// NewWebhookStorage creates a new webhook storage
+func NewWebhookStorage(db *sqlx.DB, logger utils.Logger) *WebhookStorage {
+	return &WebhookStorage{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetActiveWebhooks retrieves the active webhooks on the given chains
+func (s *WebhookStorage) GetActiveWebhooks(ctx context.Context, chainIDs []int64) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+
+	query := `
+		SELECT id, chain_id, url, secret, description, contract_address, event_name,
+		       arg_filters, is_active, created_at, updated_at
+		FROM webhooks
+		WHERE is_active AND chain_id = ANY($1)
+	`
+
+	if err := s.db.SelectContext(ctx, &webhooks, query, pq.Array(chainIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get active webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// DeliveryMatcher returns the webhook deliveries owed for newly stored events
+type DeliveryMatcher func(ctx context.Context, events []*models.Event) ([]*models.WebhookDelivery, error)
+
+// enqueueDeliveries queues deliveries for their webhooks in the transaction
+// that stores their events. An event is queued at most once per webhook.
+func enqueueDeliveries(ctx context.Context, tx *sqlx.Tx, deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, payload)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	stmt, err := tx.PreparexContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, delivery := range deliveries {
+		if _, err := stmt.ExecContext(ctx, delivery.WebhookID, delivery.EventID, delivery.Payload); err != nil {
+			return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries claims up to limit pending deliveries whose next attempt is
+// due and counts the attempt. A claimed delivery is leased for leaseFor: if the
+// worker dies before recording the outcome it becomes due again afterwards.
+func (s *WebhookStorage) ClaimDueDeliveries(ctx context.Context, limit int, leaseFor time.Duration) ([]*ClaimedDelivery, error) {
+	var deliveries []*ClaimedDelivery
+
+	query := `
+		WITH claimed AS (
+			UPDATE webhook_deliveries
+			SET attempts = attempts + 1,
+			    next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+			WHERE id IN (
+				SELECT d.id
+				FROM webhook_deliveries d
+				JOIN webhooks w ON w.id = d.webhook_id
+				WHERE d.status = 'pending'
+				  AND d.next_attempt_at <= NOW()
+				  AND w.is_active
+				ORDER BY d.next_attempt_at ASC
+				LIMIT $1
+				FOR UPDATE OF d SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT c.id, c.webhook_id, c.event_id, c.payload, c.status, c.attempts, c.next_attempt_at,
+		       c.last_status_code, c.last_error, c.created_at, c.updated_at, c.delivered_at,
+		       w.url, w.secret
+		FROM claimed c
+		JOIN webhooks w ON w.id = c.webhook_id
+	`
+
+	if err := s.db.SelectContext(ctx, &deliveries, query, limit, int64(leaseFor.Seconds())); err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// MarkDelivered records a successful delivery
+func (s *WebhookStorage) MarkDelivered(ctx context.Context, deliveryID int64, statusCode int) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'delivered',
+		    last_status_code = $1,
+		    last_error = NULL,
+		    delivered_at = NOW()
+		WHERE id = $2
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, statusCode, deliveryID); err != nil {
+		return fmt.Errorf("failed to mark webhook delivery delivered: %w", err)
+	}
+
+	return nil
+}
+
+// ScheduleRetry records a failed attempt and when to try again. A zero
+// statusCode means no response was received.
+func (s *WebhookStorage) ScheduleRetry(ctx context.Context, deliveryID int64, nextAttemptAt time.Time, statusCode int, errorMessage string) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $1,
+		    last_status_code = NULLIF($2, 0),
+		    last_error = $3
+		WHERE id = $4
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, nextAttemptAt, statusCode, errorMessage, deliveryID); err != nil {
+		return fmt.Errorf("failed to schedule webhook retry: %w", err)
+	}
+
+	return nil
+}
+
+// MarkDeadLetter moves a delivery to the dead-letter store after it failed for
+// good, unless a reorg cancelled it during the attempt
+func (s *WebhookStorage) MarkDeadLetter(ctx context.Context, deliveryID int64, statusCode int, errorMessage string) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'dead_letter',
+		    last_status_code = NULLIF($1, 0),
+		    last_error = $2
+		WHERE id = $3 AND status = 'pending'
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, statusCode, errorMessage, deliveryID); err != nil {
+		return fmt.Errorf("failed to dead-letter webhook delivery: %w", err)
+	}
+
+	return nil
+}
@@ -55,6 +55,50 @@ const ERC20ABI = `[
 	}
 ]`
 
+// ABI with an overloaded Transfer event and two anonymous events, for testing
+// lookups by signature and anonymous decoding
+const OverloadedABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "value", "type": "uint256"}
+		],
+		"name": "Transfer",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "value", "type": "uint256"},
+			{"indexed": false, "name": "data", "type": "bytes"}
+		],
+		"name": "Transfer",
+		"type": "event"
+	},
+	{
+		"anonymous": true,
+		"inputs": [
+			{"indexed": true, "name": "owner", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"}
+		],
+		"name": "Deposit",
+		"type": "event"
+	},
+	{
+		"anonymous": true,
+		"inputs": [
+			{"indexed": false, "name": "amount", "type": "uint256"},
+			{"indexed": false, "name": "closed", "type": "bool"}
+		],
+		"name": "Settled",
+		"type": "event"
+	}
+]`
+
 // Invalid ABI for error testing
 const InvalidABI = `[{"invalid": "json structure"`
 
@@ -0,0 +1,169 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/smart-contract-event-indexer/indexer-service/internal/storage"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backfillTestChainID keeps the jobs of these tests apart from anything else
+// queued in the database
+const backfillTestChainID = 990001
+
+const backfillTestContract = "0x00000000000000000000000000000000000b0f11"
+
+// setupBackfillStorage connects to the database and registers the contract
+// the test jobs target, removing the jobs of earlier runs
+func setupBackfillStorage(t *testing.T) (*sqlx.DB, *storage.BackfillStorage) {
+	t.Helper()
+
+	db, err := sqlx.Connect("postgres", loadTestConfig().DatabaseURL)
+	require.NoError(t, err, "Failed to connect to database")
+	t.Cleanup(func() { db.Close() })
+
+	// Deleting the contract cascades to its jobs
+	_, err = db.Exec("DELETE FROM contracts WHERE chain_id = $1", backfillTestChainID)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+		INSERT INTO contracts (chain_id, address, abi, name, start_block, current_block, confirm_blocks)
+		VALUES ($1, $2, $3, 'BackfillTest', 0, 0, 1)
+	`, backfillTestChainID, backfillTestContract, TestERC20ABI)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM contracts WHERE chain_id = $1", backfillTestChainID)
+	})
+
+	logger := utils.NewLogger("integration-test", "info", "text")
+	return db, storage.NewBackfillStorage(db, logger)
+}
+
+// queueBackfillJob inserts a pending job the way the admin service does
+func queueBackfillJob(t *testing.T, db *sqlx.DB, fromBlock, toBlock int64) string {
+	t.Helper()
+
+	var jobID string
+	err := db.QueryRow(`
+		INSERT INTO backfill_jobs (chain_id, contract_address, from_block, to_block, current_block, chunk_size, kind, status)
+		VALUES ($1, $2, $3, $4, $3 - 1, 100, $5, $6)
+		RETURNING id
+	`, backfillTestChainID, backfillTestContract, fromBlock, toBlock, models.BackfillKindFetch, models.BackfillStatusPending).Scan(&jobID)
+	require.NoError(t, err)
+	return jobID
+}
+
+func TestBackfillStorage_ClaimNextJob(t *testing.T) {
+	requireIntegrationEnv(t)
+	db, backfills := setupBackfillStorage(t)
+	ctx := context.Background()
+	chains := []int64{backfillTestChainID}
+
+	first := queueBackfillJob(t, db, 0, 499)
+	second := queueBackfillJob(t, db, 500, 999)
+
+	// Oldest job first, with nothing of its range counted as processed
+	job, err := backfills.ClaimNextJob(ctx, chains, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, first, job.ID)
+	assert.Equal(t, models.BackfillStatusRunning, job.Status)
+	assert.Equal(t, int64(-1), job.CurrentBlock, "a job from block 0 must not count block 0 as processed")
+	assert.Equal(t, float64(0), job.Progress())
+
+	job, err = backfills.ClaimNextJob(ctx, chains, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, second, job.ID)
+
+	// Both jobs are running and recently updated
+	job, err = backfills.ClaimNextJob(ctx, chains, time.Hour)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+
+	// Other chains' workers do not see the jobs
+	job, err = backfills.ClaimNextJob(ctx, []int64{backfillTestChainID + 1}, 0)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+}
+
+func TestBackfillStorage_ReclaimsStaleJob(t *testing.T) {
+	requireIntegrationEnv(t)
+	db, backfills := setupBackfillStorage(t)
+	ctx := context.Background()
+	chains := []int64{backfillTestChainID}
+
+	jobID := queueBackfillJob(t, db, 100, 999)
+	job, err := backfills.ClaimNextJob(ctx, chains, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+
+	running, err := backfills.UpdateProgress(ctx, jobID, 299)
+	require.NoError(t, err)
+	require.True(t, running)
+
+	// A worker that reported progress within staleAfter keeps its job
+	job, err = backfills.ClaimNextJob(ctx, chains, time.Hour)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+
+	// Once the worker has been silent for longer, the job is taken over at its
+	// last recorded block
+	time.Sleep(10 * time.Millisecond)
+	job, err = backfills.ClaimNextJob(ctx, chains, 0)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, jobID, job.ID)
+	assert.Equal(t, int64(299), job.CurrentBlock)
+}
+
+func TestBackfillStorage_ResumesReleasedJob(t *testing.T) {
+	requireIntegrationEnv(t)
+	db, backfills := setupBackfillStorage(t)
+	ctx := context.Background()
+	chains := []int64{backfillTestChainID}
+
+	jobID := queueBackfillJob(t, db, 0, 999)
+	job, err := backfills.ClaimNextJob(ctx, chains, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+
+	// Block 0 is the first chunk's first block; after it the cursor moves on
+	running, err := backfills.UpdateProgress(ctx, jobID, 0)
+	require.NoError(t, err)
+	require.True(t, running)
+	running, err = backfills.UpdateProgress(ctx, jobID, 399)
+	require.NoError(t, err)
+	require.True(t, running)
+
+	// Shutdown hands the job back and the next worker continues from 400
+	require.NoError(t, backfills.ReleaseJob(ctx, jobID))
+	job, err = backfills.ClaimNextJob(ctx, chains, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, job)
+	assert.Equal(t, jobID, job.ID)
+	assert.Equal(t, int64(399), job.CurrentBlock)
+	assert.InDelta(t, 39.9, job.Progress(), 0.1)
+
+	require.NoError(t, backfills.CompleteJob(ctx, jobID))
+	job, err = backfills.GetJob(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BackfillStatusCompleted, job.Status)
+	assert.Equal(t, int64(999), job.CurrentBlock)
+
+	// A cancelled job stops its worker at the next progress report
+	cancelled := queueBackfillJob(t, db, 0, 99)
+	_, err = backfills.ClaimNextJob(ctx, chains, time.Hour)
+	require.NoError(t, err)
+	_, err = db.Exec("UPDATE backfill_jobs SET status = $1 WHERE id = $2", models.BackfillStatusCancelled, cancelled)
+	require.NoError(t, err)
+	running, err = backfills.UpdateProgress(ctx, cancelled, 49)
+	require.NoError(t, err)
+	assert.False(t, running)
+}
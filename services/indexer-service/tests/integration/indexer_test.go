@@ -315,7 +315,7 @@ func addContractToDatabase(t *testing.T, db *sqlx.DB, address common.Address, ab
 	query := `
 		INSERT INTO contracts (address, abi, name, start_block, current_block, confirm_blocks, confirmation_strategy, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
-		ON CONFLICT (address) DO UPDATE SET
+		ON CONFLICT (chain_id, address) DO UPDATE SET
 			abi = EXCLUDED.abi,
 			updated_at = NOW()
 	`
@@ -329,7 +329,7 @@ func insertMockEvents(t *testing.T, db *sqlx.DB, testData *TestData) error {
 	query := `
 		INSERT INTO events (contract_address, event_name, block_number, block_hash, transaction_hash, transaction_index, log_index, args, timestamp, created_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
-		ON CONFLICT (contract_address, block_number, transaction_hash, log_index) DO NOTHING
+		ON CONFLICT (chain_id, transaction_hash, log_index) DO NOTHING
 	`
 	
 	// Mock Transfer event
@@ -358,7 +358,7 @@ func insertBatchEvents(t *testing.T, db *sqlx.DB, testData *TestData, count int)
 	query := `
 		INSERT INTO events (contract_address, event_name, block_number, block_hash, transaction_hash, transaction_index, log_index, args, timestamp, created_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
-		ON CONFLICT (contract_address, block_number, transaction_hash, log_index) DO NOTHING
+		ON CONFLICT (chain_id, transaction_hash, log_index) DO NOTHING
 	`
 	
 	for i := 0; i < count; i++ {
@@ -0,0 +1,237 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/redis/go-redis/v9"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/reorg"
+	"github.com/smart-contract-event-indexer/indexer-service/internal/storage"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reorgTestChainID keeps the rows of these tests apart from anything else in
+// the database
+const reorgTestChainID = 990002
+
+const (
+	reorgContractAhead  = models.Address("0x00000000000000000000000000000000000a0001") // indexed to block 120
+	reorgContractBehind = models.Address("0x00000000000000000000000000000000000a0002") // indexed to block 90
+	reorgEmitter        = models.Address("0x00000000000000000000000000000000000a0003") // only seen by the subscription
+)
+
+// reorgFixture is a chain with two contracts and a topic subscription
+type reorgFixture struct {
+	db             *sqlx.DB
+	handler        *reorg.Handler
+	reorgs         *storage.ReorgStorage
+	subscriptionID int64
+}
+
+func cleanReorgChain(db *sqlx.DB) {
+	db.Exec("DELETE FROM events WHERE chain_id = $1", reorgTestChainID)
+	db.Exec("DELETE FROM reorg_log WHERE chain_id = $1", reorgTestChainID)
+	db.Exec("DELETE FROM indexer_state WHERE chain_id = $1", reorgTestChainID)
+	db.Exec("DELETE FROM topic_subscriptions WHERE chain_id = $1", reorgTestChainID)
+	db.Exec("DELETE FROM contracts WHERE chain_id = $1", reorgTestChainID)
+}
+
+// setupReorgFixture stores the contracts, the subscription, their cursors and
+// their events:
+//
+//	ahead:        cursor 120, events at 95, 100 and 110
+//	behind:       cursor 90, event at 85
+//	subscription: cursor 120, events of another emitter at 99 and 107
+func setupReorgFixture(t *testing.T) *reorgFixture {
+	t.Helper()
+	ctx := context.Background()
+	config := loadTestConfig()
+
+	db, err := sqlx.Connect("postgres", config.DatabaseURL)
+	require.NoError(t, err, "Failed to connect to database")
+	t.Cleanup(func() { db.Close() })
+
+	redisOptions, err := redis.ParseURL(config.RedisURL)
+	require.NoError(t, err)
+	redisClient := redis.NewClient(redisOptions)
+	t.Cleanup(func() { redisClient.Close() })
+
+	cleanReorgChain(db)
+	t.Cleanup(func() { cleanReorgChain(db) })
+
+	for address, cursor := range map[models.Address]int64{reorgContractAhead: 120, reorgContractBehind: 90} {
+		_, err := db.Exec(`
+			INSERT INTO contracts (chain_id, address, abi, name, start_block, current_block, confirm_blocks)
+			VALUES ($1, $2, $3, 'ReorgTest', 0, $4, 1)
+		`, reorgTestChainID, address, TestERC20ABI, cursor)
+		require.NoError(t, err)
+		_, err = db.Exec(`
+			INSERT INTO indexer_state (chain_id, contract_address, last_indexed_block, last_block_hash)
+			VALUES ($1, $2, $3, '0xabandoned')
+		`, reorgTestChainID, address, cursor)
+		require.NoError(t, err)
+	}
+
+	fixture := &reorgFixture{db: db}
+	err = db.QueryRow(`
+		INSERT INTO topic_subscriptions (chain_id, name, abi, event_name, event_signature)
+		VALUES ($1, 'reorg-test-transfers', $2, 'Transfer', 'Transfer(address,address,uint256)')
+		RETURNING id
+	`, reorgTestChainID, TestERC20ABI).Scan(&fixture.subscriptionID)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+		INSERT INTO indexer_state (chain_id, subscription_id, last_indexed_block, last_block_hash)
+		VALUES ($1, $2, 120, '0xabandoned')
+	`, reorgTestChainID, fixture.subscriptionID)
+	require.NoError(t, err)
+
+	logger := utils.NewLogger("integration-test", "info", "text")
+	contractStorage := storage.NewContractStorage(db, logger)
+	eventStorage := storage.NewEventStorage(db, logger)
+	stateStorage := storage.NewStateStorage(db, logger)
+	fixture.reorgs = storage.NewReorgStorage(db, logger)
+
+	var events []*models.Event
+	event := func(address models.Address, block int64, subscriptionID *int64) {
+		events = append(events, &models.Event{
+			ChainID:         reorgTestChainID,
+			ContractAddress: address,
+			EventName:       "Transfer",
+			EventSignature:  "Transfer(address,address,uint256)",
+			BlockNumber:     block,
+			BlockHash:       models.Hash(fmt.Sprintf("0x%064x", block)),
+			TransactionHash: models.Hash(fmt.Sprintf("0x%040x%024x", block, len(events))),
+			Args:            models.JSONB{"value": "1"},
+			Timestamp:       time.Now().UTC(),
+			Finality:        models.FinalityPending,
+			SubscriptionID:  subscriptionID,
+		})
+	}
+	event(reorgContractAhead, 95, nil)
+	event(reorgContractAhead, 100, nil)
+	event(reorgContractAhead, 110, nil)
+	event(reorgContractBehind, 85, nil)
+	event(reorgEmitter, 99, &fixture.subscriptionID)
+	event(reorgEmitter, 107, &fixture.subscriptionID)
+	inserted, err := eventStorage.InsertEvents(ctx, events)
+	require.NoError(t, err)
+	require.Len(t, inserted, len(events))
+
+	// Rollbacks only use the detector to drop cached hashes
+	detector := reorg.NewDetector(reorgTestChainID, nil, redisClient, logger)
+	fixture.handler = reorg.NewHandler(db, contractStorage, eventStorage, stateStorage, fixture.reorgs, detector, logger)
+	return fixture
+}
+
+// eventBlocks returns the blocks of an address's stored events in order
+func (f *reorgFixture) eventBlocks(t *testing.T, address models.Address) []int64 {
+	t.Helper()
+	blocks := []int64{}
+	err := f.db.Select(&blocks, `
+		SELECT block_number FROM events
+		WHERE chain_id = $1 AND contract_address = $2
+		ORDER BY block_number
+	`, reorgTestChainID, address)
+	require.NoError(t, err)
+	return blocks
+}
+
+func TestReorgHandler_HandleReorgForAllContracts(t *testing.T) {
+	requireIntegrationEnv(t)
+
+	tests := []struct {
+		name       string
+		forkPoint  int64
+		detectedAt int64
+
+		wantAheadCursor        int64
+		wantAheadEvents        []int64
+		wantSubscriptionCursor int64
+		wantEmitterEvents      []int64
+		wantContractsAffected  int
+		wantEventsRemoved      int64
+	}{
+		{
+			name:                   "shallow fork",
+			forkPoint:              105,
+			detectedAt:             106,
+			wantAheadCursor:        104,
+			wantAheadEvents:        []int64{95, 100},
+			wantSubscriptionCursor: 104,
+			wantEmitterEvents:      []int64{99},
+			wantContractsAffected:  1,
+			wantEventsRemoved:      2,
+		},
+		{
+			name:                   "fork below every cursor but one",
+			forkPoint:              99,
+			detectedAt:             121,
+			wantAheadCursor:        98,
+			wantAheadEvents:        []int64{95},
+			wantSubscriptionCursor: 98,
+			wantEmitterEvents:      []int64{},
+			wantContractsAffected:  1,
+			wantEventsRemoved:      4,
+		},
+		{
+			name:                   "fork above every cursor",
+			forkPoint:              125,
+			detectedAt:             126,
+			wantAheadCursor:        120,
+			wantAheadEvents:        []int64{95, 100, 110},
+			wantSubscriptionCursor: 120,
+			wantEmitterEvents:      []int64{99, 107},
+			wantContractsAffected:  0,
+			wantEventsRemoved:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fixture := setupReorgFixture(t)
+
+			err := fixture.handler.HandleReorgForAllContracts(ctx, reorgTestChainID, tt.forkPoint, tt.detectedAt)
+			require.NoError(t, err)
+
+			// Contracts past the fork point are rewound with their events and state
+			var aheadCursor, behindCursor int64
+			require.NoError(t, fixture.db.Get(&aheadCursor, "SELECT current_block FROM contracts WHERE chain_id = $1 AND address = $2", reorgTestChainID, reorgContractAhead))
+			require.NoError(t, fixture.db.Get(&behindCursor, "SELECT current_block FROM contracts WHERE chain_id = $1 AND address = $2", reorgTestChainID, reorgContractBehind))
+			assert.Equal(t, tt.wantAheadCursor, aheadCursor)
+			assert.Equal(t, int64(90), behindCursor, "a contract below the fork point is left alone")
+			assert.Equal(t, tt.wantAheadEvents, fixture.eventBlocks(t, reorgContractAhead))
+			assert.Equal(t, []int64{85}, fixture.eventBlocks(t, reorgContractBehind))
+
+			var state struct {
+				LastIndexedBlock int64  `db:"last_indexed_block"`
+				Status           string `db:"status"`
+			}
+			require.NoError(t, fixture.db.Get(&state, "SELECT last_indexed_block, status FROM indexer_state WHERE chain_id = $1 AND contract_address = $2", reorgTestChainID, reorgContractAhead))
+			assert.Equal(t, tt.wantAheadCursor, state.LastIndexedBlock)
+			assert.Equal(t, "active", state.Status, "rolled back contracts are marked recovered")
+
+			// Subscriptions move back with their events
+			var subscriptionCursor int64
+			require.NoError(t, fixture.db.Get(&subscriptionCursor, "SELECT last_indexed_block FROM indexer_state WHERE subscription_id = $1", fixture.subscriptionID))
+			assert.Equal(t, tt.wantSubscriptionCursor, subscriptionCursor)
+			assert.Equal(t, tt.wantEmitterEvents, fixture.eventBlocks(t, reorgEmitter))
+
+			// The reorg is recorded for audit
+			records, err := fixture.reorgs.GetRecentReorgs(ctx, reorgTestChainID, 1)
+			require.NoError(t, err)
+			require.Len(t, records, 1)
+			assert.Equal(t, tt.forkPoint, records[0].ForkPoint)
+			assert.Equal(t, tt.detectedAt, records[0].DetectedAtBlock)
+			assert.Equal(t, tt.wantContractsAffected, records[0].ContractsAffected)
+			assert.Equal(t, tt.wantEventsRemoved, records[0].EventsRemoved)
+		})
+	}
+}
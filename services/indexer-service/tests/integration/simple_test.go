@@ -137,7 +137,7 @@ func TestIndexer_DataOperations(t *testing.T) {
 		query := `
 			INSERT INTO contracts (address, abi, name, start_block, current_block, confirm_blocks, created_at, updated_at)
 			VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
-			ON CONFLICT (address) DO UPDATE SET
+			ON CONFLICT (chain_id, address) DO UPDATE SET
 				abi = EXCLUDED.abi,
 				updated_at = NOW()
 		`
@@ -201,7 +201,7 @@ func TestIndexer_DataOperations(t *testing.T) {
 		query := `
 			INSERT INTO indexer_state (contract_address, last_indexed_block, updated_at)
 			VALUES ($1, $2, NOW())
-			ON CONFLICT (contract_address) DO UPDATE SET
+			ON CONFLICT (chain_id, contract_address) DO UPDATE SET
 				last_indexed_block = EXCLUDED.last_indexed_block,
 				updated_at = NOW()
 		`
@@ -0,0 +1,110 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/smart-contract-event-indexer/indexer-service/internal/storage"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subscriptionTestChainID keeps the rows of these tests apart from anything
+// else in the database
+const subscriptionTestChainID = 990003
+
+const subscriptionTestContract = models.Address("0x00000000000000000000000000000000000b0001")
+
+func cleanSubscriptionChain(db *sqlx.DB) {
+	db.Exec("DELETE FROM events WHERE chain_id = $1", subscriptionTestChainID)
+	db.Exec("DELETE FROM topic_subscriptions WHERE chain_id = $1", subscriptionTestChainID)
+}
+
+// overlappingTransfer is one log of the monitored contract, as the contract
+// or the subscription decodes it
+func overlappingTransfer(subscriptionID *int64) *models.Event {
+	return &models.Event{
+		ChainID:         subscriptionTestChainID,
+		ContractAddress: subscriptionTestContract,
+		EventName:       "Transfer",
+		EventSignature:  "Transfer(address,address,uint256)",
+		BlockNumber:     200,
+		BlockHash:       "0x00000000000000000000000000000000000000000000000000000000000000c8",
+		TransactionHash: "0x00000000000000000000000000000000000000000000000000000000000b0001",
+		LogIndex:        4,
+		Args:            models.JSONB{"value": "1"},
+		Timestamp:       time.Now().UTC(),
+		Finality:        models.FinalityPending,
+		SubscriptionID:  subscriptionID,
+	}
+}
+
+func TestEventStorage_SubscriptionOverlapsContract(t *testing.T) {
+	requireIntegrationEnv(t)
+
+	tests := []struct {
+		name              string
+		subscriptionFirst bool
+	}{
+		{name: "contract stores the event first"},
+		{name: "subscription stores the event first", subscriptionFirst: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			config := loadTestConfig()
+
+			db, err := sqlx.Connect("postgres", config.DatabaseURL)
+			require.NoError(t, err, "Failed to connect to database")
+			t.Cleanup(func() { db.Close() })
+
+			cleanSubscriptionChain(db)
+			t.Cleanup(func() { cleanSubscriptionChain(db) })
+
+			var subscriptionID int64
+			err = db.QueryRow(`
+				INSERT INTO topic_subscriptions (chain_id, name, abi, event_name, event_signature)
+				VALUES ($1, 'overlap-test-transfers', $2, 'Transfer', 'Transfer(address,address,uint256)')
+				RETURNING id
+			`, subscriptionTestChainID, TestERC20ABI).Scan(&subscriptionID)
+			require.NoError(t, err)
+
+			eventStorage := storage.NewEventStorage(db, utils.NewLogger("integration-test", "info", "text"))
+
+			batches := [][]*models.Event{{overlappingTransfer(nil)}, {overlappingTransfer(&subscriptionID)}}
+			if tt.subscriptionFirst {
+				batches[0], batches[1] = batches[1], batches[0]
+			}
+			first, err := eventStorage.InsertEvents(ctx, batches[0])
+			require.NoError(t, err)
+			require.Len(t, first, 1)
+			second, err := eventStorage.InsertEvents(ctx, batches[1])
+			require.NoError(t, err)
+			assert.Empty(t, second, "the log is stored once")
+
+			// The subscription is linked to the single stored event
+			var linked []int64
+			require.NoError(t, db.Select(&linked, "SELECT event_id FROM event_subscriptions WHERE subscription_id = $1", subscriptionID))
+			assert.Equal(t, []int64{first[0].ID}, linked)
+
+			// and promotes and rolls it back like its own
+			promoted, err := eventStorage.ConfirmSubscriptionEvents(ctx, subscriptionID, 200)
+			require.NoError(t, err)
+			require.Len(t, promoted, 1)
+			assert.Equal(t, first[0].ID, promoted[0].ID)
+			assert.Equal(t, models.FinalityConfirmed, promoted[0].Finality)
+			require.NotNil(t, promoted[0].SubscriptionID)
+			assert.Equal(t, subscriptionID, *promoted[0].SubscriptionID)
+
+			removed, err := eventStorage.DeleteSubscriptionEventsByBlock(ctx, db, subscriptionTestChainID, 200)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), removed)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/smart-contract-event-indexer/indexer-service/internal/storage"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookTestChainID keeps the rows of these tests apart from anything else in
+// the database
+const webhookTestChainID = 990004
+
+const webhookTestContract = models.Address("0x00000000000000000000000000000000000c0001")
+
+func cleanWebhookChain(db *sqlx.DB) {
+	db.Exec("DELETE FROM events WHERE chain_id = $1", webhookTestChainID)
+	db.Exec("DELETE FROM webhooks WHERE chain_id = $1", webhookTestChainID)
+}
+
+// setupWebhookTest returns a database with a webhook on the test chain and
+// the webhook's ID
+func setupWebhookTest(t *testing.T) (*sqlx.DB, string) {
+	t.Helper()
+	config := loadTestConfig()
+
+	db, err := sqlx.Connect("postgres", config.DatabaseURL)
+	require.NoError(t, err, "Failed to connect to database")
+	t.Cleanup(func() { db.Close() })
+
+	cleanWebhookChain(db)
+	t.Cleanup(func() { cleanWebhookChain(db) })
+
+	var webhookID string
+	err = db.QueryRow(`
+		INSERT INTO webhooks (chain_id, url, secret)
+		VALUES ($1, 'http://localhost:9/hook', 'secret')
+		RETURNING id
+	`, webhookTestChainID).Scan(&webhookID)
+	require.NoError(t, err)
+	return db, webhookID
+}
+
+func webhookTestEvent(block int64) *models.Event {
+	return &models.Event{
+		ChainID:         webhookTestChainID,
+		ContractAddress: webhookTestContract,
+		EventName:       "Transfer",
+		EventSignature:  "Transfer(address,address,uint256)",
+		BlockNumber:     block,
+		BlockHash:       models.Hash(fmt.Sprintf("0x%064x", block)),
+		TransactionHash: models.Hash(fmt.Sprintf("0x%064x", block+0xc0000)),
+		Args:            models.JSONB{"value": "1"},
+		Timestamp:       time.Now().UTC(),
+		Finality:        models.FinalityPending,
+	}
+}
+
+// matchAll returns a matcher queueing every event for the webhook
+func matchAll(webhookID string) storage.DeliveryMatcher {
+	return func(ctx context.Context, events []*models.Event) ([]*models.WebhookDelivery, error) {
+		deliveries := make([]*models.WebhookDelivery, 0, len(events))
+		for _, event := range events {
+			deliveries = append(deliveries, &models.WebhookDelivery{
+				WebhookID: webhookID,
+				EventID:   event.ID,
+				Payload:   models.JSONB{"id": event.ID},
+			})
+		}
+		return deliveries, nil
+	}
+}
+
+// deliveryStatuses returns the status of the webhook's delivery of each
+// stored event by block
+func deliveryStatuses(t *testing.T, db *sqlx.DB, webhookID string) map[int64]string {
+	t.Helper()
+	var rows []struct {
+		BlockNumber int64  `db:"block_number"`
+		Status      string `db:"status"`
+	}
+	err := db.Select(&rows, `
+		SELECT COALESCE(e.block_number, -1) AS block_number, d.status
+		FROM webhook_deliveries d
+		LEFT JOIN events e ON e.id = d.event_id
+		WHERE d.webhook_id = $1
+	`, webhookID)
+	require.NoError(t, err)
+	statuses := make(map[int64]string)
+	for _, row := range rows {
+		statuses[row.BlockNumber] = row.Status
+	}
+	return statuses
+}
+
+func TestEventStorage_InsertEventsWithDeliveries(t *testing.T) {
+	requireIntegrationEnv(t)
+	ctx := context.Background()
+	db, webhookID := setupWebhookTest(t)
+	eventStorage := storage.NewEventStorage(db, utils.NewLogger("integration-test", "info", "text"))
+
+	// Deliveries are queued with the events
+	inserted, err := eventStorage.InsertEventsWithDeliveries(ctx, []*models.Event{webhookTestEvent(100)}, matchAll(webhookID))
+	require.NoError(t, err)
+	require.Len(t, inserted, 1)
+	assert.Equal(t, map[int64]string{100: models.WebhookDeliveryPending}, deliveryStatuses(t, db, webhookID))
+
+	// and a failed match stores neither
+	failing := func(ctx context.Context, events []*models.Event) ([]*models.WebhookDelivery, error) {
+		return nil, errors.New("webhooks unavailable")
+	}
+	_, err = eventStorage.InsertEventsWithDeliveries(ctx, []*models.Event{webhookTestEvent(101)}, failing)
+	require.Error(t, err)
+
+	var stored int
+	require.NoError(t, db.Get(&stored, "SELECT COUNT(*) FROM events WHERE chain_id = $1 AND block_number = 101", webhookTestChainID))
+	assert.Zero(t, stored, "the event is rolled back with its deliveries")
+
+	// Retrying the batch queues the deliveries it missed
+	_, err = eventStorage.InsertEventsWithDeliveries(ctx, []*models.Event{webhookTestEvent(101)}, matchAll(webhookID))
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]string{100: models.WebhookDeliveryPending, 101: models.WebhookDeliveryPending}, deliveryStatuses(t, db, webhookID))
+}
+
+func TestEventStorage_DeleteEventsByBlockCancelsDeliveries(t *testing.T) {
+	requireIntegrationEnv(t)
+	ctx := context.Background()
+	db, webhookID := setupWebhookTest(t)
+	eventStorage := storage.NewEventStorage(db, utils.NewLogger("integration-test", "info", "text"))
+
+	events := []*models.Event{webhookTestEvent(100), webhookTestEvent(110), webhookTestEvent(120)}
+	_, err := eventStorage.InsertEventsWithDeliveries(ctx, events, matchAll(webhookID))
+	require.NoError(t, err)
+
+	// The delivery at 120 was already sent
+	_, err = db.Exec("UPDATE webhook_deliveries SET status = 'delivered' WHERE event_id = $1", events[2].ID)
+	require.NoError(t, err)
+
+	removed, err := eventStorage.DeleteEventsByBlock(ctx, db, webhookTestChainID, webhookTestContract, 105)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), removed)
+
+	var statuses []string
+	require.NoError(t, db.Select(&statuses, `
+		SELECT status FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY event_id
+	`, webhookID))
+	assert.Equal(t, []string{
+		models.WebhookDeliveryPending,
+		models.WebhookDeliveryCancelled,
+		models.WebhookDeliveryDelivered,
+	}, statuses, "only undelivered deliveries of removed events are cancelled")
+}
@@ -39,6 +39,9 @@ type Config struct {
 	BloomFilterSize     int           `json:"bloom_filter_size"`
 	BloomFilterHashes   int           `json:"bloom_filter_hashes"`
 	AggregationCacheTTL time.Duration `json:"aggregation_cache_ttl"`
+
+	// Streaming configuration
+	StreamPollInterval time.Duration `json:"stream_poll_interval"`
 }
 
 // Load loads configuration from environment variables
@@ -60,6 +63,7 @@ func Load(configPath string) (*Config, error) {
 		BloomFilterSize:      getEnvInt("BLOOM_FILTER_SIZE", 1<<20),
 		BloomFilterHashes:    getEnvInt("BLOOM_FILTER_HASHES", 3),
 		AggregationCacheTTL:  getEnvDuration("AGGREGATION_CACHE_TTL", 5*time.Minute),
+		StreamPollInterval:   getEnvDuration("STREAM_POLL_INTERVAL", 2*time.Second),
 	}
 
 	return cfg, nil
@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/smart-contract-event-indexer/query-service/internal/config"
 	"github.com/smart-contract-event-indexer/query-service/internal/types"
+	sharedconfig "github.com/smart-contract-event-indexer/shared/config"
 	"github.com/smart-contract-event-indexer/shared/models"
 	"github.com/smart-contract-event-indexer/shared/utils"
 )
@@ -36,88 +38,112 @@ func NewQueryBuilder(db *sql.DB, logger utils.Logger, cfg *config.Config) *Query
 }
 
 // BuildSimpleEventQuery uses a streamlined SQL path for common filters.
-func (qb *QueryBuilder) BuildSimpleEventQuery(ctx context.Context, query *types.EventQuery) ([]*models.Event, int32, error) {
+// Like the other event listings it returns one page of events, the total
+// number of matches and whether more events lie beyond the page in the
+// direction it was read.
+func (qb *QueryBuilder) BuildSimpleEventQuery(ctx context.Context, query *types.EventQuery) ([]*models.Event, int32, bool, error) {
 	if query.ContractAddress == nil {
-		return nil, 0, fmt.Errorf("simple event query requires contract address")
+		return nil, 0, false, fmt.Errorf("simple event query requires contract address")
 	}
 
 	ctx, cancel := qb.withTimeout(ctx)
 	defer cancel()
 
+	// The page and the total count share the same conditions
+	where, args := buildSimpleEventFilter(query)
+	argIndex := len(args) + 1
+
+	countQuery := "SELECT COUNT(*) FROM events e" + where
+	countArgs := append([]interface{}{}, args...)
+
 	baseQuery := `
 		SELECT 
-			e.id, e.contract_address, e.event_name,
+			e.id, e.chain_id, e.contract_address, e.event_name,
 			e.block_number, e.block_hash, e.transaction_hash,
-			e.transaction_index, e.log_index, e.args, e.timestamp, e.created_at
+			e.transaction_index, e.log_index, e.args, e.timestamp, e.created_at, e.finality,
+			e.arg_types, e.event_signature
 		FROM events e
-		WHERE e.contract_address = $1
-	`
-
-	args := []interface{}{*query.ContractAddress}
-	argIndex := 2
-
-	if query.EventName != nil {
-		baseQuery += fmt.Sprintf(" AND e.event_name = $%d", argIndex)
-		args = append(args, *query.EventName)
-		argIndex++
-	}
+	` + where
 
-	if query.FromBlock != nil {
-		baseQuery += fmt.Sprintf(" AND e.block_number >= $%d", argIndex)
-		args = append(args, *query.FromBlock)
-		argIndex++
-	}
-
-	if query.ToBlock != nil {
-		baseQuery += fmt.Sprintf(" AND e.block_number <= $%d", argIndex)
-		args = append(args, *query.ToBlock)
-		argIndex++
+	page, err := qb.buildPageClause(query.First, query.Last, query.After, query.Before, query.Limit, query.Offset, argIndex)
+	if err != nil {
+		return nil, 0, false, err
 	}
 
-	order := " ORDER BY e.block_number DESC, e.log_index ASC"
-	limitClause, limitArgs := qb.buildLimitClause(query.First, query.Last, query.Limit, query.Offset, argIndex)
-	argIndex += len(limitArgs)
-
-	queryStr := baseQuery + order + limitClause
-	args = append(args, limitArgs...)
+	queryStr := baseQuery + page.where + page.tail
+	args = append(args, page.args...)
 
 	rows, err := qb.executeRows(ctx, "events.simple", queryStr, args)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, false, err
 	}
 	defer rows.Close()
 
 	events, err := qb.parseEvents(rows)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, false, err
+	}
+	events, more := page.trimPage(events)
+
+	var totalCount int32
+	if err := qb.queryRow(ctx, "events.simple.count", countQuery, countArgs, &totalCount); err != nil {
+		return events, int32(len(events)), more, nil
+	}
+
+	return events, totalCount, more, nil
+}
+
+// buildSimpleEventFilter returns the WHERE clause of the simple event path,
+// used by both its page and its count, and the clause's arguments
+func buildSimpleEventFilter(query *types.EventQuery) (string, []interface{}) {
+	where := " WHERE e.contract_address = $1"
+	args := []interface{}{*query.ContractAddress}
+
+	if query.ChainID != nil {
+		where += fmt.Sprintf(" AND e.chain_id = $%d", len(args)+1)
+		args = append(args, *query.ChainID)
 	}
 
-	countQuery := "SELECT COUNT(*) FROM events e WHERE e.contract_address = $1"
-	countArgs := []interface{}{*query.ContractAddress}
 	if query.EventName != nil {
-		countQuery += " AND e.event_name = $2"
-		countArgs = append(countArgs, *query.EventName)
+		where += fmt.Sprintf(" AND e.event_name = $%d", len(args)+1)
+		args = append(args, *query.EventName)
 	}
 
-	var totalCount int32
-	if err := qb.queryRow(ctx, "events.simple.count", countQuery, countArgs, &totalCount); err != nil {
-		return events, int32(len(events)), nil
+	if query.EventSignature != nil {
+		where += fmt.Sprintf(" AND e.event_signature = $%d", len(args)+1)
+		args = append(args, *query.EventSignature)
+	}
+
+	if query.Finality != nil {
+		where += fmt.Sprintf(" AND e.finality = $%d", len(args)+1)
+		args = append(args, *query.Finality)
+	}
+
+	if query.FromBlock != nil {
+		where += fmt.Sprintf(" AND e.block_number >= $%d", len(args)+1)
+		args = append(args, *query.FromBlock)
+	}
+
+	if query.ToBlock != nil {
+		where += fmt.Sprintf(" AND e.block_number <= $%d", len(args)+1)
+		args = append(args, *query.ToBlock)
 	}
 
-	return events, totalCount, nil
+	return where, args
 }
 
 // BuildEventQuery builds and executes a query for events
-func (qb *QueryBuilder) BuildEventQuery(ctx context.Context, query *types.EventQuery) ([]*models.Event, int32, error) {
+func (qb *QueryBuilder) BuildEventQuery(ctx context.Context, query *types.EventQuery) ([]*models.Event, int32, bool, error) {
 	ctx, cancel := qb.withTimeout(ctx)
 	defer cancel()
 
 	// Build the base query
 	baseQuery := `
 		SELECT 
-			e.id, 	e.contract_address, e.event_name,
+			e.id, e.chain_id, e.contract_address, e.event_name,
 			e.block_number, e.block_hash, e.transaction_hash,
-			e.transaction_index, e.log_index, e.args, e.timestamp, e.created_at
+			e.transaction_index, e.log_index, e.args, e.timestamp, e.created_at, e.finality,
+			e.arg_types, e.event_signature
 		FROM events e
 		WHERE 1=1
 	`
@@ -125,22 +151,25 @@ func (qb *QueryBuilder) BuildEventQuery(ctx context.Context, query *types.EventQ
 	whereClause, args := qb.buildEventWhereClause(query)
 	countArgs := append([]interface{}{}, args...)
 
-	queryStr := baseQuery + whereClause + " ORDER BY e.block_number DESC, e.log_index ASC"
-	limitClause, limitArgs := qb.buildLimitClause(query.First, query.Last, query.Limit, query.Offset, len(args)+1)
-	queryStr += limitClause
-	args = append(args, limitArgs...)
+	page, err := qb.buildPageClause(query.First, query.Last, query.After, query.Before, query.Limit, query.Offset, len(args)+1)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	queryStr := baseQuery + whereClause + page.where + page.tail
+	args = append(args, page.args...)
 
 	rows, err := qb.executeRows(ctx, "events.complex", queryStr, args)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to execute event query: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to execute event query: %w", err)
 	}
 	defer rows.Close()
 
 	// Parse results
 	events, err := qb.parseEvents(rows)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to parse events: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to parse events: %w", err)
 	}
+	events, more := page.trimPage(events)
 
 	// Get total count (without LIMIT)
 	countQuery := `
@@ -151,30 +180,138 @@ func (qb *QueryBuilder) BuildEventQuery(ctx context.Context, query *types.EventQ
 
 	var totalCount int32
 	if err := qb.queryRow(ctx, "events.complex.count", countQuery, countArgs, &totalCount); err != nil {
-		return events, int32(len(events)), nil
+		return events, int32(len(events)), more, nil
+	}
+
+	return events, totalCount, more, nil
+}
+
+// BuildEventStreamQuery returns up to limit events matching the query that
+// come after pos, each with the position right after it. While replaying it
+// reads the events made visible by transactions older than pos.ReplayBelow in
+// block order. After that it reads in commit order: by the transaction that
+// inserted or promoted each event, and only below the oldest transaction
+// still running, so an event that took a lower ID but committed later is not
+// passed over. Promoted events come again, confirmed, in commit order.
+func (qb *QueryBuilder) BuildEventStreamQuery(ctx context.Context, query *types.EventQuery, pos types.StreamPosition, limit int32) ([]*types.StreamEvent, error) {
+	ctx, cancel := qb.withTimeout(ctx)
+	defer cancel()
+
+	baseQuery := `
+		SELECT 
+			e.id, e.chain_id, e.contract_address, e.event_name,
+			e.block_number, e.block_hash, e.transaction_hash,
+			e.transaction_index, e.log_index, e.args, e.timestamp, e.created_at, e.finality,
+			e.arg_types, e.event_signature, e.stream_xid::text
+		FROM events e
+		WHERE 1=1
+	`
+
+	whereClause, args := qb.buildEventWhereClause(query)
+
+	var order string
+	if pos.ReplayBelow > 0 {
+		args = append(args, strconv.FormatUint(pos.ReplayBelow, 10), pos.BlockNumber, pos.LogIndex, pos.ID)
+		n := len(args)
+		whereClause += fmt.Sprintf(" AND e.stream_xid < $%d::xid8 AND (e.block_number, e.log_index, e.id) > ($%d, $%d, $%d)", n-3, n-2, n-1, n)
+		order = " ORDER BY e.block_number ASC, e.log_index ASC, e.id ASC"
+	} else {
+		args = append(args, strconv.FormatUint(pos.XID, 10), pos.ID)
+		n := len(args)
+		whereClause += fmt.Sprintf(" AND (e.stream_xid, e.id) > ($%d::xid8, $%d) AND e.stream_xid < pg_snapshot_xmin(pg_current_snapshot())", n-1, n)
+		order = " ORDER BY e.stream_xid ASC, e.id ASC"
+	}
+
+	args = append(args, limit)
+	queryStr := baseQuery + whereClause + order + fmt.Sprintf(" LIMIT $%d", len(args))
+
+	rows, err := qb.executeRows(ctx, "events.stream", queryStr, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute event stream query: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*types.StreamEvent
+	for rows.Next() {
+		var xid string
+		event, err := qb.scanEvent(rows, &xid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse events: %w", err)
+		}
+		next := types.StreamPosition{ID: event.ID}
+		if pos.ReplayBelow > 0 {
+			next.ReplayBelow = pos.ReplayBelow
+			next.BlockNumber = event.BlockNumber
+			next.LogIndex = event.LogIndex
+		} else if next.XID, err = strconv.ParseUint(xid, 10, 64); err != nil {
+			return nil, fmt.Errorf("failed to parse stream position %q: %w", xid, err)
+		}
+		events = append(events, &types.StreamEvent{Event: event, Position: next})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// BuildStreamReplayBoundQuery returns the ID of the oldest transaction still
+// running. Every transaction below it has finished, so a replay bounded by it
+// sees all the events it will ever see.
+func (qb *QueryBuilder) BuildStreamReplayBoundQuery(ctx context.Context) (uint64, error) {
+	ctx, cancel := qb.withTimeout(ctx)
+	defer cancel()
+
+	var xmin string
+	if err := qb.queryRow(ctx, "events.stream.bound", "SELECT pg_snapshot_xmin(pg_current_snapshot())::text", nil, &xmin); err != nil {
+		return 0, fmt.Errorf("failed to read stream replay bound: %w", err)
+	}
+	bound, err := strconv.ParseUint(xmin, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse stream replay bound %q: %w", xmin, err)
 	}
+	return bound, nil
+}
 
-	return events, totalCount, nil
+// BuildEventPositionQuery returns the block position of an event, for streams
+// resumed from an event ID. It returns sql.ErrNoRows if the event is gone.
+func (qb *QueryBuilder) BuildEventPositionQuery(ctx context.Context, eventID int64) (types.StreamPosition, error) {
+	ctx, cancel := qb.withTimeout(ctx)
+	defer cancel()
+
+	pos := types.StreamPosition{ID: eventID}
+	start := time.Now()
+	queryStr := "SELECT block_number, log_index FROM events WHERE id = $1"
+	err := qb.db.QueryRowContext(ctx, queryStr, eventID).Scan(&pos.BlockNumber, &pos.LogIndex)
+	qb.observeQuery("events.stream.position", queryStr, []interface{}{eventID}, start, err)
+	return pos, err
 }
 
 // BuildAddressQuery builds and executes a query for events by address
-func (qb *QueryBuilder) BuildAddressQuery(ctx context.Context, query *types.AddressQuery) ([]*models.Event, int32, error) {
+func (qb *QueryBuilder) BuildAddressQuery(ctx context.Context, query *types.AddressQuery) ([]*models.Event, int32, bool, error) {
 	ctx, cancel := qb.withTimeout(ctx)
 	defer cancel()
 
 	baseQuery := `
 		SELECT 
-			e.id, 	e.contract_address, e.event_name,
+			e.id, e.chain_id, e.contract_address, e.event_name,
 			e.block_number, e.block_hash, e.transaction_hash,
-			e.transaction_index, e.log_index, e.args, e.timestamp, e.created_at
+			e.transaction_index, e.log_index, e.args, e.timestamp, e.created_at, e.finality,
+			e.arg_types, e.event_signature
 		FROM events e
 		WHERE %s
 	`
 
-	filter, filterArgs := qb.buildAddressClause(query.Address, 1)
+	filter, filterArgs := qb.buildAddressClause([]string{query.Address}, query.Role, 1)
 	args := filterArgs
 	argIndex := len(args) + 1
 
+	if query.ChainID != nil {
+		filter += fmt.Sprintf(" AND e.chain_id = $%d", argIndex)
+		args = append(args, *query.ChainID)
+		argIndex++
+	}
+
 	if query.ContractAddress != nil {
 		filter += fmt.Sprintf(" AND e.contract_address = $%d", argIndex)
 		args = append(args, *query.ContractAddress)
@@ -200,29 +337,32 @@ func (qb *QueryBuilder) BuildAddressQuery(ctx context.Context, query *types.Addr
 	}
 
 	countArgs := append([]interface{}{}, args...)
-	queryStr := fmt.Sprintf(baseQuery, filter) + " ORDER BY e.block_number DESC, e.log_index ASC"
-	limitClause, limitArgs := qb.buildLimitClause(query.First, query.Last, query.Limit, query.Offset, argIndex)
-	queryStr += limitClause
-	args = append(args, limitArgs...)
+	page, err := qb.buildPageClause(query.First, query.Last, query.After, query.Before, query.Limit, query.Offset, argIndex)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	queryStr := fmt.Sprintf(baseQuery, filter) + page.where + page.tail
+	args = append(args, page.args...)
 
 	rows, err := qb.executeRows(ctx, "events.address", queryStr, args)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to execute address query: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to execute address query: %w", err)
 	}
 	defer rows.Close()
 
 	events, err := qb.parseEvents(rows)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to parse events: %w", err)
+		return nil, 0, false, fmt.Errorf("failed to parse events: %w", err)
 	}
+	events, more := page.trimPage(events)
 
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM events e WHERE %s", filter)
 	var totalCount int32
 	if err := qb.queryRow(ctx, "events.address.count", countQuery, countArgs, &totalCount); err != nil {
-		return events, int32(len(events)), nil
+		return events, int32(len(events)), more, nil
 	}
 
-	return events, totalCount, nil
+	return events, totalCount, more, nil
 }
 
 // BuildTransactionQuery builds and executes a query for events by transaction
@@ -232,15 +372,22 @@ func (qb *QueryBuilder) BuildTransactionQuery(ctx context.Context, query *types.
 
 	queryStr := `
 		SELECT 
-			e.id, 	e.contract_address, e.event_name,
+			e.id, e.chain_id, e.contract_address, e.event_name,
 			e.block_number, e.block_hash, e.transaction_hash,
-			e.transaction_index, e.log_index, e.args, e.timestamp, e.created_at
+			e.transaction_index, e.log_index, e.args, e.timestamp, e.created_at, e.finality,
+			e.arg_types, e.event_signature
 		FROM events e
 		WHERE e.transaction_hash = $1
-		ORDER BY e.log_index ASC
 	`
+	args := []interface{}{query.TransactionHash}
 
-	rows, err := qb.executeRows(ctx, "events.tx", queryStr, []interface{}{query.TransactionHash})
+	if query.ChainID != nil {
+		queryStr += " AND e.chain_id = $2"
+		args = append(args, *query.ChainID)
+	}
+	queryStr += " ORDER BY e.chain_id ASC, e.log_index ASC"
+
+	rows, err := qb.executeRows(ctx, "events.tx", queryStr, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute transaction query: %w", err)
 	}
@@ -259,24 +406,31 @@ func (qb *QueryBuilder) BuildStatsQuery(ctx context.Context, query *types.StatsQ
 	ctx, cancel := qb.withTimeout(ctx)
 	defer cancel()
 
+	chainID := query.ChainID
+	if chainID == 0 {
+		chainID = sharedconfig.DefaultChainID
+	}
+
 	stats := &types.StatsResponse{
+		ChainID:         chainID,
 		ContractAddress: query.ContractAddress,
 	}
+	statsArgs := []interface{}{query.ContractAddress, chainID}
 
-	countQuery := `SELECT COUNT(*) FROM events WHERE contract_address = $1`
-	if err := qb.queryRow(ctx, "stats.count", countQuery, []interface{}{query.ContractAddress}, &stats.TotalEvents); err != nil {
+	countQuery := `SELECT COUNT(*) FROM events WHERE contract_address = $1 AND chain_id = $2`
+	if err := qb.queryRow(ctx, "stats.count", countQuery, statsArgs, &stats.TotalEvents); err != nil {
 		return nil, fmt.Errorf("failed to get total events: %w", err)
 	}
 
-	latestQuery := `SELECT COALESCE(MAX(block_number), 0) FROM events WHERE contract_address = $1`
-	if err := qb.queryRow(ctx, "stats.latest", latestQuery, []interface{}{query.ContractAddress}, &stats.LatestBlock); err != nil {
+	latestQuery := `SELECT COALESCE(MAX(block_number), 0) FROM events WHERE contract_address = $1 AND chain_id = $2`
+	if err := qb.queryRow(ctx, "stats.latest", latestQuery, statsArgs, &stats.LatestBlock); err != nil {
 		return nil, fmt.Errorf("failed to get latest block: %w", err)
 	}
 
 	var currentBlock sql.NullInt64
 	var lastUpdated sql.NullTime
-	stateQuery := `SELECT last_indexed_block, updated_at FROM indexer_state WHERE contract_address = $1`
-	switch err := qb.db.QueryRowContext(ctx, stateQuery, query.ContractAddress).Scan(&currentBlock, &lastUpdated); err {
+	stateQuery := `SELECT last_indexed_block, updated_at FROM indexer_state WHERE contract_address = $1 AND chain_id = $2`
+	switch err := qb.db.QueryRowContext(ctx, stateQuery, statsArgs...).Scan(&currentBlock, &lastUpdated); err {
 	case nil:
 		stats.CurrentBlock = currentBlock.Int64
 		if lastUpdated.Valid {
@@ -293,7 +447,7 @@ func (qb *QueryBuilder) BuildStatsQuery(ctx context.Context, query *types.StatsQ
 	}
 
 	if stats.LastUpdated.IsZero() {
-		if err := qb.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(created_at), NOW()) FROM events WHERE contract_address = $1`, query.ContractAddress).Scan(&stats.LastUpdated); err != nil {
+		if err := qb.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(created_at), NOW()) FROM events WHERE contract_address = $1 AND chain_id = $2`, statsArgs...).Scan(&stats.LastUpdated); err != nil {
 			stats.LastUpdated = time.Now()
 		}
 	}
@@ -311,11 +465,12 @@ func (qb *QueryBuilder) BuildStatsQuery(ctx context.Context, query *types.StatsQ
 			FROM events e
 			CROSS JOIN LATERAL jsonb_each_text(e.args) kv(key, value)
 			WHERE e.contract_address = $1
+			  AND e.chain_id = $2
 			  AND kv.value ~ '^0x[0-9a-fA-F]{40}$'
 		)
 		SELECT COUNT(DISTINCT addr) FROM addresses
 	`
-	if err := qb.db.QueryRowContext(ctx, uniqueQuery, query.ContractAddress).Scan(&uniqueAddresses); err == nil && uniqueAddresses.Valid {
+	if err := qb.db.QueryRowContext(ctx, uniqueQuery, statsArgs...).Scan(&uniqueAddresses); err == nil && uniqueAddresses.Valid {
 		count := uniqueAddresses.Int64
 		stats.UniqueAddresses = &count
 	}
@@ -440,6 +595,12 @@ func (qb *QueryBuilder) buildEventWhereClause(query *types.EventQuery) (string,
 	var args []interface{}
 	argIndex := 1
 
+	if query.ChainID != nil {
+		conditions = append(conditions, fmt.Sprintf("e.chain_id = $%d", argIndex))
+		args = append(args, *query.ChainID)
+		argIndex++
+	}
+
 	if query.ContractAddress != nil {
 		conditions = append(conditions, fmt.Sprintf("e.contract_address = $%d", argIndex))
 		args = append(args, *query.ContractAddress)
@@ -452,6 +613,12 @@ func (qb *QueryBuilder) buildEventWhereClause(query *types.EventQuery) (string,
 		argIndex++
 	}
 
+	if query.EventSignature != nil {
+		conditions = append(conditions, fmt.Sprintf("e.event_signature = $%d", argIndex))
+		args = append(args, *query.EventSignature)
+		argIndex++
+	}
+
 	if query.FromBlock != nil {
 		conditions = append(conditions, fmt.Sprintf("e.block_number >= $%d", argIndex))
 		args = append(args, *query.FromBlock)
@@ -470,15 +637,24 @@ func (qb *QueryBuilder) buildEventWhereClause(query *types.EventQuery) (string,
 		argIndex++
 	}
 
-	// Handle address filtering using JSONB
+	if query.Finality != nil {
+		conditions = append(conditions, fmt.Sprintf("e.finality = $%d", argIndex))
+		args = append(args, *query.Finality)
+		argIndex++
+	}
+
 	if len(query.Addresses) > 0 {
-		addressConditions := make([]string, len(query.Addresses))
-		for i, addr := range query.Addresses {
-			addressConditions[i] = fmt.Sprintf("e.args @> $%d", argIndex)
-			args = append(args, fmt.Sprintf(`{"from": "%s"}`, addr))
-			argIndex++
-		}
-		conditions = append(conditions, "("+strings.Join(addressConditions, " OR ")+")")
+		addressClause, addressArgs := qb.buildAddressClause(query.Addresses, query.AddressRole, argIndex)
+		conditions = append(conditions, addressClause)
+		args = append(args, addressArgs...)
+		argIndex += len(addressArgs)
+	}
+
+	for _, predicate := range query.ArgPredicates {
+		predicateClause, predicateArgs := qb.buildArgPredicateClause(predicate, argIndex)
+		conditions = append(conditions, predicateClause)
+		args = append(args, predicateArgs...)
+		argIndex += len(predicateArgs)
 	}
 
 	whereClause := ""
@@ -494,33 +670,11 @@ func (qb *QueryBuilder) parseEvents(rows *sql.Rows) ([]*models.Event, error) {
 	var events []*models.Event
 
 	for rows.Next() {
-		var event models.Event
-		var argsJSON string
-
-		err := rows.Scan(
-			&event.ID,
-			&event.ContractAddress,
-			&event.EventName,
-			&event.BlockNumber,
-			&event.BlockHash,
-			&event.TransactionHash,
-			&event.TransactionIndex,
-			&event.LogIndex,
-			&argsJSON,
-			&event.Timestamp,
-			&event.CreatedAt,
-		)
+		event, err := qb.scanEvent(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan event row: %w", err)
-		}
-
-		// Parse JSONB args
-		if err := json.Unmarshal([]byte(argsJSON), &event.Args); err != nil {
-			qb.logger.Warn("Failed to parse event args", "error", err)
-			event.Args = models.JSONB{}
+			return nil, err
 		}
-
-		events = append(events, &event)
+		events = append(events, event)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -530,6 +684,45 @@ func (qb *QueryBuilder) parseEvents(rows *sql.Rows) ([]*models.Event, error) {
 	return events, nil
 }
 
+// scanEvent scans the current row into an Event; extra receives the columns
+// selected after the event's own
+func (qb *QueryBuilder) scanEvent(rows *sql.Rows, extra ...interface{}) (*models.Event, error) {
+	var event models.Event
+	var argsJSON string
+
+	dest := []interface{}{
+		&event.ID,
+		&event.ChainID,
+		&event.ContractAddress,
+		&event.EventName,
+		&event.BlockNumber,
+		&event.BlockHash,
+		&event.TransactionHash,
+		&event.TransactionIndex,
+		&event.LogIndex,
+		&argsJSON,
+		&event.Timestamp,
+		&event.CreatedAt,
+		&event.Finality,
+		&event.ArgTypes,
+		&event.EventSignature,
+	}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return nil, fmt.Errorf("failed to scan event row: %w", err)
+	}
+
+	// Parse JSONB args, keeping numbers of events decoded before integers
+	// were stored as strings exact
+	decoder := json.NewDecoder(strings.NewReader(argsJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event.Args); err != nil {
+		qb.logger.Warn("Failed to parse event args", "error", err)
+		event.Args = models.JSONB{}
+	}
+
+	return &event, nil
+}
+
 // getLimit returns the appropriate limit for pagination
 func (qb *QueryBuilder) getLimit(first, last *int32, defaultLimit int32) int32 {
 	if first != nil && *first > 0 {
@@ -541,7 +734,29 @@ func (qb *QueryBuilder) getLimit(first, last *int32, defaultLimit int32) int32 {
 	return defaultLimit
 }
 
-func (qb *QueryBuilder) buildLimitClause(first, last *int32, limit, offset int32, startIndex int) (string, []interface{}) {
+// Event listings are ordered newest block first and by log index within a
+// block; the ID tie-breaker makes the order total across chains, which keyset
+// cursors need
+const (
+	eventOrderClause         = " ORDER BY e.block_number DESC, e.log_index ASC, e.id ASC"
+	reversedEventOrderClause = " ORDER BY e.block_number ASC, e.log_index DESC, e.id DESC"
+)
+
+// pageClause is the part of an event query that selects one page
+type pageClause struct {
+	where    string // keyset conditions, each prefixed with " AND "
+	tail     string // ORDER BY, LIMIT and OFFSET
+	args     []interface{}
+	size     int32 // events in a full page; one more row is fetched
+	backward bool  // rows are read in reverse order and must be flipped
+}
+
+// buildPageClause resolves the page size and keyset conditions for a query.
+// Cursors are positions in the listing order: after selects events that come
+// later, before selects events that come earlier. Backward pages (last
+// without first) read the order in reverse so LIMIT keeps the events nearest
+// the before cursor. The offset is only honoured without cursors.
+func (qb *QueryBuilder) buildPageClause(first, last *int32, after, before *string, limit, offset int32, startIndex int) (*pageClause, error) {
 	maxLimit := int32(qb.config.DefaultLimit)
 	if maxLimit == 0 {
 		maxLimit = 25
@@ -558,15 +773,68 @@ func (qb *QueryBuilder) buildLimitClause(first, last *int32, limit, offset int32
 		calculated = maxLimit
 	}
 
-	clause := fmt.Sprintf(" LIMIT $%d", startIndex)
-	args := []interface{}{calculated}
+	page := &pageClause{
+		size:     calculated,
+		backward: (first == nil || *first <= 0) && last != nil && *last > 0,
+	}
+	idx := startIndex
+
+	if after != nil {
+		cursor, err := models.DecodeEventCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		page.where += fmt.Sprintf(
+			" AND (e.block_number < $%d OR (e.block_number = $%d AND (e.log_index, e.id) > ($%d, $%d)))",
+			idx, idx, idx+1, idx+2,
+		)
+		page.args = append(page.args, cursor.BlockNumber, cursor.LogIndex, cursor.ID)
+		idx += 3
+	}
+
+	if before != nil {
+		cursor, err := models.DecodeEventCursor(*before)
+		if err != nil {
+			return nil, err
+		}
+		page.where += fmt.Sprintf(
+			" AND (e.block_number > $%d OR (e.block_number = $%d AND (e.log_index, e.id) < ($%d, $%d)))",
+			idx, idx, idx+1, idx+2,
+		)
+		page.args = append(page.args, cursor.BlockNumber, cursor.LogIndex, cursor.ID)
+		idx += 3
+	}
+
+	page.tail = eventOrderClause
+	if page.backward {
+		page.tail = reversedEventOrderClause
+	}
+	page.tail += fmt.Sprintf(" LIMIT $%d", idx)
+	page.args = append(page.args, calculated+1)
+	idx++
 
-	if offset > 0 {
-		clause += fmt.Sprintf(" OFFSET $%d", startIndex+1)
-		args = append(args, offset)
+	if offset > 0 && after == nil && before == nil {
+		page.tail += fmt.Sprintf(" OFFSET $%d", idx)
+		page.args = append(page.args, offset)
 	}
 
-	return clause, args
+	return page, nil
+}
+
+// trimPage drops the extra row fetched to detect further events and restores
+// the listing order of backward pages. It reports whether more events exist
+// beyond the page in the direction it was read.
+func (p *pageClause) trimPage(events []*models.Event) ([]*models.Event, bool) {
+	more := int32(len(events)) > p.size
+	if more {
+		events = events[:p.size]
+	}
+	if p.backward {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+	return events, more
 }
 
 func (qb *QueryBuilder) executeRows(ctx context.Context, label string, queryStr string, args []interface{}) (*sql.Rows, error) {
@@ -586,25 +854,112 @@ func (qb *QueryBuilder) queryRow(ctx context.Context, label string, queryStr str
 	return err
 }
 
-func (qb *QueryBuilder) buildAddressClause(address string, startIndex int) (string, []interface{}) {
-	fields := []string{"from", "to", "owner", "spender"}
-	var conditions []string
-	args := make([]interface{}, 0, len(fields))
+// buildAddressClause matches events holding any of the addresses in an
+// address-typed argument, or only in the argument named role, through the
+// event_addresses table the indexer fills as it stores events
+func (qb *QueryBuilder) buildAddressClause(addresses []string, role *string, startIndex int) (string, []interface{}) {
+	placeholders := make([]string, len(addresses))
+	args := make([]interface{}, 0, len(addresses)+1)
 	idx := startIndex
 
-	for _, field := range fields {
-		conditions = append(conditions, fmt.Sprintf("e.args @> $%d", idx))
-		args = append(args, fmt.Sprintf(`{"%s": "%s"}`, field, address))
+	for i, addr := range addresses {
+		placeholders[i] = fmt.Sprintf("$%d", idx)
+		args = append(args, strings.ToLower(addr))
 		idx++
 	}
 
-	// Fallback generic contains check using ilike on JSON text
-	conditions = append(conditions, fmt.Sprintf("encode(e.args::bytea, 'escape') ILIKE $%d", idx))
-	args = append(args, fmt.Sprintf("%%%s%%", strings.TrimPrefix(strings.ToLower(address), "0x")))
+	clause := "e.id IN (SELECT ea.event_id FROM event_addresses ea WHERE ea.address IN (" + strings.Join(placeholders, ", ") + ")"
+	if role != nil {
+		clause += fmt.Sprintf(" AND ea.role = $%d", idx)
+		args = append(args, *role)
+	}
+
+	return clause + ")", args
+}
+
+// argComparisons maps the numeric predicate operators to SQL
+var argComparisons = map[models.ArgOp]string{
+	models.ArgOpGt:  ">",
+	models.ArgOpGte: ">=",
+	models.ArgOpLt:  "<",
+	models.ArgOpLte: "<=",
+}
+
+// buildArgPredicateClause compiles a predicate on a decoded argument.
+// Equality becomes JSONB containment, served by the GIN index on args, or a
+// lookup in event_addresses when the value is an address. Numeric comparisons
+// cast the argument's digits to numeric, which holds 256-bit integers exactly,
+// behind a key existence check the GIN index also serves.
+func (qb *QueryBuilder) buildArgPredicateClause(predicate models.ArgPredicate, startIndex int) (string, []interface{}) {
+	var args []interface{}
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", startIndex+len(args)-1)
+	}
+
+	if predicate.Op.IsNumeric() {
+		bound, _ := models.ParseArgInt(predicate.Value)
+		name := placeholder(predicate.Name) + "::text"
+		value := placeholder(bound.String()) + "::numeric"
+		clause := fmt.Sprintf(
+			"(e.args ? %[1]s AND (CASE WHEN e.args->>%[1]s ~ '^-?[0-9]+(\\.[0-9]+)?$' THEN (e.args->>%[1]s)::numeric END) %[2]s %[3]s)",
+			name, argComparisons[predicate.Op], value,
+		)
+		return clause, args
+	}
+
+	values := predicate.Values
+	if predicate.Op != models.ArgOpIn {
+		values = []string{predicate.Value}
+	}
+
+	var conditions []string
+	for _, value := range values {
+		if models.IsArgAddress(value) {
+			conditions = append(conditions, fmt.Sprintf(
+				"e.id IN (SELECT ea.event_id FROM event_addresses ea WHERE ea.address = %s AND ea.role = %s)",
+				placeholder(strings.ToLower(value)), placeholder(predicate.Name),
+			))
+			continue
+		}
+		for _, candidate := range argJSONValues(value) {
+			doc, _ := json.Marshal(map[string]interface{}{predicate.Name: candidate})
+			conditions = append(conditions, fmt.Sprintf("e.args @> %s::jsonb", placeholder(string(doc))))
+		}
+	}
 
 	return "(" + strings.Join(conditions, " OR ") + ")", args
 }
 
+// argJSONValues returns the JSON values an argument equal to value may be
+// stored as: a string, and also a bool for true and false, or a number for
+// the integers of events stored before integers were kept as strings
+func argJSONValues(value string) []interface{} {
+	values := []interface{}{value}
+	switch {
+	case value == "true" || value == "false":
+		values = append(values, value == "true")
+	case isDecimalInt(value):
+		values = append(values, json.Number(value))
+	}
+	return values
+}
+
+// isDecimalInt reports whether value is a decimal integer written as a JSON
+// number would be, without leading zeros
+func isDecimalInt(value string) bool {
+	digits := strings.TrimPrefix(value, "-")
+	if digits == "" || (digits[0] == '0' && len(digits) > 1) {
+		return false
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func (qb *QueryBuilder) observeQuery(label, queryStr string, args []interface{}, start time.Time, err error) {
 	duration := time.Since(start)
 	if err != nil && err != sql.ErrNoRows {
@@ -0,0 +1,176 @@
+package optimizer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/smart-contract-event-indexer/query-service/internal/config"
+	"github.com/smart-contract-event-indexer/query-service/internal/types"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+func TestBuildPageClause(t *testing.T) {
+	qb := NewQueryBuilder(nil, utils.NewTestLogger(), &config.Config{DefaultLimit: 20, MaxQueryLimit: 100})
+
+	first := int32(10)
+	page, err := qb.buildPageClause(&first, nil, nil, nil, 0, 0, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.backward || page.size != 10 || page.where != "" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+	if !strings.HasSuffix(page.tail, "LIMIT $3") || page.args[0] != int32(11) {
+		t.Fatalf("expected one extra row to be fetched, got %q %v", page.tail, page.args)
+	}
+
+	after := models.EventCursor{BlockNumber: 50, LogIndex: 2, ID: 900}.Encode()
+	page, err = qb.buildPageClause(&first, nil, &after, nil, 0, 40, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(page.where, "e.block_number < $1") || strings.Contains(page.tail, "OFFSET") {
+		t.Fatalf("expected keyset condition without offset, got %q %q", page.where, page.tail)
+	}
+	if len(page.args) != 4 || page.args[0] != int64(50) || page.args[1] != 2 || page.args[2] != int64(900) {
+		t.Fatalf("unexpected args: %v", page.args)
+	}
+
+	last := int32(5)
+	before := after
+	page, err = qb.buildPageClause(nil, &last, nil, &before, 0, 0, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !page.backward || !strings.Contains(page.tail, reversedEventOrderClause) {
+		t.Fatalf("expected a reversed read for last/before, got %+v", page)
+	}
+
+	bad := "not-a-cursor"
+	if _, err := qb.buildPageClause(&first, nil, &bad, nil, 0, 0, 1); !errors.Is(err, models.ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestTrimPage(t *testing.T) {
+	events := []*models.Event{{ID: 3}, {ID: 2}, {ID: 1}}
+
+	forward := &pageClause{size: 2}
+	page, more := forward.trimPage(append([]*models.Event{}, events...))
+	if !more || len(page) != 2 || page[0].ID != 3 || page[1].ID != 2 {
+		t.Fatalf("unexpected forward page: more=%v ids=%v", more, eventIDs(page))
+	}
+
+	backward := &pageClause{size: 2, backward: true}
+	page, more = backward.trimPage(append([]*models.Event{}, events...))
+	if !more || len(page) != 2 || page[0].ID != 2 || page[1].ID != 3 {
+		t.Fatalf("unexpected backward page: more=%v ids=%v", more, eventIDs(page))
+	}
+
+	page, more = backward.trimPage(events[:1])
+	if more || len(page) != 1 {
+		t.Fatalf("expected a short page without more events, got more=%v ids=%v", more, eventIDs(page))
+	}
+}
+
+func TestBuildAddressClause(t *testing.T) {
+	qb := NewQueryBuilder(nil, utils.NewTestLogger(), &config.Config{DefaultLimit: 20, MaxQueryLimit: 100})
+
+	clause, args := qb.buildAddressClause([]string{"0xAbC0000000000000000000000000000000000001"}, nil, 3)
+	if !strings.Contains(clause, "FROM event_addresses ea WHERE ea.address IN ($3)") || strings.Contains(clause, "ea.role") {
+		t.Fatalf("unexpected clause: %q", clause)
+	}
+	if len(args) != 1 || args[0] != "0xabc0000000000000000000000000000000000001" {
+		t.Fatalf("expected the address lower-cased, got %v", args)
+	}
+
+	role := "to"
+	query := &types.EventQuery{
+		Addresses:   []string{"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002"},
+		AddressRole: &role,
+	}
+	where, args := qb.buildEventWhereClause(query)
+	if !strings.Contains(where, "ea.address IN ($1, $2) AND ea.role = $3") {
+		t.Fatalf("expected both addresses and the role in the clause, got %q", where)
+	}
+	if len(args) != 3 || args[2] != "to" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+	if strings.Contains(where, "e.args") {
+		t.Fatalf("expected no JSONB scan of the args, got %q", where)
+	}
+}
+
+func TestBuildSimpleEventFilter(t *testing.T) {
+	contract := "0x0000000000000000000000000000000000000001"
+	eventName := "Transfer"
+	fromBlock, toBlock := int64(100), int64(200)
+	query := &types.EventQuery{
+		ContractAddress: &contract,
+		EventName:       &eventName,
+		FromBlock:       &fromBlock,
+		ToBlock:         &toBlock,
+	}
+
+	// The count reuses this clause, so the total covers the same block range
+	// as the page
+	where, args := buildSimpleEventFilter(query)
+	want := " WHERE e.contract_address = $1 AND e.event_name = $2 AND e.block_number >= $3 AND e.block_number <= $4"
+	if where != want {
+		t.Fatalf("where = %q, want %q", where, want)
+	}
+	if len(args) != 4 || args[2] != fromBlock || args[3] != toBlock {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestBuildArgPredicateClause(t *testing.T) {
+	qb := NewQueryBuilder(nil, utils.NewTestLogger(), &config.Config{DefaultLimit: 20, MaxQueryLimit: 100})
+
+	clause, args := qb.buildArgPredicateClause(models.ArgPredicate{Name: "value", Op: models.ArgOpGt, Value: "0xd3c21bcecceda1000000"}, 2)
+	if !strings.Contains(clause, "e.args ? $2::text") || !strings.Contains(clause, "::numeric END) > $3::numeric") {
+		t.Fatalf("unexpected numeric clause: %q", clause)
+	}
+	if len(args) != 2 || args[0] != "value" || args[1] != "1000000000000000000000000" {
+		t.Fatalf("expected the bound as exact decimal digits, got %v", args)
+	}
+
+	clause, args = qb.buildArgPredicateClause(models.ArgPredicate{Name: "approved", Op: models.ArgOpEq, Value: "true"}, 1)
+	if clause != "(e.args @> $1::jsonb OR e.args @> $2::jsonb)" {
+		t.Fatalf("unexpected equality clause: %q", clause)
+	}
+	if len(args) != 2 || args[0] != `{"approved":"true"}` || args[1] != `{"approved":true}` {
+		t.Fatalf("expected string and bool containment, got %v", args)
+	}
+
+	clause, args = qb.buildArgPredicateClause(models.ArgPredicate{
+		Name:   "to",
+		Op:     models.ArgOpIn,
+		Values: []string{"0xAbC0000000000000000000000000000000000001", "42"},
+	}, 1)
+	if !strings.Contains(clause, "ea.address = $1 AND ea.role = $2") || !strings.Contains(clause, "e.args @> $3::jsonb OR e.args @> $4::jsonb") {
+		t.Fatalf("unexpected IN clause: %q", clause)
+	}
+	if len(args) != 4 || args[0] != "0xabc0000000000000000000000000000000000001" || args[3] != `{"to":42}` {
+		t.Fatalf("unexpected IN args: %v", args)
+	}
+
+	query := &types.EventQuery{ArgPredicates: []models.ArgPredicate{
+		{Name: "from", Op: models.ArgOpEq, Value: "alice"},
+		{Name: "value", Op: models.ArgOpLte, Value: "10"},
+	}}
+	where, args := qb.buildEventWhereClause(query)
+	if !strings.Contains(where, "e.args @> $1::jsonb") || !strings.Contains(where, "e.args ? $2::text") || len(args) != 3 {
+		t.Fatalf("expected both predicates with consecutive placeholders, got %q %v", where, args)
+	}
+}
+
+func eventIDs(events []*models.Event) []int64 {
+	ids := make([]int64, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	return ids
+}
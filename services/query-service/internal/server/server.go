@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,8 +16,10 @@ import (
 	"github.com/smart-contract-event-indexer/query-service/internal/types"
 	"github.com/smart-contract-event-indexer/shared/models"
 	protoapi "github.com/smart-contract-event-indexer/shared/proto"
+	"github.com/smart-contract-event-indexer/shared/pubsub"
 	"github.com/smart-contract-event-indexer/shared/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -197,10 +200,16 @@ func (s *QueryServiceServer) HealthCheck(ctx context.Context) error {
 
 // GetEvents handles the gRPC call and proxies to the domain query service.
 func (s *QueryServiceServer) GetEvents(ctx context.Context, req *protoapi.EventQuery) (*protoapi.EventResponse, error) {
+	if val := req.GetFinality(); val != "" && !models.Finality(val).IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid finality %q", val)
+	}
 	query := convertEventQuery(req)
+	if err := validateArgPredicates(query.ArgPredicates); err != nil {
+		return nil, err
+	}
 	resp, err := s.queryService.GetEvents(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, queryError(err)
 	}
 	return convertEventResponse(resp), nil
 }
@@ -209,15 +218,38 @@ func (s *QueryServiceServer) GetEventsByAddress(ctx context.Context, req *protoa
 	query := convertAddressQuery(req)
 	resp, err := s.queryService.GetEventsByAddress(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, queryError(err)
 	}
 	return convertEventResponse(resp), nil
 }
 
+// validateArgPredicates reports malformed argument predicates as invalid
+// arguments before they reach the query builder
+func validateArgPredicates(predicates []models.ArgPredicate) error {
+	for _, predicate := range predicates {
+		if err := predicate.Validate(); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return nil
+}
+
+// queryError reports malformed cursors as invalid arguments rather than
+// internal errors
+func queryError(err error) error {
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
 func (s *QueryServiceServer) GetEventsByTransaction(ctx context.Context, req *protoapi.TransactionQuery) (*protoapi.EventResponse, error) {
 	query := &types.TransactionQuery{
 		TransactionHash: req.TransactionHash,
 	}
+	if val := req.GetChainId(); val > 0 {
+		query.ChainID = int64Ptr(val)
+	}
 	resp, err := s.queryService.GetEventsByTransaction(ctx, query)
 	if err != nil {
 		return nil, err
@@ -227,6 +259,7 @@ func (s *QueryServiceServer) GetEventsByTransaction(ctx context.Context, req *pr
 
 func (s *QueryServiceServer) GetContractStats(ctx context.Context, req *protoapi.StatsQuery) (*protoapi.StatsResponse, error) {
 	stats, err := s.queryService.GetContractStats(ctx, &types.StatsQuery{
+		ChainID:         req.GetChainId(),
 		ContractAddress: req.GetContractAddress(),
 	})
 	if err != nil {
@@ -235,6 +268,66 @@ func (s *QueryServiceServer) GetContractStats(ctx context.Context, req *protoapi
 	return convertStatsResponse(stats), nil
 }
 
+// StreamEvents replays matching events and, with follow set, tails new ones.
+// Sends block under gRPC flow control, which in turn paces the database reads.
+func (s *QueryServiceServer) StreamEvents(req *protoapi.EventQuery, stream protoapi.QueryService_StreamEventsServer) error {
+	if val := req.GetFinality(); val != "" && !models.Finality(val).IsValid() {
+		return status.Errorf(codes.InvalidArgument, "invalid finality %q", val)
+	}
+	ctx := stream.Context()
+	query := convertEventQuery(req)
+	if err := validateArgPredicates(query.ArgPredicates); err != nil {
+		return err
+	}
+
+	var wake <-chan struct{}
+	if req.GetFollow() {
+		channel := pubsub.EventChannelPattern
+		if query.ChainID != nil {
+			channel = pubsub.EventChannel(*query.ChainID)
+		}
+		sub := s.redisClient.PSubscribe(ctx, channel)
+		defer sub.Close()
+		wake = wakeOnMessage(sub.Channel())
+	}
+
+	err := s.queryService.StreamEvents(ctx, query, wake, func(events []*types.StreamEvent) error {
+		for _, streamed := range events {
+			evt := convertEvent(streamed.Event)
+			evt.StreamCursor = service.EncodeStreamCursor(streamed.Position)
+			if err := stream.Send(evt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, service.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	default:
+		return err
+	}
+}
+
+// wakeOnMessage coalesces pub/sub messages into a wake-up signal that never
+// holds more than one pending notification
+func wakeOnMessage(messages <-chan *redis.Message) <-chan struct{} {
+	wake := make(chan struct{}, 1)
+	go func() {
+		for range messages {
+			select {
+			case wake <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return wake
+}
+
 // --- conversion helpers ---
 
 func convertEventQuery(req *protoapi.EventQuery) *types.EventQuery {
@@ -242,12 +335,33 @@ func convertEventQuery(req *protoapi.EventQuery) *types.EventQuery {
 		Addresses: req.GetAddresses(),
 	}
 
+	if val := req.GetChainId(); val > 0 {
+		query.ChainID = int64Ptr(val)
+	}
 	if val := req.GetContractAddress(); val != "" {
 		query.ContractAddress = stringPtr(val)
 	}
 	if val := req.GetEventName(); val != "" {
 		query.EventName = stringPtr(val)
 	}
+	if val := req.GetEventSignature(); val != "" {
+		query.EventSignature = stringPtr(val)
+	}
+	if val := req.GetAddressRole(); val != "" {
+		query.AddressRole = stringPtr(val)
+	}
+	for _, predicate := range req.GetArgPredicates() {
+		op := models.ArgOp(predicate.GetOp())
+		if op == "" {
+			op = models.ArgOpEq
+		}
+		query.ArgPredicates = append(query.ArgPredicates, models.ArgPredicate{
+			Name:   predicate.GetName(),
+			Op:     op,
+			Value:  predicate.GetValue(),
+			Values: predicate.GetValues(),
+		})
+	}
 	if val := req.GetFromBlock(); val > 0 {
 		query.FromBlock = int64Ptr(val)
 	}
@@ -257,6 +371,9 @@ func convertEventQuery(req *protoapi.EventQuery) *types.EventQuery {
 	if val := req.GetTransactionHash(); val != "" {
 		query.TransactionHash = stringPtr(val)
 	}
+	if val := req.GetFinality(); val != "" {
+		query.Finality = stringPtr(val)
+	}
 	if val := req.GetFirst(); val > 0 {
 		query.First = int32Ptr(val)
 	}
@@ -277,9 +394,15 @@ func convertAddressQuery(req *protoapi.AddressQuery) *types.AddressQuery {
 	query := &types.AddressQuery{
 		Address: req.GetAddress(),
 	}
+	if val := req.GetChainId(); val > 0 {
+		query.ChainID = int64Ptr(val)
+	}
 	if val := req.GetContractAddress(); val != "" {
 		query.ContractAddress = stringPtr(val)
 	}
+	if val := req.GetRole(); val != "" {
+		query.Role = stringPtr(val)
+	}
 	if val := req.GetFirst(); val > 0 {
 		query.First = int32Ptr(val)
 	}
@@ -319,33 +442,44 @@ func convertEvents(events []*models.Event) []*protoapi.Event {
 		if evt == nil {
 			continue
 		}
-		result = append(result, &protoapi.Event{
-			Id:               evt.ID,
-			ContractAddress:  string(evt.ContractAddress),
-			EventName:        evt.EventName,
-			BlockNumber:      evt.BlockNumber,
-			BlockHash:        string(evt.BlockHash),
-			TransactionHash:  string(evt.TransactionHash),
-			TransactionIndex: int32(evt.TransactionIndex),
-			LogIndex:         int32(evt.LogIndex),
-			Args:             convertEventArgs(evt.Args),
-			Timestamp:        timestamppb.New(evt.Timestamp),
-			CreatedAt:        timestamppb.New(evt.CreatedAt),
-		})
+		result = append(result, convertEvent(evt))
 	}
 	return result
 }
 
-func convertEventArgs(args models.JSONB) []*protoapi.EventArg {
-	if len(args) == 0 {
+func convertEvent(evt *models.Event) *protoapi.Event {
+	return &protoapi.Event{
+		Id:               evt.ID,
+		ChainId:          evt.ChainID,
+		ContractAddress:  string(evt.ContractAddress),
+		EventName:        evt.EventName,
+		EventSignature:   evt.EventSignature,
+		BlockNumber:      evt.BlockNumber,
+		BlockHash:        string(evt.BlockHash),
+		TransactionHash:  string(evt.TransactionHash),
+		TransactionIndex: int32(evt.TransactionIndex),
+		LogIndex:         int32(evt.LogIndex),
+		Args:             convertEventArgs(evt),
+		Timestamp:        timestamppb.New(evt.Timestamp),
+		CreatedAt:        timestamppb.New(evt.CreatedAt),
+		Finality:         string(evt.Finality),
+	}
+}
+
+// convertEventArgs returns an event's arguments in ABI order with their
+// Solidity types
+func convertEventArgs(evt *models.Event) []*protoapi.EventArg {
+	if len(evt.Args) == 0 {
 		return nil
 	}
-	result := make([]*protoapi.EventArg, 0, len(args))
-	for key, value := range args {
+	ordered := evt.OrderedArgs()
+	result := make([]*protoapi.EventArg, 0, len(ordered))
+	for _, arg := range ordered {
 		result = append(result, &protoapi.EventArg{
-			Key:   key,
-			Value: fmt.Sprintf("%v", value),
-			Type:  fmt.Sprintf("%T", value),
+			Key:     arg.Name,
+			Value:   models.FormatArgValue(arg.Value),
+			Type:    arg.Type,
+			Indexed: arg.Indexed,
 		})
 	}
 	return result
@@ -361,14 +495,8 @@ func convertPageInfo(info *types.PageInfo) *protoapi.PageInfo {
 		HasPreviousPage: info.HasPreviousPage,
 	}
 
-	if info.StartCursor != nil {
-		cursor := fmt.Sprintf("%d", *info.StartCursor)
-		page.StartCursor = stringPtr(cursor)
-	}
-	if info.EndCursor != nil {
-		cursor := fmt.Sprintf("%d", *info.EndCursor)
-		page.EndCursor = stringPtr(cursor)
-	}
+	page.StartCursor = info.StartCursor
+	page.EndCursor = info.EndCursor
 
 	return page
 }
@@ -384,6 +512,7 @@ func convertStatsResponse(stats *types.StatsResponse) *protoapi.StatsResponse {
 	}
 
 	resp := &protoapi.StatsResponse{
+		ChainId:         stats.ChainID,
 		ContractAddress: stats.ContractAddress,
 		TotalEvents:     stats.TotalEvents,
 		LatestBlock:     stats.LatestBlock,
@@ -15,7 +15,11 @@ import (
 	"github.com/smart-contract-event-indexer/shared/utils"
 )
 
-const cacheVersion = "v2"
+const cacheVersion = "v5"
+
+// pendingEventsCacheTTL caps how long responses holding pending events are
+// cached, since those events are confirmed or dropped within a few blocks
+const pendingEventsCacheTTL = 5 * time.Second
 
 type queryPath string
 
@@ -78,13 +82,14 @@ func (s *QueryService) GetEvents(ctx context.Context, query *types.EventQuery) (
 	var (
 		events     []*models.Event
 		totalCount int32
+		more       bool
 	)
 
 	switch queryPath {
 	case queryPathSimple:
-		events, totalCount, err = s.queryBuilder.BuildSimpleEventQuery(ctx, query)
+		events, totalCount, more, err = s.queryBuilder.BuildSimpleEventQuery(ctx, query)
 	default:
-		events, totalCount, err = s.queryBuilder.BuildEventQuery(ctx, query)
+		events, totalCount, more, err = s.queryBuilder.BuildEventQuery(ctx, query)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to build event query: %w", err)
@@ -93,14 +98,14 @@ func (s *QueryService) GetEvents(ctx context.Context, query *types.EventQuery) (
 	response = &types.EventResponse{
 		Events:     events,
 		TotalCount: totalCount,
-		PageInfo:   s.buildPageInfo(events, query),
+		PageInfo:   buildPageInfo(events, more, query.IsBackward(), query.After, query.Before),
 	}
 
 	if len(events) == 0 {
 		s.cache.MarkNegative(ctx, cacheKey)
 	}
 
-	ttl := s.getCacheTTL(query)
+	ttl := capTTLForPending(s.getCacheTTL(query), events)
 	if err := s.cache.Set(ctx, cacheKey, response, ttl); err != nil {
 		s.logger.Warn("Failed to cache events query", "error", err)
 	}
@@ -135,7 +140,7 @@ func (s *QueryService) GetEventsByAddress(ctx context.Context, query *types.Addr
 	start := time.Now()
 
 	// Build and execute query
-	events, totalCount, err := s.queryBuilder.BuildAddressQuery(ctx, query)
+	events, totalCount, more, err := s.queryBuilder.BuildAddressQuery(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build address query: %w", err)
 	}
@@ -144,7 +149,7 @@ func (s *QueryService) GetEventsByAddress(ctx context.Context, query *types.Addr
 	response = &types.EventResponse{
 		Events:     events,
 		TotalCount: totalCount,
-		PageInfo:   s.buildAddressPageInfo(events, query),
+		PageInfo:   buildPageInfo(events, more, query.IsBackward(), query.After, query.Before),
 	}
 
 	if len(events) == 0 {
@@ -152,7 +157,7 @@ func (s *QueryService) GetEventsByAddress(ctx context.Context, query *types.Addr
 	}
 
 	// Cache the response
-	ttl := s.getAddressCacheTTL(query)
+	ttl := capTTLForPending(s.getAddressCacheTTL(query), events)
 	if err := s.cache.Set(ctx, cacheKey, response, ttl); err != nil {
 		s.logger.Warn("Failed to cache address query", "error", err)
 	}
@@ -209,8 +214,8 @@ func (s *QueryService) GetEventsByTransaction(ctx context.Context, query *types.
 		},
 	}
 
-	// Cache the response (transactions are immutable, so longer TTL)
-	if err := s.cache.Set(ctx, cacheKey, response, 1*time.Hour); err != nil {
+	// Cache the response (confirmed transactions are immutable, so longer TTL)
+	if err := s.cache.Set(ctx, cacheKey, response, capTTLForPending(1*time.Hour, events)); err != nil {
 		s.logger.Warn("Failed to cache transaction query", "error", err)
 	} else if len(events) == 0 {
 		s.cache.MarkNegative(ctx, cacheKey)
@@ -364,78 +369,27 @@ func (s *QueryService) generateAggregationCacheKey(cacheType string, query inter
 	return cache.NewCacheKey(cacheType, hash, cacheVersion), nil
 }
 
-// buildPageInfo builds pagination information for event queries
-func (s *QueryService) buildPageInfo(events []*models.Event, query *types.EventQuery) *types.PageInfo {
-	if len(events) == 0 {
-		return &types.PageInfo{
-			HasNextPage:     false,
-			HasPreviousPage: false,
-		}
+// buildPageInfo builds pagination information for a page of events. more
+// reports whether the query found events beyond the page in the direction it
+// was read; in the other direction a cursor means the caller came from there.
+func buildPageInfo(events []*models.Event, more, backward bool, after, before *string) *types.PageInfo {
+	info := &types.PageInfo{}
+	if backward {
+		info.HasPreviousPage = more
+		info.HasNextPage = before != nil
+	} else {
+		info.HasNextPage = more
+		info.HasPreviousPage = after != nil
 	}
 
-	// Simple cursor-based pagination using event ID
-	hasNext := false
-	hasPrevious := false
-	var startCursor, endCursor *int64
-
 	if len(events) > 0 {
-		firstID := events[0].ID
-		lastID := events[len(events)-1].ID
-		startCursor = &firstID
-		endCursor = &lastID
-
-		// Check if there are more results (simplified logic)
-		if query.First != nil && len(events) == int(*query.First) {
-			hasNext = true
-		}
-		if query.Before != nil {
-			hasPrevious = true
-		}
+		startCursor := models.CursorForEvent(events[0]).Encode()
+		endCursor := models.CursorForEvent(events[len(events)-1]).Encode()
+		info.StartCursor = &startCursor
+		info.EndCursor = &endCursor
 	}
 
-	return &types.PageInfo{
-		HasNextPage:     hasNext,
-		HasPreviousPage: hasPrevious,
-		StartCursor:     startCursor,
-		EndCursor:       endCursor,
-	}
-}
-
-// buildAddressPageInfo builds pagination information for address queries
-func (s *QueryService) buildAddressPageInfo(events []*models.Event, query *types.AddressQuery) *types.PageInfo {
-	if len(events) == 0 {
-		return &types.PageInfo{
-			HasNextPage:     false,
-			HasPreviousPage: false,
-		}
-	}
-
-	// Simple cursor-based pagination using event ID
-	hasNext := false
-	hasPrevious := false
-	var startCursor, endCursor *int64
-
-	if len(events) > 0 {
-		firstID := events[0].ID
-		lastID := events[len(events)-1].ID
-		startCursor = &firstID
-		endCursor = &lastID
-
-		// Check if there are more results (simplified logic)
-		if query.First != nil && len(events) == int(*query.First) {
-			hasNext = true
-		}
-		if query.Before != nil {
-			hasPrevious = true
-		}
-	}
-
-	return &types.PageInfo{
-		HasNextPage:     hasNext,
-		HasPreviousPage: hasPrevious,
-		StartCursor:     startCursor,
-		EndCursor:       endCursor,
-	}
+	return info
 }
 
 // getCacheTTL returns the appropriate TTL for event queries
@@ -457,6 +411,19 @@ func (s *QueryService) getCacheTTL(query *types.EventQuery) time.Duration {
 	return 5 * time.Minute
 }
 
+// capTTLForPending shortens ttl when any of the events is still pending
+func capTTLForPending(ttl time.Duration, events []*models.Event) time.Duration {
+	if ttl <= pendingEventsCacheTTL {
+		return ttl
+	}
+	for _, event := range events {
+		if event != nil && event.Finality == models.FinalityPending {
+			return pendingEventsCacheTTL
+		}
+	}
+	return ttl
+}
+
 // getAddressCacheTTL returns the appropriate TTL for address queries
 func (s *QueryService) getAddressCacheTTL(query *types.AddressQuery) time.Duration {
 	// Address queries are often for recent activity
@@ -471,10 +438,9 @@ func (s *QueryService) withQueryTimeout(parent context.Context) (context.Context
 }
 
 func (s *QueryService) determineEventQueryPath(query *types.EventQuery) queryPath {
-	// Complex path if addresses, transaction hashes, or cursor pagination are in play.
-	if len(query.Addresses) > 0 ||
-		query.TransactionHash != nil ||
-		query.After != nil || query.Before != nil {
+	// Complex path if addresses, argument predicates or transaction hashes are
+	// in play. Both paths page with the same keyset cursors.
+	if len(query.Addresses) > 0 || len(query.ArgPredicates) > 0 || query.TransactionHash != nil {
 		return queryPathComplex
 	}
 
@@ -491,7 +457,7 @@ func (s *QueryService) emptyEventResponse(query *types.EventQuery) *types.EventR
 	return &types.EventResponse{
 		Events:     []*models.Event{},
 		TotalCount: 0,
-		PageInfo:   s.buildPageInfo(nil, query),
+		PageInfo:   buildPageInfo(nil, false, query.IsBackward(), query.After, query.Before),
 	}
 }
 
@@ -499,7 +465,7 @@ func (s *QueryService) emptyAddressResponse(query *types.AddressQuery) *types.Ev
 	return &types.EventResponse{
 		Events:     []*models.Event{},
 		TotalCount: 0,
-		PageInfo:   s.buildAddressPageInfo(nil, query),
+		PageInfo:   buildPageInfo(nil, false, query.IsBackward(), query.After, query.Before),
 	}
 }
 
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/smart-contract-event-indexer/query-service/internal/config"
 	"github.com/smart-contract-event-indexer/query-service/internal/types"
@@ -23,22 +25,114 @@ func TestDetermineEventQueryPath(t *testing.T) {
 	if path := svc.determineEventQueryPath(complex); path != queryPathComplex {
 		t.Fatalf("expected complex path, got %s", path)
 	}
+
+	predicated := &types.EventQuery{ContractAddress: &addr, EventName: &evt, ArgPredicates: []models.ArgPredicate{{Name: "value", Op: models.ArgOpGt, Value: "1"}}}
+	if path := svc.determineEventQueryPath(predicated); path != queryPathComplex {
+		t.Fatalf("expected argument predicates to take the complex path, got %s", path)
+	}
 }
 
 func TestBuildPageInfo(t *testing.T) {
-	svc := &QueryService{config: &config.Config{}, logger: utils.NewTestLogger()}
-
-	events := []*models.Event{{ID: 101}, {ID: 102}, {ID: 103}}
-	first := int32(3)
-	info := svc.buildPageInfo(events, &types.EventQuery{First: &first})
+	events := []*models.Event{
+		{ID: 101, BlockNumber: 12, LogIndex: 0},
+		{ID: 102, BlockNumber: 12, LogIndex: 1},
+		{ID: 103, BlockNumber: 11, LogIndex: 0},
+	}
 
-	if !info.HasNextPage {
-		t.Fatalf("expected HasNextPage true when results hit requested limit")
+	info := buildPageInfo(events, true, false, nil, nil)
+	if !info.HasNextPage || info.HasPreviousPage {
+		t.Fatalf("expected only a next page on the first forward page, got %+v", info)
 	}
-	if info.StartCursor == nil || *info.StartCursor != 101 {
+	if info.StartCursor == nil || *info.StartCursor != models.CursorForEvent(events[0]).Encode() {
 		t.Fatalf("unexpected start cursor: %+v", info.StartCursor)
 	}
-	if info.EndCursor == nil || *info.EndCursor != 103 {
+	if info.EndCursor == nil || *info.EndCursor != models.CursorForEvent(events[2]).Encode() {
 		t.Fatalf("unexpected end cursor: %+v", info.EndCursor)
 	}
+
+	// A full page with nothing beyond it is the last page
+	after := *info.EndCursor
+	info = buildPageInfo(events, false, false, &after, nil)
+	if info.HasNextPage || !info.HasPreviousPage {
+		t.Fatalf("expected only a previous page after the cursor, got %+v", info)
+	}
+
+	// Backward pages read towards the start of the listing
+	before := *info.StartCursor
+	info = buildPageInfo(events, true, true, nil, &before)
+	if !info.HasPreviousPage || !info.HasNextPage {
+		t.Fatalf("expected both pages for a backward page with more events, got %+v", info)
+	}
+
+	info = buildPageInfo(nil, false, false, nil, nil)
+	if info.HasNextPage || info.HasPreviousPage || info.StartCursor != nil || info.EndCursor != nil {
+		t.Fatalf("expected an empty page info, got %+v", info)
+	}
+}
+
+func TestCapTTLForPending(t *testing.T) {
+	confirmed := []*models.Event{{ID: 1, Finality: models.FinalityConfirmed}}
+	if ttl := capTTLForPending(time.Minute, confirmed); ttl != time.Minute {
+		t.Fatalf("expected confirmed events to keep the ttl, got %s", ttl)
+	}
+
+	mixed := []*models.Event{{ID: 1, Finality: models.FinalityConfirmed}, {ID: 2, Finality: models.FinalityPending}}
+	if ttl := capTTLForPending(time.Minute, mixed); ttl != pendingEventsCacheTTL {
+		t.Fatalf("expected pending events to cap the ttl, got %s", ttl)
+	}
+
+	if ttl := capTTLForPending(time.Second, mixed); ttl != time.Second {
+		t.Fatalf("expected shorter ttl to be kept, got %s", ttl)
+	}
+}
+
+func TestParseStreamCursor(t *testing.T) {
+	if pos, id, err := parseStreamCursor(nil); err != nil || id != 0 || pos != (types.StreamPosition{BlockNumber: -1}) {
+		t.Fatalf("expected empty cursor to replay from the start, got %+v, %d (%v)", pos, id, err)
+	}
+
+	cursor := "42"
+	if _, id, err := parseStreamCursor(&cursor); err != nil || id != 42 {
+		t.Fatalf("expected event 42 to be looked up, got %d (%v)", id, err)
+	}
+
+	page := models.EventCursor{BlockNumber: 12, LogIndex: 3, ID: 77}.Encode()
+	want := types.StreamPosition{BlockNumber: 12, LogIndex: 3, ID: 77}
+	if pos, id, err := parseStreamCursor(&page); err != nil || id != 0 || pos != want {
+		t.Fatalf("expected page cursor to replay after event 77, got %+v, %d (%v)", pos, id, err)
+	}
+
+	for _, want := range []types.StreamPosition{
+		{ReplayBelow: 900, BlockNumber: 12, LogIndex: 3, ID: 77},
+		{XID: 905, ID: 80},
+	} {
+		encoded := EncodeStreamCursor(want)
+		if pos, id, err := parseStreamCursor(&encoded); err != nil || id != 0 || pos != want {
+			t.Fatalf("expected stream cursor to resume at %+v, got %+v, %d (%v)", want, pos, id, err)
+		}
+	}
+
+	for _, bad := range []string{
+		"abc",
+		"0",
+		EncodeStreamCursor(types.StreamPosition{ID: 80}),
+		EncodeStreamCursor(types.StreamPosition{ReplayBelow: 900, XID: 905, ID: 80}),
+	} {
+		if _, _, err := parseStreamCursor(&bad); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("expected ErrInvalidCursor for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestStreamBatchSize(t *testing.T) {
+	svc := &QueryService{config: &config.Config{DefaultLimit: 20, MaxQueryLimit: 100}, logger: utils.NewTestLogger()}
+
+	if size := svc.streamBatchSize(&types.EventQuery{}); size != 20 {
+		t.Fatalf("expected default batch size 20, got %d", size)
+	}
+
+	first := int32(500)
+	if size := svc.streamBatchSize(&types.EventQuery{First: &first}); size != 100 {
+		t.Fatalf("expected batch size capped at 100, got %d", size)
+	}
 }
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smart-contract-event-indexer/query-service/internal/types"
+	"github.com/smart-contract-event-indexer/shared/models"
+)
+
+// defaultStreamPollInterval is used when no poll interval is configured
+const defaultStreamPollInterval = 2 * time.Second
+
+// ErrInvalidCursor is returned when a pagination or stream cursor cannot be
+// decoded
+var ErrInvalidCursor = models.ErrInvalidCursor
+
+// streamCursorVersion prefixes encoded stream cursors so the format can
+// change later
+const streamCursorVersion = "s1"
+
+// StreamEvents replays the events matching query and passes them to send one
+// batch at a time. The replay covers the events committed when the stream
+// starts, in block order from query.FromBlock or the cursor in query.After;
+// events committed later follow in commit order. The next batch is read only
+// after send returns, so a slow consumer holds back the database reads
+// instead of events piling up in memory.
+//
+// When wake is non-nil the stream keeps running after the replay: it checks
+// for new events whenever wake fires (and at the configured poll interval, in
+// case a notification is missed) until ctx is cancelled.
+func (s *QueryService) StreamEvents(
+	ctx context.Context,
+	query *types.EventQuery,
+	wake <-chan struct{},
+	send func([]*types.StreamEvent) error,
+) error {
+	pos, err := s.streamStart(ctx, query.After)
+	if err != nil {
+		return err
+	}
+	limit := s.streamBatchSize(query)
+
+	var poll <-chan time.Time
+	if wake != nil {
+		interval := s.config.StreamPollInterval
+		if interval <= 0 {
+			interval = defaultStreamPollInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		// Drain everything currently available
+		for {
+			events, err := s.queryBuilder.BuildEventStreamQuery(ctx, query, pos, limit)
+			if err != nil {
+				return fmt.Errorf("failed to read event stream: %w", err)
+			}
+			if len(events) > 0 {
+				if err := send(events); err != nil {
+					return err
+				}
+				pos = events[len(events)-1].Position
+			}
+			if int32(len(events)) == limit {
+				continue
+			}
+			if pos.ReplayBelow == 0 {
+				break
+			}
+			// The replay is done; follow from the transaction that bounded it
+			pos = types.StreamPosition{XID: pos.ReplayBelow}
+		}
+
+		if wake == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		case <-poll:
+		}
+	}
+}
+
+// streamStart resolves the position a stream starts from. Stream cursors
+// resume exactly where they were taken; event IDs and page cursors start a
+// new replay after that event's block position.
+func (s *QueryService) streamStart(ctx context.Context, after *string) (types.StreamPosition, error) {
+	pos, eventID, err := parseStreamCursor(after)
+	if err != nil {
+		return pos, err
+	}
+	if eventID > 0 {
+		pos, err = s.queryBuilder.BuildEventPositionQuery(ctx, eventID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return pos, fmt.Errorf("%w: event %d no longer exists", ErrInvalidCursor, eventID)
+		}
+		if err != nil {
+			return pos, fmt.Errorf("failed to resolve stream cursor: %w", err)
+		}
+	}
+	if pos.ReplayBelow == 0 && pos.XID == 0 {
+		if pos.ReplayBelow, err = s.queryBuilder.BuildStreamReplayBoundQuery(ctx); err != nil {
+			return pos, err
+		}
+	}
+	return pos, nil
+}
+
+// parseStreamCursor converts an optional cursor into a stream position. It
+// accepts the stream cursor of the last event received, a page cursor from an
+// event listing, or a bare event ID, which is returned as eventID for the
+// caller to look up. Positions other than stream cursors have no ReplayBelow.
+func parseStreamCursor(after *string) (pos types.StreamPosition, eventID int64, err error) {
+	if after == nil || *after == "" {
+		return types.StreamPosition{BlockNumber: -1}, 0, nil
+	}
+	if id, err := strconv.ParseInt(*after, 10, 64); err == nil {
+		if id <= 0 {
+			return pos, 0, fmt.Errorf("%w: %q", ErrInvalidCursor, *after)
+		}
+		return pos, id, nil
+	}
+	if pos, ok := decodeStreamCursor(*after); ok {
+		return pos, 0, nil
+	}
+	cursor, err := models.DecodeEventCursor(*after)
+	if err != nil {
+		return pos, 0, err
+	}
+	return types.StreamPosition{BlockNumber: cursor.BlockNumber, LogIndex: cursor.LogIndex, ID: cursor.ID}, 0, nil
+}
+
+// EncodeStreamCursor returns the opaque cursor that resumes a stream right
+// after the event at pos
+func EncodeStreamCursor(pos types.StreamPosition) string {
+	raw := fmt.Sprintf("%s:%d:%d:%d:%d:%d", streamCursorVersion, pos.ReplayBelow, pos.BlockNumber, pos.LogIndex, pos.XID, pos.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeStreamCursor parses a cursor produced by EncodeStreamCursor
+func decodeStreamCursor(s string) (types.StreamPosition, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return types.StreamPosition{}, false
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 6 || parts[0] != streamCursorVersion {
+		return types.StreamPosition{}, false
+	}
+
+	replayBelow, err1 := strconv.ParseUint(parts[1], 10, 64)
+	blockNumber, err2 := strconv.ParseInt(parts[2], 10, 64)
+	logIndex, err3 := strconv.Atoi(parts[3])
+	xid, err4 := strconv.ParseUint(parts[4], 10, 64)
+	id, err5 := strconv.ParseInt(parts[5], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil || logIndex < 0 || id < 0 {
+		return types.StreamPosition{}, false
+	}
+	// A cursor is either in the replay or past it
+	if (replayBelow == 0) == (xid == 0) {
+		return types.StreamPosition{}, false
+	}
+
+	return types.StreamPosition{ReplayBelow: replayBelow, BlockNumber: blockNumber, LogIndex: logIndex, XID: xid, ID: id}, true
+}
+
+// streamBatchSize returns how many events are read per stream batch
+func (s *QueryService) streamBatchSize(query *types.EventQuery) int32 {
+	size := int32(s.config.DefaultLimit)
+	if query.First != nil && *query.First > 0 {
+		size = *query.First
+	}
+	if s.config.MaxQueryLimit > 0 && size > int32(s.config.MaxQueryLimit) {
+		size = int32(s.config.MaxQueryLimit)
+	}
+	if size <= 0 {
+		size = 20
+	}
+	return size
+}
@@ -8,27 +8,40 @@ import (
 
 // EventQuery represents a query for events
 type EventQuery struct {
-	ContractAddress *string    `json:"contractAddress,omitempty"`
-	EventName       *string    `json:"eventName,omitempty"`
-	FromBlock       *int64     `json:"fromBlock,omitempty"`
-	ToBlock         *int64     `json:"toBlock,omitempty"`
-	FromDate        *time.Time `json:"fromDate,omitempty"`
-	ToDate          *time.Time `json:"toDate,omitempty"`
-	Addresses       []string   `json:"addresses,omitempty"`
-	TransactionHash *string    `json:"transactionHash,omitempty"`
-	First           *int32     `json:"first,omitempty"`
-	After           *string    `json:"after,omitempty"`
-	Before          *string    `json:"before,omitempty"`
-	Last            *int32     `json:"last,omitempty"`
-	Limit           int32      `json:"limit"`
-	Offset          int32      `json:"offset"`
-	OrderBy         string     `json:"orderBy"`
-	OrderDirection  string     `json:"orderDirection"`
+	ChainID         *int64                `json:"chainId,omitempty"`
+	ContractAddress *string               `json:"contractAddress,omitempty"`
+	EventName       *string               `json:"eventName,omitempty"`
+	EventSignature  *string               `json:"eventSignature,omitempty"` // one overload, e.g. Transfer(address,address,uint256)
+	FromBlock       *int64                `json:"fromBlock,omitempty"`
+	ToBlock         *int64                `json:"toBlock,omitempty"`
+	FromDate        *time.Time            `json:"fromDate,omitempty"`
+	ToDate          *time.Time            `json:"toDate,omitempty"`
+	Addresses       []string              `json:"addresses,omitempty"`
+	AddressRole     *string               `json:"addressRole,omitempty"`   // only match Addresses held by this argument, e.g. to
+	ArgPredicates   []models.ArgPredicate `json:"argPredicates,omitempty"` // conditions on decoded arguments; all must hold
+	TransactionHash *string               `json:"transactionHash,omitempty"`
+	Finality        *string               `json:"finality,omitempty"` // "pending" or "confirmed"; nil matches both
+	First           *int32                `json:"first,omitempty"`
+	After           *string               `json:"after,omitempty"`
+	Before          *string               `json:"before,omitempty"`
+	Last            *int32                `json:"last,omitempty"`
+	Limit           int32                 `json:"limit"`
+	Offset          int32                 `json:"offset"`
+	OrderBy         string                `json:"orderBy"`
+	OrderDirection  string                `json:"orderDirection"`
+}
+
+// IsBackward reports whether the query pages backwards, taking the Last
+// events before its Before cursor
+func (q *EventQuery) IsBackward() bool {
+	return isBackward(q.First, q.Last)
 }
 
 // AddressQuery represents a query for events by address
 type AddressQuery struct {
+	ChainID         *int64     `json:"chainId,omitempty"`
 	Address         string     `json:"address"`
+	Role            *string    `json:"role,omitempty"` // only match the address in this argument, e.g. to
 	ContractAddress *string    `json:"contractAddress,omitempty"`
 	EventName       *string    `json:"eventName,omitempty"`
 	FromBlock       *int64     `json:"fromBlock,omitempty"`
@@ -45,13 +58,27 @@ type AddressQuery struct {
 	OrderDirection  string     `json:"orderDirection"`
 }
 
+// IsBackward reports whether the query pages backwards, taking the Last
+// events before its Before cursor
+func (q *AddressQuery) IsBackward() bool {
+	return isBackward(q.First, q.Last)
+}
+
+// isBackward applies the Relay rule: first wins when both limits are given
+func isBackward(first, last *int32) bool {
+	return (first == nil || *first <= 0) && last != nil && *last > 0
+}
+
 // TransactionQuery represents a query for events by transaction
 type TransactionQuery struct {
+	ChainID         *int64 `json:"chainId,omitempty"`
 	TransactionHash string `json:"transactionHash"`
 }
 
-// StatsQuery represents a query for contract statistics
+// StatsQuery represents a query for contract statistics. A zero ChainID
+// means the default chain.
 type StatsQuery struct {
+	ChainID         int64  `json:"chainId"`
 	ContractAddress string `json:"contractAddress"`
 }
 
@@ -62,16 +89,35 @@ type EventResponse struct {
 	PageInfo   *PageInfo       `json:"pageInfo"`
 }
 
+// StreamPosition is where an event stream resumes. A stream first replays the
+// events made visible by transactions older than ReplayBelow in block order,
+// then follows newer events in commit order; ReplayBelow is 0 once the replay
+// is done.
+type StreamPosition struct {
+	ReplayBelow uint64 // transaction ID bounding the replay
+	BlockNumber int64  // replay: block of the last event sent, -1 before the first
+	LogIndex    int
+	XID         uint64 // follow: transaction that inserted or promoted the last event sent
+	ID          int64
+}
+
+// StreamEvent is an event read by a stream with the position right after it
+type StreamEvent struct {
+	Event    *models.Event
+	Position StreamPosition
+}
+
 // PageInfo represents pagination information
 type PageInfo struct {
-	HasNextPage     bool   `json:"hasNextPage"`
-	HasPreviousPage bool   `json:"hasPreviousPage"`
-	StartCursor     *int64 `json:"startCursor,omitempty"`
-	EndCursor       *int64 `json:"endCursor,omitempty"`
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
 }
 
 // StatsResponse represents the response for statistics queries
 type StatsResponse struct {
+	ChainID         int64     `json:"chainId"`
 	ContractAddress string    `json:"contractAddress"`
 	TotalEvents     int64     `json:"totalEvents"`
 	LatestBlock     int64     `json:"latestBlock"`
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultChainID is the chain assumed when a request or record does not specify one
+const DefaultChainID int64 = 1
+
+// ChainConfig describes a blockchain network the indexer can follow
+type ChainConfig struct {
+	ID            int64         `yaml:"id"`
+	Name          string        `yaml:"name"`
+	RPCEndpoints  []string      `yaml:"rpc_endpoints"` // first entry is the primary, the rest are fallbacks
+	BlockTime     time.Duration `yaml:"block_time"`
+	ConfirmBlocks int           `yaml:"confirm_blocks"` // default for contracts added without an explicit value
+}
+
+// Validate validates a single chain entry
+func (c *ChainConfig) Validate() error {
+	if c.ID <= 0 {
+		return fmt.Errorf("chain id must be positive")
+	}
+	if len(c.RPCEndpoints) == 0 || c.RPCEndpoints[0] == "" {
+		return fmt.Errorf("chain %d: at least one RPC endpoint is required", c.ID)
+	}
+	if c.BlockTime <= 0 {
+		return fmt.Errorf("chain %d: block time must be positive", c.ID)
+	}
+	if c.ConfirmBlocks < 1 || c.ConfirmBlocks > 100 {
+		return fmt.Errorf("chain %d: confirm blocks must be between 1 and 100", c.ID)
+	}
+	return nil
+}
+
+// PrimaryEndpoint returns the preferred RPC endpoint for the chain
+func (c *ChainConfig) PrimaryEndpoint() string {
+	return c.RPCEndpoints[0]
+}
+
+// FallbackEndpoints returns the RPC endpoints to use when the primary fails
+func (c *ChainConfig) FallbackEndpoints() []string {
+	return c.RPCEndpoints[1:]
+}
+
+// ChainRegistry holds the configured chains keyed by chain ID
+type ChainRegistry struct {
+	chains map[int64]*ChainConfig
+}
+
+// NewChainRegistry validates the given chains and builds a registry
+func NewChainRegistry(chains []ChainConfig) (*ChainRegistry, error) {
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("at least one chain must be configured")
+	}
+
+	registry := &ChainRegistry{chains: make(map[int64]*ChainConfig, len(chains))}
+	for i := range chains {
+		chain := chains[i]
+		if err := chain.Validate(); err != nil {
+			return nil, err
+		}
+		if _, exists := registry.chains[chain.ID]; exists {
+			return nil, fmt.Errorf("chain %d is configured more than once", chain.ID)
+		}
+		if chain.Name == "" {
+			chain.Name = fmt.Sprintf("chain-%d", chain.ID)
+		}
+		registry.chains[chain.ID] = &chain
+	}
+
+	return registry, nil
+}
+
+// LoadChainRegistry reads a YAML chain registry file of the form:
+//
+//	chains:
+//	  - id: 1
+//	    name: mainnet
+//	    rpc_endpoints: ["https://eth.example", "https://eth-backup.example"]
+//	    block_time: 12s
+//	    confirm_blocks: 12
+func LoadChainRegistry(path string) (*ChainRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain registry: %w", err)
+	}
+
+	var file struct {
+		Chains []ChainConfig `yaml:"chains"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse chain registry: %w", err)
+	}
+
+	return NewChainRegistry(file.Chains)
+}
+
+// Get returns the chain with the given ID
+func (r *ChainRegistry) Get(chainID int64) (*ChainConfig, bool) {
+	chain, ok := r.chains[chainID]
+	return chain, ok
+}
+
+// Has reports whether the chain ID is configured
+func (r *ChainRegistry) Has(chainID int64) bool {
+	_, ok := r.chains[chainID]
+	return ok
+}
+
+// Chains returns all configured chains ordered by chain ID
+func (r *ChainRegistry) Chains() []*ChainConfig {
+	chains := make([]*ChainConfig, 0, len(r.chains))
+	for _, chain := range r.chains {
+		chains = append(chains, chain)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ID < chains[j].ID })
+	return chains
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// ABIVersion is an ABI that replaces a contract's earlier ABIs from FromBlock
+// onwards, for upgradeable proxies whose event set changes with the
+// implementation. The contract's own ABI applies before its first version.
+// A version with an Implementation and no FromBlock is pending: it takes
+// effect at the proxy's Upgraded(address) event to that implementation.
+type ABIVersion struct {
+	ID              int64     `db:"id" json:"id"`
+	ChainID         int64     `db:"chain_id" json:"chainId"`
+	ContractAddress Address   `db:"contract_address" json:"contractAddress"`
+	ABI             string    `db:"abi" json:"abi"`
+	FromBlock       *int64    `db:"from_block" json:"fromBlock,omitempty"`          // nil while pending
+	Implementation  *Address  `db:"implementation" json:"implementation,omitempty"` // set for versions activated by Upgraded
+	CreatedAt       time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// IsPending reports whether the version still waits for its Upgraded event
+func (v *ABIVersion) IsPending() bool {
+	return v.FromBlock == nil
+}
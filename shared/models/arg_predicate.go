@@ -0,0 +1,223 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ArgOp is the comparison an ArgPredicate applies to an event argument
+type ArgOp string
+
+const (
+	// ArgOpEq matches arguments equal to the value
+	ArgOpEq ArgOp = "eq"
+	// ArgOpGt matches integer arguments greater than the value
+	ArgOpGt ArgOp = "gt"
+	// ArgOpGte matches integer arguments greater than or equal to the value
+	ArgOpGte ArgOp = "gte"
+	// ArgOpLt matches integer arguments less than the value
+	ArgOpLt ArgOp = "lt"
+	// ArgOpLte matches integer arguments less than or equal to the value
+	ArgOpLte ArgOp = "lte"
+	// ArgOpIn matches arguments equal to any of the values
+	ArgOpIn ArgOp = "in"
+)
+
+// MaxArgPredicateValues bounds the values of an IN predicate
+const MaxArgPredicateValues = 100
+
+// IsValid checks if the operator is known
+func (op ArgOp) IsValid() bool {
+	switch op {
+	case ArgOpEq, ArgOpGt, ArgOpGte, ArgOpLt, ArgOpLte, ArgOpIn:
+		return true
+	}
+	return false
+}
+
+// IsNumeric reports whether the operator compares integers
+func (op ArgOp) IsNumeric() bool {
+	return op == ArgOpGt || op == ArgOpGte || op == ArgOpLt || op == ArgOpLte
+}
+
+// ArgPredicate is a condition on a decoded event argument. Equality compares
+// the argument as it is rendered by FormatArgValue, so bool arguments match
+// true or false and integers their decimal digits; addresses are compared
+// case-insensitively. Numeric comparisons take a decimal or 0x-prefixed
+// integer and compare with full 256-bit precision.
+type ArgPredicate struct {
+	Name   string   `json:"name"`
+	Op     ArgOp    `json:"op"`
+	Value  string   `json:"value,omitempty"`
+	Values []string `json:"values,omitempty"` // IN only
+}
+
+// ArgPredicates is a list of predicates that must all hold. It is stored as a
+// JSONB array, as in webhooks.arg_filters.
+type ArgPredicates []ArgPredicate
+
+// Value implements the driver.Valuer interface for ArgPredicates
+func (p ArgPredicates) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan implements the sql.Scanner interface for ArgPredicates
+func (p *ArgPredicates) Scan(value interface{}) error {
+	if value == nil {
+		*p = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan ArgPredicates: expected []byte, got %T", value)
+	}
+
+	return json.Unmarshal(bytes, p)
+}
+
+// Matches reports whether the event's args satisfy every predicate
+func (p ArgPredicates) Matches(event *Event) bool {
+	for _, predicate := range p {
+		if !predicate.Matches(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseArgPredicate parses a predicate written as name:op:value, e.g.
+// value:gt:1000000000000000000000000 or to:in:0xabc...,0xdef...; IN values
+// are separated by commas
+func ParseArgPredicate(s string) (ArgPredicate, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) != 3 {
+		return ArgPredicate{}, fmt.Errorf("%w: argument predicate %q is not name:op:value", ErrInvalidEventFilter, s)
+	}
+	predicate := ArgPredicate{Name: parts[0], Op: ArgOp(strings.ToLower(parts[1]))}
+	if predicate.Op == ArgOpIn {
+		predicate.Values = strings.Split(parts[2], ",")
+	} else {
+		predicate.Value = parts[2]
+	}
+	return predicate, predicate.Validate()
+}
+
+// Validate checks that the predicate names an argument, uses a known
+// operator and carries the values the operator needs
+func (p ArgPredicate) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("%w: argument predicate without a name", ErrInvalidEventFilter)
+	}
+	if !p.Op.IsValid() {
+		return fmt.Errorf("%w: unknown operator %q for argument %s", ErrInvalidEventFilter, p.Op, p.Name)
+	}
+	if p.Op == ArgOpIn {
+		if len(p.Values) == 0 || len(p.Values) > MaxArgPredicateValues {
+			return fmt.Errorf("%w: IN on argument %s takes 1 to %d values", ErrInvalidEventFilter, p.Name, MaxArgPredicateValues)
+		}
+		return nil
+	}
+	if p.Op.IsNumeric() {
+		if _, ok := ParseArgInt(p.Value); !ok {
+			return fmt.Errorf("%w: %s on argument %s needs an integer, got %q", ErrInvalidEventFilter, p.Op, p.Name, p.Value)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the event's args satisfy the predicate. It mirrors
+// the SQL the query service compiles the predicate into.
+func (p ArgPredicate) Matches(event *Event) bool {
+	switch {
+	case p.Op == ArgOpEq:
+		return argEquals(event, p.Name, p.Value)
+	case p.Op == ArgOpIn:
+		for _, value := range p.Values {
+			if argEquals(event, p.Name, value) {
+				return true
+			}
+		}
+		return false
+	case p.Op.IsNumeric():
+		bound, ok := ParseArgInt(p.Value)
+		if !ok {
+			return false
+		}
+		text := FormatArgValue(event.Args[p.Name])
+		if !isDecimalNumber(text) {
+			return false
+		}
+		value, ok := new(big.Rat).SetString(text)
+		if !ok {
+			return false
+		}
+		cmp := value.Cmp(new(big.Rat).SetInt(bound))
+		switch p.Op {
+		case ArgOpGt:
+			return cmp > 0
+		case ArgOpGte:
+			return cmp >= 0
+		case ArgOpLt:
+			return cmp < 0
+		default:
+			return cmp <= 0
+		}
+	}
+	return false
+}
+
+// argEquals compares an argument with a predicate value. Addresses are looked
+// up among the event's address arguments so the comparison ignores case, as
+// the event_addresses table does.
+func argEquals(event *Event, name, value string) bool {
+	if IsArgAddress(value) {
+		for _, held := range event.AddressArgs() {
+			if held.Role == name && strings.EqualFold(string(held.Address), value) {
+				return true
+			}
+		}
+		return false
+	}
+	arg, ok := event.Args[name]
+	return ok && FormatArgValue(arg) == value
+}
+
+// isDecimalNumber reports whether an argument is written in decimal digits
+// with an optional sign and fraction, the only values numeric comparisons
+// apply to
+func isDecimalNumber(value string) bool {
+	whole, fraction, hasFraction := strings.Cut(strings.TrimPrefix(value, "-"), ".")
+	return isDigits(whole) && (!hasFraction || isDigits(fraction))
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsArgAddress reports whether a value is a 0x-prefixed address
+func IsArgAddress(value string) bool {
+	return len(value) == 42 && Address(value).Validate() == nil
+}
+
+// ParseArgInt parses a decimal or 0x-prefixed integer bound
+func ParseArgInt(value string) (*big.Int, bool) {
+	if hex := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X"); hex != value {
+		return new(big.Int).SetString(hex, 16)
+	}
+	return new(big.Int).SetString(value, 10)
+}
@@ -6,19 +6,26 @@ import (
 
 // Contract represents a smart contract being monitored
 type Contract struct {
-	ID            int64     `db:"id" json:"id"`
-	Address       Address   `db:"address" json:"address"`
-	ABI           string    `db:"abi" json:"abi"`
-	Name          string    `db:"name" json:"name"`
-	StartBlock    int64     `db:"start_block" json:"startBlock"`
-	CurrentBlock  int64     `db:"current_block" json:"currentBlock"`
-	ConfirmBlocks int       `db:"confirm_blocks" json:"confirmBlocks"` // Number of blocks to wait for confirmation
-	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
-	UpdatedAt     time.Time `db:"updated_at" json:"updatedAt"`
+	ID                    int64          `db:"id" json:"id"`
+	ChainID               int64          `db:"chain_id" json:"chainId"`
+	Address               Address        `db:"address" json:"address"`
+	ABI                   string         `db:"abi" json:"abi"`
+	Name                  string         `db:"name" json:"name"`
+	StartBlock            int64          `db:"start_block" json:"startBlock"`
+	CurrentBlock          int64          `db:"current_block" json:"currentBlock"`
+	ConfirmBlocks         int            `db:"confirm_blocks" json:"confirmBlocks"`                  // Number of blocks to wait for confirmation
+	IsActive              bool           `db:"is_active" json:"isActive"`                            // false while indexing is paused
+	Filter                ContractFilter `db:"event_filter" json:"filter"`                           // empty indexes every event
+	DecodeAnonymousEvents bool           `db:"decode_anonymous_events" json:"decodeAnonymousEvents"` // match logs without a known topic0 against the ABI's anonymous events
+	CreatedAt             time.Time      `db:"created_at" json:"createdAt"`
+	UpdatedAt             time.Time      `db:"updated_at" json:"updatedAt"`
 }
 
 // Validate checks if the contract data is valid
 func (c *Contract) Validate() error {
+	if c.ChainID <= 0 {
+		return ErrInvalidChainID
+	}
 	if err := c.Address.Validate(); err != nil {
 		return err
 	}
@@ -44,12 +51,23 @@ func (c *Contract) IsConfirmed(blockNumber, latestBlock int64) bool {
 
 // AddContractInput represents input for adding a new contract
 type AddContractInput struct {
-	Address       Address              `json:"address"`
-	ABI           string               `json:"abi"`
-	Name          string               `json:"name"`
-	StartBlock    int64                `json:"startBlock"`
-	ConfirmBlocks *int                 `json:"confirmBlocks,omitempty"` // Optional, defaults to 6
-	Strategy      ConfirmationStrategy `json:"strategy,omitempty"`      // Optional, overrides confirmBlocks
+	ChainID               *int64               `json:"chainId,omitempty"` // Optional, defaults to the service's default chain
+	Address               Address              `json:"address"`
+	ABI                   string               `json:"abi"`
+	Name                  string               `json:"name"`
+	StartBlock            int64                `json:"startBlock"`
+	ConfirmBlocks         *int                 `json:"confirmBlocks,omitempty"`         // Optional, defaults to 6
+	Strategy              ConfirmationStrategy `json:"strategy,omitempty"`              // Optional, overrides confirmBlocks
+	Filter                ContractFilter       `json:"filter,omitempty"`                // Optional, indexes every event when empty
+	DecodeAnonymousEvents bool                 `json:"decodeAnonymousEvents,omitempty"` // Optional, decodes anonymous events by matching them against the ABI
+}
+
+// GetChainID returns the requested chain, or 0 to let the service apply its default
+func (i *AddContractInput) GetChainID() int64 {
+	if i.ChainID != nil {
+		return *i.ChainID
+	}
+	return 0
 }
 
 // GetConfirmBlocks returns the confirmation blocks based on strategy or explicit value
@@ -62,4 +80,3 @@ func (i *AddContractInput) GetConfirmBlocks() int {
 	}
 	return 6 // default balanced strategy
 }
-
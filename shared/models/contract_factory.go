@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// ContractFactory is a rule that registers the contracts a factory creates.
+// Whenever the factory emits EventName from FromBlock on, the address held in
+// its AddressArg argument is added as a contract decoded with ChildABI and
+// indexed from the block of that event.
+type ContractFactory struct {
+	ID              int64     `db:"id" json:"id"`
+	ChainID         int64     `db:"chain_id" json:"chainId"`
+	ContractAddress Address   `db:"contract_address" json:"contractAddress"`
+	EventName       string    `db:"event_name" json:"eventName"`
+	AddressArg      string    `db:"address_arg" json:"addressArg"`
+	ChildABI        string    `db:"child_abi" json:"childAbi"`
+	ChildName       string    `db:"child_name" json:"childName"`
+	FromBlock       int64     `db:"from_block" json:"fromBlock"`
+	Children        int64     `db:"children" json:"children"` // contracts registered so far
+	CreatedAt       time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// ChildAddress returns the address of the contract created by event, or false
+// if the event is not one of the rule's creation events
+func (f *ContractFactory) ChildAddress(event *Event) (Address, bool) {
+	if event.EventName != f.EventName || event.BlockNumber < f.FromBlock {
+		return "", false
+	}
+	value, ok := event.Args[f.AddressArg].(string)
+	if !ok {
+		return "", false
+	}
+	address := Address(value)
+	if address.Validate() != nil {
+		return "", false
+	}
+	return address.Normalize(), true
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidContractFilter is returned for a malformed contract event filter
+var ErrInvalidContractFilter = errors.New("invalid contract filter")
+
+// ContractFilter restricts which of a contract's logs are fetched and stored.
+// It is applied to the eth_getLogs queries, so filtered-out logs are never
+// fetched. The zero value indexes every event in the ABI.
+//
+// A changed filter applies to blocks indexed after the change. Events already
+// stored are kept; run a backfill over older blocks to pick up events a wider
+// filter now allows.
+type ContractFilter struct {
+	// Events lists the ABI event names to index; empty indexes every event
+	Events []string `json:"events,omitempty"`
+	// Topics lists alternative conditions on the indexed arguments; a log is
+	// indexed if it matches any of them. Empty places no condition.
+	Topics []TopicFilter `json:"topics,omitempty"`
+}
+
+// TopicFilter gives the accepted values of a log's indexed arguments by
+// position: Topic1 is the first indexed argument. A log must match every
+// non-empty position, and values within a position are alternatives.
+// Addresses are accepted and stored as 32-byte topics.
+type TopicFilter struct {
+	Topic1 []string `json:"topic1,omitempty"`
+	Topic2 []string `json:"topic2,omitempty"`
+	Topic3 []string `json:"topic3,omitempty"`
+}
+
+// Positions returns the accepted values of topic1 to topic3 in order
+func (t TopicFilter) Positions() [][]string {
+	return [][]string{t.Topic1, t.Topic2, t.Topic3}
+}
+
+// IsEmpty reports whether the topic filter accepts every log
+func (t TopicFilter) IsEmpty() bool {
+	return len(t.Topic1) == 0 && len(t.Topic2) == 0 && len(t.Topic3) == 0
+}
+
+// IsEmpty reports whether the filter indexes every event
+func (f ContractFilter) IsEmpty() bool {
+	return len(f.Events) == 0 && len(f.Topics) == 0
+}
+
+// Normalize validates the filter and returns it with topic values in their
+// canonical 32-byte lowercase hex form and duplicates removed. Topic filters
+// without any condition are dropped, since they would accept every log.
+func (f ContractFilter) Normalize() (ContractFilter, error) {
+	var normalized ContractFilter
+
+	seen := make(map[string]bool)
+	for _, name := range f.Events {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return ContractFilter{}, fmt.Errorf("%w: event names cannot be empty", ErrInvalidContractFilter)
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized.Events = append(normalized.Events, name)
+		}
+	}
+
+	for _, topics := range f.Topics {
+		var filter TopicFilter
+		positions := []*[]string{&filter.Topic1, &filter.Topic2, &filter.Topic3}
+		for k, values := range topics.Positions() {
+			for _, value := range values {
+				topic, err := NormalizeTopic(value)
+				if err != nil {
+					return ContractFilter{}, err
+				}
+				if !containsString(*positions[k], topic) {
+					*positions[k] = append(*positions[k], topic)
+				}
+			}
+		}
+		if filter.IsEmpty() {
+			// An unconstrained alternative accepts every log, which makes
+			// the other alternatives pointless
+			normalized.Topics = nil
+			return normalized, nil
+		}
+		normalized.Topics = append(normalized.Topics, filter)
+	}
+
+	return normalized, nil
+}
+
+// NormalizeTopic converts a 32-byte topic or a 20-byte address to the
+// 0x-prefixed lowercase 32-byte topic form
+func NormalizeTopic(value string) (string, error) {
+	raw := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(value), "0x"), "0X")
+	if _, err := hex.DecodeString(raw); err != nil || (len(raw) != 40 && len(raw) != 64) {
+		return "", fmt.Errorf("%w: topic %q must be a 32-byte hex value or an address", ErrInvalidContractFilter, value)
+	}
+	return "0x" + strings.Repeat("0", 64-len(raw)) + strings.ToLower(raw), nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Value implements the driver.Valuer interface. An empty filter is stored as NULL.
+func (f ContractFilter) Value() (driver.Value, error) {
+	if f.IsEmpty() {
+		return nil, nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements the sql.Scanner interface
+func (f *ContractFilter) Scan(value interface{}) error {
+	*f = ContractFilter{}
+	if value == nil {
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan ContractFilter: expected []byte, got %T", value)
+	}
+
+	return json.Unmarshal(bytes, f)
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// eventCursorVersion prefixes encoded cursors so the format can change later
+const eventCursorVersion = "e1"
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EventCursor is an event's position in the order event listings use: newest
+// block first, then log index, with the event ID breaking ties between chains.
+// Clients only ever see it encoded, as an opaque string.
+type EventCursor struct {
+	BlockNumber int64
+	LogIndex    int
+	ID          int64
+}
+
+// CursorForEvent returns the cursor pointing at an event
+func CursorForEvent(event *Event) EventCursor {
+	return EventCursor{
+		BlockNumber: event.BlockNumber,
+		LogIndex:    event.LogIndex,
+		ID:          event.ID,
+	}
+}
+
+// Encode returns the opaque string form of the cursor
+func (c EventCursor) Encode() string {
+	raw := fmt.Sprintf("%s:%d:%d:%d", eventCursorVersion, c.BlockNumber, c.LogIndex, c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeEventCursor parses a cursor produced by EventCursor.Encode
+func DecodeEventCursor(s string) (EventCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return EventCursor{}, fmt.Errorf("%w: %q", ErrInvalidCursor, s)
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 4 || parts[0] != eventCursorVersion {
+		return EventCursor{}, fmt.Errorf("%w: %q", ErrInvalidCursor, s)
+	}
+
+	blockNumber, err1 := strconv.ParseInt(parts[1], 10, 64)
+	logIndex, err2 := strconv.Atoi(parts[2])
+	id, err3 := strconv.ParseInt(parts[3], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || blockNumber < 0 || logIndex < 0 || id < 0 {
+		return EventCursor{}, fmt.Errorf("%w: %q", ErrInvalidCursor, s)
+	}
+
+	return EventCursor{BlockNumber: blockNumber, LogIndex: logIndex, ID: id}, nil
+}
@@ -7,6 +7,7 @@ var (
 	ErrContractNotFound       = errors.New("contract not found")
 	ErrContractAlreadyExists  = errors.New("contract already exists")
 	ErrInvalidContractAddress = errors.New("invalid contract address")
+	ErrInvalidChainID         = errors.New("invalid chain id")
 	ErrInvalidContractName    = errors.New("invalid contract name")
 	ErrInvalidContractABI     = errors.New("invalid contract ABI")
 	ErrInvalidBlockNumber     = errors.New("invalid block number")
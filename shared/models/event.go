@@ -1,26 +1,47 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
+// Finality describes whether an event's block has enough confirmations
+type Finality string
+
+const (
+	// FinalityPending marks events indexed at the chain head that may still be reorged out
+	FinalityPending Finality = "pending"
+	// FinalityConfirmed marks events whose block has the contract's required confirmations
+	FinalityConfirmed Finality = "confirmed"
+)
+
+// IsValid checks if the finality value is known
+func (f Finality) IsValid() bool {
+	return f == FinalityPending || f == FinalityConfirmed
+}
+
 // Event represents a blockchain event that has been indexed
 type Event struct {
 	ID               int64     `db:"id" json:"id"`
+	ChainID          int64     `db:"chain_id" json:"chainId"`
 	ContractAddress  Address   `db:"contract_address" json:"contractAddress"`
 	EventName        string    `db:"event_name" json:"eventName"`
+	EventSignature   string    `db:"event_signature" json:"eventSignature"` // e.g. Transfer(address,address,uint256); tells overloads apart
 	BlockNumber      int64     `db:"block_number" json:"blockNumber"`
 	BlockHash        Hash      `db:"block_hash" json:"blockHash"`
 	TransactionHash  Hash      `db:"transaction_hash" json:"transactionHash"`
 	TransactionIndex int       `db:"transaction_index" json:"transactionIndex"`
 	LogIndex         int       `db:"log_index" json:"logIndex"`
 	Args             JSONB     `db:"args" json:"args"`
+	ArgTypes         ArgTypes  `db:"arg_types" json:"argTypes,omitempty"` // ABI declaration of Args, in ABI order
 	RawLog           *string   `db:"raw_log" json:"rawLog,omitempty"`
 	Timestamp        time.Time `db:"timestamp" json:"timestamp"`
+	Finality         Finality  `db:"finality" json:"finality"`
+	SubscriptionID   *int64    `db:"subscription_id" json:"subscriptionId,omitempty"` // topic subscription indexing the event, stored in event_subscriptions
 	CreatedAt        time.Time `db:"created_at" json:"createdAt"`
 }
 
-// EventArg represents a single argument from an event
+// EventArg represents a single argument from an event, as returned to clients
 type EventArg struct {
 	Name    string      `json:"name"`
 	Type    string      `json:"type"`
@@ -30,13 +51,75 @@ type EventArg struct {
 
 // EventFilter represents filters for querying events
 type EventFilter struct {
-	ContractAddress *Address  `json:"contractAddress,omitempty"`
-	EventName       *string   `json:"eventName,omitempty"`
-	FromBlock       *int64    `json:"fromBlock,omitempty"`
-	ToBlock         *int64    `json:"toBlock,omitempty"`
-	TransactionHash *Hash     `json:"transactionHash,omitempty"`
-	Addresses       []Address `json:"addresses,omitempty"`
-	Address         *Address  `json:"address,omitempty"` // For filtering by address in args
+	ChainID         *int64         `json:"chainId,omitempty"`
+	ContractAddress *Address       `json:"contractAddress,omitempty"`
+	EventName       *string        `json:"eventName,omitempty"`
+	EventSignature  *string        `json:"eventSignature,omitempty"` // one overload of an event, e.g. Transfer(address,address,uint256)
+	FromBlock       *int64         `json:"fromBlock,omitempty"`
+	ToBlock         *int64         `json:"toBlock,omitempty"`
+	TransactionHash *Hash          `json:"transactionHash,omitempty"`
+	Addresses       []Address      `json:"addresses,omitempty"`
+	Address         *Address       `json:"address,omitempty"`     // For filtering by address in args
+	AddressRole     *string        `json:"addressRole,omitempty"` // only match Addresses held by this argument, e.g. to
+	Finality        *Finality      `json:"finality,omitempty"`    // nil matches pending and confirmed
+	Args            []ArgPredicate `json:"args,omitempty"`        // conditions on decoded arguments; all must hold
+}
+
+// Matches reports whether an event satisfies the filter. It mirrors the
+// query service's SQL filtering so live subscribers see the same events a
+// query with the same filter would return.
+func (f *EventFilter) Matches(event *Event) bool {
+	if f == nil {
+		return true
+	}
+	if f.ChainID != nil && *f.ChainID != event.ChainID {
+		return false
+	}
+	if f.ContractAddress != nil && !strings.EqualFold(string(*f.ContractAddress), string(event.ContractAddress)) {
+		return false
+	}
+	if f.EventName != nil && *f.EventName != event.EventName {
+		return false
+	}
+	if f.EventSignature != nil && *f.EventSignature != event.EventSignature {
+		return false
+	}
+	if f.FromBlock != nil && event.BlockNumber < *f.FromBlock {
+		return false
+	}
+	if f.ToBlock != nil && event.BlockNumber > *f.ToBlock {
+		return false
+	}
+	if f.TransactionHash != nil && !strings.EqualFold(string(*f.TransactionHash), string(event.TransactionHash)) {
+		return false
+	}
+	if f.Finality != nil && *f.Finality != event.Finality {
+		return false
+	}
+	for _, predicate := range f.Args {
+		if !predicate.Matches(event) {
+			return false
+		}
+	}
+
+	addresses := f.Addresses
+	if len(addresses) == 0 && f.Address != nil {
+		addresses = []Address{*f.Address}
+	}
+	if len(addresses) == 0 {
+		return true
+	}
+	for _, held := range event.AddressArgs() {
+		if f.AddressRole != nil && held.Role != *f.AddressRole {
+			continue
+		}
+		for _, addr := range addresses {
+			if strings.EqualFold(string(held.Address), string(addr)) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Pagination represents pagination parameters
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// EventAddress is one address held by an address-typed argument of an event,
+// as stored in the event_addresses table
+type EventAddress struct {
+	EventID int64   `db:"event_id" json:"eventId"`
+	Address Address `db:"address" json:"address"` // lower-case hex
+	Role    string  `db:"role" json:"role"`       // name of the argument holding the address, e.g. from or to
+}
+
+// AddressArgs returns the addresses held by the event's address-typed
+// arguments, indexed or not, including the elements of address arrays. Each
+// address is lower-cased and tagged with the name of its argument as role.
+// Events stored before types were recorded have no ArgTypes; their top-level
+// arguments holding an address are taken instead.
+func (e *Event) AddressArgs() []EventAddress {
+	seen := make(map[EventAddress]bool)
+	var addresses []EventAddress
+	add := func(role string, value interface{}) {
+		for _, addr := range collectAddresses(value) {
+			entry := EventAddress{EventID: e.ID, Address: addr, Role: role}
+			if !seen[entry] {
+				seen[entry] = true
+				addresses = append(addresses, entry)
+			}
+		}
+	}
+
+	if len(e.ArgTypes) > 0 {
+		for _, argType := range e.ArgTypes {
+			if isAddressType(argType.Type) {
+				add(argType.Name, e.Args[argType.Name])
+			}
+		}
+		return addresses
+	}
+
+	names := make([]string, 0, len(e.Args))
+	for name, value := range e.Args {
+		if _, ok := value.(string); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(name, e.Args[name])
+	}
+	return addresses
+}
+
+// isAddressType reports whether a Solidity type is address or an array of
+// addresses of any dimension, e.g. address[] or address[2][]
+func isAddressType(typ string) bool {
+	for strings.HasSuffix(typ, "]") {
+		open := strings.LastIndex(typ, "[")
+		if open < 0 {
+			return false
+		}
+		typ = typ[:open]
+	}
+	return typ == "address"
+}
+
+// collectAddresses returns the lower-cased addresses in a decoded address or
+// address array value, skipping anything that is not an address
+func collectAddresses(value interface{}) []Address {
+	switch v := value.(type) {
+	case string:
+		// 0x-prefixed only, so bytes20 values decoded as bare hex are skipped
+		if !IsArgAddress(v) {
+			return nil
+		}
+		return []Address{Address(strings.ToLower(v))}
+	case []interface{}:
+		var addresses []Address
+		for _, item := range v {
+			addresses = append(addresses, collectAddresses(item)...)
+		}
+		return addresses
+	default:
+		return nil
+	}
+}
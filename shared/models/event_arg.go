@@ -0,0 +1,104 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ArgType is the ABI declaration of one decoded event argument
+type ArgType struct {
+	Name    string `json:"name"`    // key of the argument in Event.Args
+	Type    string `json:"type"`    // canonical Solidity type, e.g. uint256 or (address,uint256)[]
+	Indexed bool   `json:"indexed"` // stored in a topic; dynamic types then hold only their hash
+}
+
+// ArgTypes lists the arguments of an event in ABI order. It is stored as a
+// JSONB array next to the args.
+type ArgTypes []ArgType
+
+// Value implements the driver.Valuer interface for ArgTypes
+func (a ArgTypes) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements the sql.Scanner interface for ArgTypes
+func (a *ArgTypes) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan ArgTypes: expected []byte, got %T", value)
+	}
+
+	return json.Unmarshal(bytes, a)
+}
+
+// OrderedArgs returns the event's arguments in ABI order with their Solidity
+// types. Events stored before types were recorded have no ArgTypes; their
+// arguments are returned by name with an empty type.
+func (e *Event) OrderedArgs() []EventArg {
+	args := make([]EventArg, 0, len(e.Args))
+	seen := make(map[string]bool, len(e.ArgTypes))
+	for _, argType := range e.ArgTypes {
+		value, ok := e.Args[argType.Name]
+		if !ok || seen[argType.Name] {
+			continue
+		}
+		seen[argType.Name] = true
+		args = append(args, EventArg{
+			Name:    argType.Name,
+			Type:    argType.Type,
+			Value:   value,
+			Indexed: argType.Indexed,
+		})
+	}
+
+	var untyped []string
+	for name := range e.Args {
+		if !seen[name] {
+			untyped = append(untyped, name)
+		}
+	}
+	sort.Strings(untyped)
+	for _, name := range untyped {
+		args = append(args, EventArg{Name: name, Value: e.Args[name]})
+	}
+
+	return args
+}
+
+// FormatArgValue renders a decoded argument value as a string without losing
+// precision. Integers are stored as decimal strings and pass through as they
+// are; arrays and tuples are rendered as JSON.
+func FormatArgValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		// Numbers decoded before integers were stored as strings
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case []interface{}, map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -7,7 +7,9 @@ import (
 // IndexerState represents the current state of the indexer for a contract
 type IndexerState struct {
 	ID               int64     `db:"id" json:"id"`
+	ChainID          int64     `db:"chain_id" json:"chainId"`
 	ContractAddress  Address   `db:"contract_address" json:"contractAddress"`
+	SubscriptionID   *int64    `db:"subscription_id" json:"subscriptionId,omitempty"` // set instead of ContractAddress for topic subscriptions
 	LastIndexedBlock int64     `db:"last_indexed_block" json:"lastIndexedBlock"`
 	LastBlockHash    Hash      `db:"last_block_hash" json:"lastBlockHash"`
 	LastProcessedAt  time.Time `db:"last_processed_at" json:"lastProcessedAt"`
@@ -20,6 +22,7 @@ type IndexerState struct {
 
 // BlockCache represents cached block information for reorg detection
 type BlockCache struct {
+	ChainID     int64     `db:"chain_id" json:"chainId"`
 	BlockNumber int64     `db:"block_number" json:"blockNumber"`
 	BlockHash   Hash      `db:"block_hash" json:"blockHash"`
 	ParentHash  Hash      `db:"parent_hash" json:"parentHash"`
@@ -27,8 +30,21 @@ type BlockCache struct {
 	CachedAt    time.Time `db:"cached_at" json:"cachedAt"`
 }
 
+// ReorgRecord is an audit entry for a chain reorganization handled by the indexer
+type ReorgRecord struct {
+	ID                int64     `db:"id" json:"id"`
+	ChainID           int64     `db:"chain_id" json:"chainId"`
+	ForkPoint         int64     `db:"fork_point" json:"forkPoint"`              // first block replaced by the new branch
+	DetectedAtBlock   int64     `db:"detected_at_block" json:"detectedAtBlock"` // block whose parent did not match the cache
+	Depth             int64     `db:"depth" json:"depth"`                       // number of blocks rolled back
+	ContractsAffected int       `db:"contracts_affected" json:"contractsAffected"`
+	EventsRemoved     int64     `db:"events_removed" json:"eventsRemoved"`
+	DetectedAt        time.Time `db:"detected_at" json:"detectedAt"`
+}
+
 // ContractStats represents statistics for a contract
 type ContractStats struct {
+	ChainID         int64     `json:"chainId"`
 	ContractAddress Address   `json:"contractAddress"`
 	TotalEvents     int64     `json:"totalEvents"`
 	LatestBlock     int64     `json:"latestBlock"`
@@ -38,14 +54,36 @@ type ContractStats struct {
 	LastUpdated     time.Time `json:"lastUpdated"`
 }
 
+// Backfill job statuses, matching the backfill_jobs.status check constraint
+const (
+	BackfillStatusPending   = "pending"
+	BackfillStatusRunning   = "running"
+	BackfillStatusCompleted = "completed"
+	BackfillStatusFailed    = "failed"
+	BackfillStatusCancelled = "cancelled"
+)
+
+// Backfill job kinds, matching the backfill_jobs.kind check constraint. Fetch
+// jobs index logs from the chain; redecode jobs rewrite stored events from
+// their raw logs with the contract's current ABIs.
+const (
+	BackfillKindFetch    = "fetch"
+	BackfillKindRedecode = "redecode"
+)
+
 // BackfillJob represents a historical data backfill job
 type BackfillJob struct {
 	ID              string     `db:"id" json:"id"`
+	ChainID         int64      `db:"chain_id" json:"chainId"`
 	ContractAddress Address    `db:"contract_address" json:"contractAddress"`
 	FromBlock       int64      `db:"from_block" json:"fromBlock"`
 	ToBlock         int64      `db:"to_block" json:"toBlock"`
-	CurrentBlock    int64      `db:"current_block" json:"currentBlock"`
-	Status          string     `db:"status" json:"status"` // pending, running, completed, failed
+	CurrentBlock    int64      `db:"current_block" json:"currentBlock"` // last block fully processed
+	ChunkSize       int        `db:"chunk_size" json:"chunkSize"`
+	Kind            string     `db:"kind" json:"kind"`                    // fetch or redecode
+	EventsUpdated   int64      `db:"events_updated" json:"eventsUpdated"` // redecode: events whose name or args changed
+	EventsSkipped   int64      `db:"events_skipped" json:"eventsSkipped"` // redecode: events without a raw log or no longer decodable
+	Status          string     `db:"status" json:"status"`                // pending, running, completed, failed, cancelled
 	ErrorMessage    *string    `db:"error_message" json:"errorMessage,omitempty"`
 	CreatedAt       time.Time  `db:"created_at" json:"createdAt"`
 	UpdatedAt       time.Time  `db:"updated_at" json:"updatedAt"`
@@ -54,7 +92,10 @@ type BackfillJob struct {
 
 // Progress returns the progress percentage of the backfill job
 func (b *BackfillJob) Progress() float64 {
-	if b.ToBlock <= b.FromBlock {
+	if b.Status == BackfillStatusCompleted {
+		return 100
+	}
+	if b.ToBlock <= b.FromBlock || b.CurrentBlock < b.FromBlock {
 		return 0
 	}
 	total := float64(b.ToBlock - b.FromBlock)
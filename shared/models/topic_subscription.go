@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// TopicSubscription indexes one event signature from any emitter on a chain,
+// for example every ERC-20 Transfer. Logs are decoded with the subscription's
+// ABI fragment and stored as events of their emitting address.
+type TopicSubscription struct {
+	ID             int64     `db:"id" json:"id"`
+	ChainID        int64     `db:"chain_id" json:"chainId"`
+	Name           string    `db:"name" json:"name"`
+	ABI            string    `db:"abi" json:"abi"`
+	EventName      string    `db:"event_name" json:"eventName"`
+	EventSignature string    `db:"event_signature" json:"eventSignature"`
+	StartBlock     int64     `db:"start_block" json:"startBlock"`
+	ConfirmBlocks  int       `db:"confirm_blocks" json:"confirmBlocks"`
+	IsActive       bool      `db:"is_active" json:"isActive"`
+	CurrentBlock   int64     `db:"current_block" json:"currentBlock"` // last indexed block, from indexer_state
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
+}
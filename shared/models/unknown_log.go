@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// UnknownLog is a log of a monitored contract that could not be decoded into
+// an event. It keeps the raw log so it can be decoded again once the
+// contract's ABI is fixed.
+type UnknownLog struct {
+	ID               int64          `db:"id" json:"id"`
+	ChainID          int64          `db:"chain_id" json:"chainId"`
+	ContractAddress  Address        `db:"contract_address" json:"contractAddress"`
+	BlockNumber      int64          `db:"block_number" json:"blockNumber"`
+	BlockHash        Hash           `db:"block_hash" json:"blockHash"`
+	BlockTimestamp   time.Time      `db:"block_timestamp" json:"blockTimestamp"`
+	TransactionHash  Hash           `db:"transaction_hash" json:"transactionHash"`
+	TransactionIndex int            `db:"transaction_index" json:"transactionIndex"`
+	LogIndex         int            `db:"log_index" json:"logIndex"`
+	Topics           pq.StringArray `db:"topics" json:"topics"` // 0x-prefixed hex, topic0 first
+	Data             string         `db:"data" json:"data"`     // 0x-prefixed hex
+	Reason           string         `db:"reason" json:"reason"` // why the last decoding attempt failed
+	RetryRequestedAt *time.Time     `db:"retry_requested_at" json:"retryRequestedAt,omitempty"`
+	CreatedAt        time.Time      `db:"created_at" json:"createdAt"`
+	UpdatedAt        time.Time      `db:"updated_at" json:"updatedAt"`
+}
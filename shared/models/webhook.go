@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Webhook delivery statuses, matching the webhook_deliveries.status check constraint
+const (
+	WebhookDeliveryPending    = "pending"
+	WebhookDeliveryDelivered  = "delivered"
+	WebhookDeliveryDeadLetter = "dead_letter"
+	WebhookDeliveryCancelled  = "cancelled" // the event was removed by a reorg before delivery
+)
+
+// Webhook is a subscription that pushes matching events to an HTTP endpoint
+type Webhook struct {
+	ID              string        `db:"id" json:"id"`
+	ChainID         int64         `db:"chain_id" json:"chainId"`
+	URL             string        `db:"url" json:"url"`
+	Secret          string        `db:"secret" json:"-"` // HMAC key for payload signatures
+	Description     string        `db:"description" json:"description"`
+	ContractAddress *Address      `db:"contract_address" json:"contractAddress,omitempty"`
+	EventName       *string       `db:"event_name" json:"eventName,omitempty"`
+	ArgFilters      ArgPredicates `db:"arg_filters" json:"argFilters"` // conditions on the event's args; all must hold
+	IsActive        bool          `db:"is_active" json:"isActive"`
+	CreatedAt       time.Time     `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time     `db:"updated_at" json:"updatedAt"`
+}
+
+// Matches reports whether an event satisfies the webhook's filter. Argument
+// filters are evaluated like the argument predicates of event queries.
+func (w *Webhook) Matches(event *Event) bool {
+	if w.ChainID != event.ChainID {
+		return false
+	}
+	if w.ContractAddress != nil && !strings.EqualFold(string(*w.ContractAddress), string(event.ContractAddress)) {
+		return false
+	}
+	if w.EventName != nil && *w.EventName != event.EventName {
+		return false
+	}
+	return w.ArgFilters.Matches(event)
+}
+
+// WebhookDelivery is one event queued for, or delivered to, a webhook
+type WebhookDelivery struct {
+	ID             int64      `db:"id" json:"id"`
+	WebhookID      string     `db:"webhook_id" json:"webhookId"`
+	EventID        int64      `db:"event_id" json:"eventId"`
+	Payload        JSONB      `db:"payload" json:"payload"` // event snapshot taken when queued
+	Status         string     `db:"status" json:"status"`   // pending, delivered, dead_letter, cancelled
+	Attempts       int        `db:"attempts" json:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at" json:"nextAttemptAt"`
+	LastStatusCode *int       `db:"last_status_code" json:"lastStatusCode,omitempty"`
+	LastError      *string    `db:"last_error" json:"lastError,omitempty"`
+	CreatedAt      time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time  `db:"updated_at" json:"updatedAt"`
+	DeliveredAt    *time.Time `db:"delivered_at" json:"deliveredAt,omitempty"`
+}
@@ -0,0 +1,1057 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: admin_service.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	AdminService_AddContract_FullMethodName             = "/proto.AdminService/AddContract"
+	AdminService_RemoveContract_FullMethodName          = "/proto.AdminService/RemoveContract"
+	AdminService_SetContractActive_FullMethodName       = "/proto.AdminService/SetContractActive"
+	AdminService_UpdateContractFilter_FullMethodName    = "/proto.AdminService/UpdateContractFilter"
+	AdminService_AddContractABIVersion_FullMethodName   = "/proto.AdminService/AddContractABIVersion"
+	AdminService_ListContractABIVersions_FullMethodName = "/proto.AdminService/ListContractABIVersions"
+	AdminService_AddContractFactory_FullMethodName      = "/proto.AdminService/AddContractFactory"
+	AdminService_ListContractFactories_FullMethodName   = "/proto.AdminService/ListContractFactories"
+	AdminService_GetContract_FullMethodName             = "/proto.AdminService/GetContract"
+	AdminService_ListContracts_FullMethodName           = "/proto.AdminService/ListContracts"
+	AdminService_TriggerBackfill_FullMethodName         = "/proto.AdminService/TriggerBackfill"
+	AdminService_TriggerRedecode_FullMethodName         = "/proto.AdminService/TriggerRedecode"
+	AdminService_GetBackfillStatus_FullMethodName       = "/proto.AdminService/GetBackfillStatus"
+	AdminService_GetSystemStatus_FullMethodName         = "/proto.AdminService/GetSystemStatus"
+	AdminService_HealthCheck_FullMethodName             = "/proto.AdminService/HealthCheck"
+	AdminService_CreateWebhook_FullMethodName           = "/proto.AdminService/CreateWebhook"
+	AdminService_ListWebhooks_FullMethodName            = "/proto.AdminService/ListWebhooks"
+	AdminService_DeleteWebhook_FullMethodName           = "/proto.AdminService/DeleteWebhook"
+	AdminService_ListWebhookDeliveries_FullMethodName   = "/proto.AdminService/ListWebhookDeliveries"
+	AdminService_RetryWebhookDelivery_FullMethodName    = "/proto.AdminService/RetryWebhookDelivery"
+	AdminService_ListUnknownLogs_FullMethodName         = "/proto.AdminService/ListUnknownLogs"
+	AdminService_RetryUnknownLogs_FullMethodName        = "/proto.AdminService/RetryUnknownLogs"
+	AdminService_CreateTopicSubscription_FullMethodName = "/proto.AdminService/CreateTopicSubscription"
+	AdminService_ListTopicSubscriptions_FullMethodName  = "/proto.AdminService/ListTopicSubscriptions"
+	AdminService_DeleteTopicSubscription_FullMethodName = "/proto.AdminService/DeleteTopicSubscription"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// AddContract adds a new contract to monitor
+	AddContract(ctx context.Context, in *AddContractRequest, opts ...grpc.CallOption) (*AddContractResponse, error)
+	// RemoveContract removes a contract from monitoring
+	RemoveContract(ctx context.Context, in *RemoveContractRequest, opts ...grpc.CallOption) (*RemoveContractResponse, error)
+	// SetContractActive pauses or resumes indexing for a contract without
+	// deleting its events or indexing position
+	SetContractActive(ctx context.Context, in *SetContractActiveRequest, opts ...grpc.CallOption) (*SetContractActiveResponse, error)
+	// UpdateContractFilter replaces the event filter of a contract. The new
+	// filter applies to blocks indexed afterwards; stored events are kept.
+	UpdateContractFilter(ctx context.Context, in *UpdateContractFilterRequest, opts ...grpc.CallOption) (*UpdateContractFilterResponse, error)
+	// AddContractABIVersion attaches a new ABI to an upgradeable proxy, either
+	// from a given block or from the proxy's Upgraded event to an implementation
+	AddContractABIVersion(ctx context.Context, in *AddContractABIVersionRequest, opts ...grpc.CallOption) (*AddContractABIVersionResponse, error)
+	// ListContractABIVersions lists the ABI versions attached to a contract
+	ListContractABIVersions(ctx context.Context, in *ListContractABIVersionsRequest, opts ...grpc.CallOption) (*ListContractABIVersionsResponse, error)
+	// AddContractFactory registers a rule that adds the contracts a factory
+	// creates, as the factory's creation events are indexed
+	AddContractFactory(ctx context.Context, in *AddContractFactoryRequest, opts ...grpc.CallOption) (*AddContractFactoryResponse, error)
+	// ListContractFactories lists the factory rules of a contract
+	ListContractFactories(ctx context.Context, in *ListContractFactoriesRequest, opts ...grpc.CallOption) (*ListContractFactoriesResponse, error)
+	// GetContract retrieves contract information
+	GetContract(ctx context.Context, in *GetContractRequest, opts ...grpc.CallOption) (*Contract, error)
+	// ListContracts lists all monitored contracts
+	ListContracts(ctx context.Context, in *ListContractsRequest, opts ...grpc.CallOption) (*ListContractsResponse, error)
+	// TriggerBackfill triggers historical data backfill
+	TriggerBackfill(ctx context.Context, in *BackfillRequest, opts ...grpc.CallOption) (*BackfillResponse, error)
+	// TriggerRedecode queues a job that decodes a contract's stored events again
+	// from their raw logs with its current ABIs, without calling the RPC
+	TriggerRedecode(ctx context.Context, in *RedecodeRequest, opts ...grpc.CallOption) (*BackfillResponse, error)
+	// GetBackfillStatus retrieves backfill or redecode job status
+	GetBackfillStatus(ctx context.Context, in *BackfillStatusRequest, opts ...grpc.CallOption) (*BackfillJob, error)
+	// GetSystemStatus retrieves overall system status
+	GetSystemStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SystemStatusResponse, error)
+	// HealthCheck performs a health check
+	HealthCheck(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthCheckResponse, error)
+	// CreateWebhook registers an HTTP endpoint to receive matching events
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	// ListWebhooks lists registered webhooks
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	// DeleteWebhook removes a webhook and its delivery log
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	// ListWebhookDeliveries queries the delivery log, including dead letters
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// RetryWebhookDelivery requeues a dead-lettered delivery
+	RetryWebhookDelivery(ctx context.Context, in *RetryWebhookDeliveryRequest, opts ...grpc.CallOption) (*RetryWebhookDeliveryResponse, error)
+	// ListUnknownLogs queries the logs of a contract that could not be decoded
+	ListUnknownLogs(ctx context.Context, in *ListUnknownLogsRequest, opts ...grpc.CallOption) (*ListUnknownLogsResponse, error)
+	// RetryUnknownLogs has the indexer decode a contract's unknown logs again
+	RetryUnknownLogs(ctx context.Context, in *RetryUnknownLogsRequest, opts ...grpc.CallOption) (*RetryUnknownLogsResponse, error)
+	// CreateTopicSubscription indexes an event signature from any emitter
+	CreateTopicSubscription(ctx context.Context, in *CreateTopicSubscriptionRequest, opts ...grpc.CallOption) (*CreateTopicSubscriptionResponse, error)
+	// ListTopicSubscriptions lists topic subscriptions with their indexing position
+	ListTopicSubscriptions(ctx context.Context, in *ListTopicSubscriptionsRequest, opts ...grpc.CallOption) (*ListTopicSubscriptionsResponse, error)
+	// DeleteTopicSubscription stops a topic subscription, keeping its events
+	DeleteTopicSubscription(ctx context.Context, in *DeleteTopicSubscriptionRequest, opts ...grpc.CallOption) (*DeleteTopicSubscriptionResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) AddContract(ctx context.Context, in *AddContractRequest, opts ...grpc.CallOption) (*AddContractResponse, error) {
+	out := new(AddContractResponse)
+	err := c.cc.Invoke(ctx, AdminService_AddContract_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemoveContract(ctx context.Context, in *RemoveContractRequest, opts ...grpc.CallOption) (*RemoveContractResponse, error) {
+	out := new(RemoveContractResponse)
+	err := c.cc.Invoke(ctx, AdminService_RemoveContract_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetContractActive(ctx context.Context, in *SetContractActiveRequest, opts ...grpc.CallOption) (*SetContractActiveResponse, error) {
+	out := new(SetContractActiveResponse)
+	err := c.cc.Invoke(ctx, AdminService_SetContractActive_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateContractFilter(ctx context.Context, in *UpdateContractFilterRequest, opts ...grpc.CallOption) (*UpdateContractFilterResponse, error) {
+	out := new(UpdateContractFilterResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateContractFilter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AddContractABIVersion(ctx context.Context, in *AddContractABIVersionRequest, opts ...grpc.CallOption) (*AddContractABIVersionResponse, error) {
+	out := new(AddContractABIVersionResponse)
+	err := c.cc.Invoke(ctx, AdminService_AddContractABIVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListContractABIVersions(ctx context.Context, in *ListContractABIVersionsRequest, opts ...grpc.CallOption) (*ListContractABIVersionsResponse, error) {
+	out := new(ListContractABIVersionsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListContractABIVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AddContractFactory(ctx context.Context, in *AddContractFactoryRequest, opts ...grpc.CallOption) (*AddContractFactoryResponse, error) {
+	out := new(AddContractFactoryResponse)
+	err := c.cc.Invoke(ctx, AdminService_AddContractFactory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListContractFactories(ctx context.Context, in *ListContractFactoriesRequest, opts ...grpc.CallOption) (*ListContractFactoriesResponse, error) {
+	out := new(ListContractFactoriesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListContractFactories_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetContract(ctx context.Context, in *GetContractRequest, opts ...grpc.CallOption) (*Contract, error) {
+	out := new(Contract)
+	err := c.cc.Invoke(ctx, AdminService_GetContract_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListContracts(ctx context.Context, in *ListContractsRequest, opts ...grpc.CallOption) (*ListContractsResponse, error) {
+	out := new(ListContractsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListContracts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TriggerBackfill(ctx context.Context, in *BackfillRequest, opts ...grpc.CallOption) (*BackfillResponse, error) {
+	out := new(BackfillResponse)
+	err := c.cc.Invoke(ctx, AdminService_TriggerBackfill_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TriggerRedecode(ctx context.Context, in *RedecodeRequest, opts ...grpc.CallOption) (*BackfillResponse, error) {
+	out := new(BackfillResponse)
+	err := c.cc.Invoke(ctx, AdminService_TriggerRedecode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetBackfillStatus(ctx context.Context, in *BackfillStatusRequest, opts ...grpc.CallOption) (*BackfillJob, error) {
+	out := new(BackfillJob)
+	err := c.cc.Invoke(ctx, AdminService_GetBackfillStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetSystemStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SystemStatusResponse, error) {
+	out := new(SystemStatusResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetSystemStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) HealthCheck(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthCheckResponse, error) {
+	out := new(HealthCheckResponse)
+	err := c.cc.Invoke(ctx, AdminService_HealthCheck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListWebhooks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListWebhookDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RetryWebhookDelivery(ctx context.Context, in *RetryWebhookDeliveryRequest, opts ...grpc.CallOption) (*RetryWebhookDeliveryResponse, error) {
+	out := new(RetryWebhookDeliveryResponse)
+	err := c.cc.Invoke(ctx, AdminService_RetryWebhookDelivery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListUnknownLogs(ctx context.Context, in *ListUnknownLogsRequest, opts ...grpc.CallOption) (*ListUnknownLogsResponse, error) {
+	out := new(ListUnknownLogsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListUnknownLogs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RetryUnknownLogs(ctx context.Context, in *RetryUnknownLogsRequest, opts ...grpc.CallOption) (*RetryUnknownLogsResponse, error) {
+	out := new(RetryUnknownLogsResponse)
+	err := c.cc.Invoke(ctx, AdminService_RetryUnknownLogs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateTopicSubscription(ctx context.Context, in *CreateTopicSubscriptionRequest, opts ...grpc.CallOption) (*CreateTopicSubscriptionResponse, error) {
+	out := new(CreateTopicSubscriptionResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateTopicSubscription_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListTopicSubscriptions(ctx context.Context, in *ListTopicSubscriptionsRequest, opts ...grpc.CallOption) (*ListTopicSubscriptionsResponse, error) {
+	out := new(ListTopicSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListTopicSubscriptions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteTopicSubscription(ctx context.Context, in *DeleteTopicSubscriptionRequest, opts ...grpc.CallOption) (*DeleteTopicSubscriptionResponse, error) {
+	out := new(DeleteTopicSubscriptionResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteTopicSubscription_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	// AddContract adds a new contract to monitor
+	AddContract(context.Context, *AddContractRequest) (*AddContractResponse, error)
+	// RemoveContract removes a contract from monitoring
+	RemoveContract(context.Context, *RemoveContractRequest) (*RemoveContractResponse, error)
+	// SetContractActive pauses or resumes indexing for a contract without
+	// deleting its events or indexing position
+	SetContractActive(context.Context, *SetContractActiveRequest) (*SetContractActiveResponse, error)
+	// UpdateContractFilter replaces the event filter of a contract. The new
+	// filter applies to blocks indexed afterwards; stored events are kept.
+	UpdateContractFilter(context.Context, *UpdateContractFilterRequest) (*UpdateContractFilterResponse, error)
+	// AddContractABIVersion attaches a new ABI to an upgradeable proxy, either
+	// from a given block or from the proxy's Upgraded event to an implementation
+	AddContractABIVersion(context.Context, *AddContractABIVersionRequest) (*AddContractABIVersionResponse, error)
+	// ListContractABIVersions lists the ABI versions attached to a contract
+	ListContractABIVersions(context.Context, *ListContractABIVersionsRequest) (*ListContractABIVersionsResponse, error)
+	// AddContractFactory registers a rule that adds the contracts a factory
+	// creates, as the factory's creation events are indexed
+	AddContractFactory(context.Context, *AddContractFactoryRequest) (*AddContractFactoryResponse, error)
+	// ListContractFactories lists the factory rules of a contract
+	ListContractFactories(context.Context, *ListContractFactoriesRequest) (*ListContractFactoriesResponse, error)
+	// GetContract retrieves contract information
+	GetContract(context.Context, *GetContractRequest) (*Contract, error)
+	// ListContracts lists all monitored contracts
+	ListContracts(context.Context, *ListContractsRequest) (*ListContractsResponse, error)
+	// TriggerBackfill triggers historical data backfill
+	TriggerBackfill(context.Context, *BackfillRequest) (*BackfillResponse, error)
+	// TriggerRedecode queues a job that decodes a contract's stored events again
+	// from their raw logs with its current ABIs, without calling the RPC
+	TriggerRedecode(context.Context, *RedecodeRequest) (*BackfillResponse, error)
+	// GetBackfillStatus retrieves backfill or redecode job status
+	GetBackfillStatus(context.Context, *BackfillStatusRequest) (*BackfillJob, error)
+	// GetSystemStatus retrieves overall system status
+	GetSystemStatus(context.Context, *Empty) (*SystemStatusResponse, error)
+	// HealthCheck performs a health check
+	HealthCheck(context.Context, *Empty) (*HealthCheckResponse, error)
+	// CreateWebhook registers an HTTP endpoint to receive matching events
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	// ListWebhooks lists registered webhooks
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	// DeleteWebhook removes a webhook and its delivery log
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	// ListWebhookDeliveries queries the delivery log, including dead letters
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	// RetryWebhookDelivery requeues a dead-lettered delivery
+	RetryWebhookDelivery(context.Context, *RetryWebhookDeliveryRequest) (*RetryWebhookDeliveryResponse, error)
+	// ListUnknownLogs queries the logs of a contract that could not be decoded
+	ListUnknownLogs(context.Context, *ListUnknownLogsRequest) (*ListUnknownLogsResponse, error)
+	// RetryUnknownLogs has the indexer decode a contract's unknown logs again
+	RetryUnknownLogs(context.Context, *RetryUnknownLogsRequest) (*RetryUnknownLogsResponse, error)
+	// CreateTopicSubscription indexes an event signature from any emitter
+	CreateTopicSubscription(context.Context, *CreateTopicSubscriptionRequest) (*CreateTopicSubscriptionResponse, error)
+	// ListTopicSubscriptions lists topic subscriptions with their indexing position
+	ListTopicSubscriptions(context.Context, *ListTopicSubscriptionsRequest) (*ListTopicSubscriptionsResponse, error)
+	// DeleteTopicSubscription stops a topic subscription, keeping its events
+	DeleteTopicSubscription(context.Context, *DeleteTopicSubscriptionRequest) (*DeleteTopicSubscriptionResponse, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) AddContract(context.Context, *AddContractRequest) (*AddContractResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddContract not implemented")
+}
+func (UnimplementedAdminServiceServer) RemoveContract(context.Context, *RemoveContractRequest) (*RemoveContractResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveContract not implemented")
+}
+func (UnimplementedAdminServiceServer) SetContractActive(context.Context, *SetContractActiveRequest) (*SetContractActiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetContractActive not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateContractFilter(context.Context, *UpdateContractFilterRequest) (*UpdateContractFilterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateContractFilter not implemented")
+}
+func (UnimplementedAdminServiceServer) AddContractABIVersion(context.Context, *AddContractABIVersionRequest) (*AddContractABIVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddContractABIVersion not implemented")
+}
+func (UnimplementedAdminServiceServer) ListContractABIVersions(context.Context, *ListContractABIVersionsRequest) (*ListContractABIVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContractABIVersions not implemented")
+}
+func (UnimplementedAdminServiceServer) AddContractFactory(context.Context, *AddContractFactoryRequest) (*AddContractFactoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddContractFactory not implemented")
+}
+func (UnimplementedAdminServiceServer) ListContractFactories(context.Context, *ListContractFactoriesRequest) (*ListContractFactoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContractFactories not implemented")
+}
+func (UnimplementedAdminServiceServer) GetContract(context.Context, *GetContractRequest) (*Contract, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContract not implemented")
+}
+func (UnimplementedAdminServiceServer) ListContracts(context.Context, *ListContractsRequest) (*ListContractsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContracts not implemented")
+}
+func (UnimplementedAdminServiceServer) TriggerBackfill(context.Context, *BackfillRequest) (*BackfillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerBackfill not implemented")
+}
+func (UnimplementedAdminServiceServer) TriggerRedecode(context.Context, *RedecodeRequest) (*BackfillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerRedecode not implemented")
+}
+func (UnimplementedAdminServiceServer) GetBackfillStatus(context.Context, *BackfillStatusRequest) (*BackfillJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackfillStatus not implemented")
+}
+func (UnimplementedAdminServiceServer) GetSystemStatus(context.Context, *Empty) (*SystemStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSystemStatus not implemented")
+}
+func (UnimplementedAdminServiceServer) HealthCheck(context.Context, *Empty) (*HealthCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedAdminServiceServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedAdminServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedAdminServiceServer) RetryWebhookDelivery(context.Context, *RetryWebhookDeliveryRequest) (*RetryWebhookDeliveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryWebhookDelivery not implemented")
+}
+func (UnimplementedAdminServiceServer) ListUnknownLogs(context.Context, *ListUnknownLogsRequest) (*ListUnknownLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnknownLogs not implemented")
+}
+func (UnimplementedAdminServiceServer) RetryUnknownLogs(context.Context, *RetryUnknownLogsRequest) (*RetryUnknownLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryUnknownLogs not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateTopicSubscription(context.Context, *CreateTopicSubscriptionRequest) (*CreateTopicSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTopicSubscription not implemented")
+}
+func (UnimplementedAdminServiceServer) ListTopicSubscriptions(context.Context, *ListTopicSubscriptionsRequest) (*ListTopicSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopicSubscriptions not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteTopicSubscription(context.Context, *DeleteTopicSubscriptionRequest) (*DeleteTopicSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTopicSubscription not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_AddContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddContractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AddContract_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddContract(ctx, req.(*AddContractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemoveContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveContractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemoveContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RemoveContract_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemoveContract(ctx, req.(*RemoveContractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetContractActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetContractActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetContractActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetContractActive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetContractActive(ctx, req.(*SetContractActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateContractFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateContractFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateContractFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateContractFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateContractFilter(ctx, req.(*UpdateContractFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddContractABIVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddContractABIVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddContractABIVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AddContractABIVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddContractABIVersion(ctx, req.(*AddContractABIVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListContractABIVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContractABIVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListContractABIVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListContractABIVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListContractABIVersions(ctx, req.(*ListContractABIVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddContractFactory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddContractFactoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddContractFactory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AddContractFactory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddContractFactory(ctx, req.(*AddContractFactoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListContractFactories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContractFactoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListContractFactories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListContractFactories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListContractFactories(ctx, req.(*ListContractFactoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetContract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetContract_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetContract(ctx, req.(*GetContractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListContracts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContractsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListContracts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListContracts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListContracts(ctx, req.(*ListContractsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TriggerBackfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TriggerBackfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_TriggerBackfill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TriggerBackfill(ctx, req.(*BackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TriggerRedecode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedecodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TriggerRedecode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_TriggerRedecode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TriggerRedecode(ctx, req.(*RedecodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetBackfillStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetBackfillStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetBackfillStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetBackfillStatus(ctx, req.(*BackfillStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetSystemStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetSystemStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetSystemStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetSystemStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).HealthCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_HealthCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).HealthCheck(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RetryWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RetryWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RetryWebhookDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RetryWebhookDelivery(ctx, req.(*RetryWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListUnknownLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnknownLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListUnknownLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListUnknownLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListUnknownLogs(ctx, req.(*ListUnknownLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RetryUnknownLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryUnknownLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RetryUnknownLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RetryUnknownLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RetryUnknownLogs(ctx, req.(*RetryUnknownLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateTopicSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTopicSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateTopicSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateTopicSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateTopicSubscription(ctx, req.(*CreateTopicSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListTopicSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopicSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListTopicSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListTopicSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListTopicSubscriptions(ctx, req.(*ListTopicSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteTopicSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTopicSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteTopicSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteTopicSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteTopicSubscription(ctx, req.(*DeleteTopicSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddContract",
+			Handler:    _AdminService_AddContract_Handler,
+		},
+		{
+			MethodName: "RemoveContract",
+			Handler:    _AdminService_RemoveContract_Handler,
+		},
+		{
+			MethodName: "SetContractActive",
+			Handler:    _AdminService_SetContractActive_Handler,
+		},
+		{
+			MethodName: "UpdateContractFilter",
+			Handler:    _AdminService_UpdateContractFilter_Handler,
+		},
+		{
+			MethodName: "AddContractABIVersion",
+			Handler:    _AdminService_AddContractABIVersion_Handler,
+		},
+		{
+			MethodName: "ListContractABIVersions",
+			Handler:    _AdminService_ListContractABIVersions_Handler,
+		},
+		{
+			MethodName: "AddContractFactory",
+			Handler:    _AdminService_AddContractFactory_Handler,
+		},
+		{
+			MethodName: "ListContractFactories",
+			Handler:    _AdminService_ListContractFactories_Handler,
+		},
+		{
+			MethodName: "GetContract",
+			Handler:    _AdminService_GetContract_Handler,
+		},
+		{
+			MethodName: "ListContracts",
+			Handler:    _AdminService_ListContracts_Handler,
+		},
+		{
+			MethodName: "TriggerBackfill",
+			Handler:    _AdminService_TriggerBackfill_Handler,
+		},
+		{
+			MethodName: "TriggerRedecode",
+			Handler:    _AdminService_TriggerRedecode_Handler,
+		},
+		{
+			MethodName: "GetBackfillStatus",
+			Handler:    _AdminService_GetBackfillStatus_Handler,
+		},
+		{
+			MethodName: "GetSystemStatus",
+			Handler:    _AdminService_GetSystemStatus_Handler,
+		},
+		{
+			MethodName: "HealthCheck",
+			Handler:    _AdminService_HealthCheck_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _AdminService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _AdminService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _AdminService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _AdminService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RetryWebhookDelivery",
+			Handler:    _AdminService_RetryWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "ListUnknownLogs",
+			Handler:    _AdminService_ListUnknownLogs_Handler,
+		},
+		{
+			MethodName: "RetryUnknownLogs",
+			Handler:    _AdminService_RetryUnknownLogs_Handler,
+		},
+		{
+			MethodName: "CreateTopicSubscription",
+			Handler:    _AdminService_CreateTopicSubscription_Handler,
+		},
+		{
+			MethodName: "ListTopicSubscriptions",
+			Handler:    _AdminService_ListTopicSubscriptions_Handler,
+		},
+		{
+			MethodName: "DeleteTopicSubscription",
+			Handler:    _AdminService_DeleteTopicSubscription_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin_service.proto",
+}
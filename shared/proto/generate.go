@@ -0,0 +1,6 @@
+// Package proto holds the gRPC definitions shared by the services. The
+// generated stubs are checked in; run go generate in this directory after
+// editing a .proto file.
+package proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative admin_service.proto query_service.proto
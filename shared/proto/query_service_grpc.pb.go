@@ -0,0 +1,301 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: query_service.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	QueryService_GetEvents_FullMethodName              = "/proto.QueryService/GetEvents"
+	QueryService_GetEventsByAddress_FullMethodName     = "/proto.QueryService/GetEventsByAddress"
+	QueryService_GetEventsByTransaction_FullMethodName = "/proto.QueryService/GetEventsByTransaction"
+	QueryService_GetContractStats_FullMethodName       = "/proto.QueryService/GetContractStats"
+	QueryService_StreamEvents_FullMethodName           = "/proto.QueryService/StreamEvents"
+)
+
+// QueryServiceClient is the client API for QueryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type QueryServiceClient interface {
+	// GetEvents retrieves events based on filter criteria
+	GetEvents(ctx context.Context, in *EventQuery, opts ...grpc.CallOption) (*EventResponse, error)
+	// GetEventsByAddress retrieves events involving a specific address
+	GetEventsByAddress(ctx context.Context, in *AddressQuery, opts ...grpc.CallOption) (*EventResponse, error)
+	// GetEventsByTransaction retrieves events for a specific transaction
+	GetEventsByTransaction(ctx context.Context, in *TransactionQuery, opts ...grpc.CallOption) (*EventResponse, error)
+	// GetContractStats retrieves statistics for a contract
+	GetContractStats(ctx context.Context, in *StatsQuery, opts ...grpc.CallOption) (*StatsResponse, error)
+	// StreamEvents replays matching events in block order, starting at `from_block` or after
+	// the `after` cursor (a stream cursor, page cursor or event id), and with `follow` set keeps
+	// streaming new events in commit order as they are indexed. Reconnect with `after` set to
+	// the stream_cursor of the last received event to resume without gaps or duplicates.
+	StreamEvents(ctx context.Context, in *EventQuery, opts ...grpc.CallOption) (QueryService_StreamEventsClient, error)
+}
+
+type queryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQueryServiceClient(cc grpc.ClientConnInterface) QueryServiceClient {
+	return &queryServiceClient{cc}
+}
+
+func (c *queryServiceClient) GetEvents(ctx context.Context, in *EventQuery, opts ...grpc.CallOption) (*EventResponse, error) {
+	out := new(EventResponse)
+	err := c.cc.Invoke(ctx, QueryService_GetEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) GetEventsByAddress(ctx context.Context, in *AddressQuery, opts ...grpc.CallOption) (*EventResponse, error) {
+	out := new(EventResponse)
+	err := c.cc.Invoke(ctx, QueryService_GetEventsByAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) GetEventsByTransaction(ctx context.Context, in *TransactionQuery, opts ...grpc.CallOption) (*EventResponse, error) {
+	out := new(EventResponse)
+	err := c.cc.Invoke(ctx, QueryService_GetEventsByTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) GetContractStats(ctx context.Context, in *StatsQuery, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, QueryService_GetContractStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryServiceClient) StreamEvents(ctx context.Context, in *EventQuery, opts ...grpc.CallOption) (QueryService_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QueryService_ServiceDesc.Streams[0], QueryService_StreamEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryServiceStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QueryService_StreamEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type queryServiceStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryServiceStreamEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// QueryServiceServer is the server API for QueryService service.
+// All implementations must embed UnimplementedQueryServiceServer
+// for forward compatibility
+type QueryServiceServer interface {
+	// GetEvents retrieves events based on filter criteria
+	GetEvents(context.Context, *EventQuery) (*EventResponse, error)
+	// GetEventsByAddress retrieves events involving a specific address
+	GetEventsByAddress(context.Context, *AddressQuery) (*EventResponse, error)
+	// GetEventsByTransaction retrieves events for a specific transaction
+	GetEventsByTransaction(context.Context, *TransactionQuery) (*EventResponse, error)
+	// GetContractStats retrieves statistics for a contract
+	GetContractStats(context.Context, *StatsQuery) (*StatsResponse, error)
+	// StreamEvents replays matching events in block order, starting at `from_block` or after
+	// the `after` cursor (a stream cursor, page cursor or event id), and with `follow` set keeps
+	// streaming new events in commit order as they are indexed. Reconnect with `after` set to
+	// the stream_cursor of the last received event to resume without gaps or duplicates.
+	StreamEvents(*EventQuery, QueryService_StreamEventsServer) error
+	mustEmbedUnimplementedQueryServiceServer()
+}
+
+// UnimplementedQueryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedQueryServiceServer struct {
+}
+
+func (UnimplementedQueryServiceServer) GetEvents(context.Context, *EventQuery) (*EventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvents not implemented")
+}
+func (UnimplementedQueryServiceServer) GetEventsByAddress(context.Context, *AddressQuery) (*EventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventsByAddress not implemented")
+}
+func (UnimplementedQueryServiceServer) GetEventsByTransaction(context.Context, *TransactionQuery) (*EventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventsByTransaction not implemented")
+}
+func (UnimplementedQueryServiceServer) GetContractStats(context.Context, *StatsQuery) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContractStats not implemented")
+}
+func (UnimplementedQueryServiceServer) StreamEvents(*EventQuery, QueryService_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedQueryServiceServer) mustEmbedUnimplementedQueryServiceServer() {}
+
+// UnsafeQueryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QueryServiceServer will
+// result in compilation errors.
+type UnsafeQueryServiceServer interface {
+	mustEmbedUnimplementedQueryServiceServer()
+}
+
+func RegisterQueryServiceServer(s grpc.ServiceRegistrar, srv QueryServiceServer) {
+	s.RegisterService(&QueryService_ServiceDesc, srv)
+}
+
+func _QueryService_GetEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryService_GetEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetEvents(ctx, req.(*EventQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_GetEventsByAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetEventsByAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryService_GetEventsByAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetEventsByAddress(ctx, req.(*AddressQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_GetEventsByTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetEventsByTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryService_GetEventsByTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetEventsByTransaction(ctx, req.(*TransactionQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_GetContractStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).GetContractStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryService_GetContractStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).GetContractStats(ctx, req.(*StatsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QueryService_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServiceServer).StreamEvents(m, &queryServiceStreamEventsServer{stream})
+}
+
+type QueryService_StreamEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type queryServiceStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryServiceStreamEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// QueryService_ServiceDesc is the grpc.ServiceDesc for QueryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QueryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.QueryService",
+	HandlerType: (*QueryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEvents",
+			Handler:    _QueryService_GetEvents_Handler,
+		},
+		{
+			MethodName: "GetEventsByAddress",
+			Handler:    _QueryService_GetEventsByAddress_Handler,
+		},
+		{
+			MethodName: "GetEventsByTransaction",
+			Handler:    _QueryService_GetEventsByTransaction_Handler,
+		},
+		{
+			MethodName: "GetContractStats",
+			Handler:    _QueryService_GetContractStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _QueryService_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "query_service.proto",
+}
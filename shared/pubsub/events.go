@@ -0,0 +1,77 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/smart-contract-event-indexer/shared/models"
+	"github.com/smart-contract-event-indexer/shared/utils"
+)
+
+// EventChannelPrefix is the Redis pub/sub channel prefix for newly indexed
+// events. The indexer publishes to one channel per chain.
+const EventChannelPrefix = "events:new:"
+
+// EventChannelPattern matches the event channels of every chain
+const EventChannelPattern = EventChannelPrefix + "*"
+
+// EventChannel returns the channel newly indexed events of a chain are published to
+func EventChannel(chainID int64) string {
+	return fmt.Sprintf("%s%d", EventChannelPrefix, chainID)
+}
+
+// EventPublisher announces newly indexed events to live subscribers
+type EventPublisher struct {
+	client *redis.Client
+	logger utils.Logger
+}
+
+// NewEventPublisher creates a new event publisher
+func NewEventPublisher(client *redis.Client, logger utils.Logger) *EventPublisher {
+	return &EventPublisher{
+		client: client,
+		logger: logger,
+	}
+}
+
+// PublishEvents publishes a batch of events that has been committed to the
+// database. Each chain's events are sent as a single JSON array message.
+func (p *EventPublisher) PublishEvents(ctx context.Context, events []*models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	byChain := make(map[int64][]*models.Event)
+	for _, event := range events {
+		byChain[event.ChainID] = append(byChain[event.ChainID], event)
+	}
+
+	for chainID, batch := range byChain {
+		payload, err := json.Marshal(batch)
+		if err != nil {
+			return fmt.Errorf("failed to encode events: %w", err)
+		}
+
+		if err := p.client.Publish(ctx, EventChannel(chainID), payload).Err(); err != nil {
+			return fmt.Errorf("failed to publish events: %w", err)
+		}
+
+		p.logger.WithFields(map[string]interface{}{
+			"chain_id": chainID,
+			"count":    len(batch),
+		}).Debug("Published new events")
+	}
+
+	return nil
+}
+
+// DecodeEvents decodes a message published by PublishEvents
+func DecodeEvents(payload string) ([]*models.Event, error) {
+	var events []*models.Event
+	if err := json.Unmarshal([]byte(payload), &events); err != nil {
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+	return events, nil
+}